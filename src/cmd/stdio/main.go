@@ -107,6 +107,14 @@ func registerToolsFromMCP(stdioServer *mcp.Server, internalServer *internalmcp.S
 		// Create a wrapper function that calls our MCP server's tool handler
 		handler := func(toolName string, tool internalmcp.Tool) func(ctx context.Context, req *mcp.CallToolRequest, args map[string]interface{}) (*mcp.CallToolResult, any, error) {
 			return func(ctx context.Context, req *mcp.CallToolRequest, args map[string]interface{}) (*mcp.CallToolResult, any, error) {
+				// Re-decode the raw arguments so large integers are not
+				// rounded through float64
+				if req != nil && req.Params != nil && len(req.Params.Arguments) > 0 {
+					if exact, err := internalmcp.DecodeToolArguments(req.Params.Arguments); err == nil {
+						args = exact
+					}
+				}
+
 				// Call the tool handler from our MCP server
 				result, err := tool.Handler(ctx, args)
 				if err != nil {
@@ -114,6 +122,7 @@ func registerToolsFromMCP(stdioServer *mcp.Server, internalServer *internalmcp.S
 						zap.String("tool", toolName),
 						zap.Error(err))
 					return &mcp.CallToolResult{
+						Meta: mcp.Meta{"api_version": internalmcp.APIVersion},
 						Content: []mcp.Content{
 							&mcp.TextContent{Text: fmt.Sprintf("Error: %v", err)},
 						},
@@ -130,6 +139,7 @@ func registerToolsFromMCP(stdioServer *mcp.Server, internalServer *internalmcp.S
 				}
 
 				return &mcp.CallToolResult{
+					Meta: mcp.Meta{"api_version": internalmcp.APIVersion},
 					Content: []mcp.Content{
 						&mcp.TextContent{Text: string(resultJSON)},
 					},
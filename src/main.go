@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -92,38 +93,33 @@ func main() {
 		}
 	}()
 
-	// Parse CORS configuration with environment variable fallbacks
-	corsOriginsEnv := os.Getenv("CORS_ORIGINS")
-	if corsOriginsEnv != "" {
-		*corsOrigins = corsOriginsEnv
-	}
+	// CORS precedence: --cors-* flags > CORS_* environment variables >
+	// config file cors section > defaults
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
 
-	corsMethodsEnv := os.Getenv("CORS_METHODS")
-	if corsMethodsEnv != "" {
-		*corsMethods = corsMethodsEnv
+	corsConfig := mcp.CORSConfigFromConfig(cfg.CORS)
+	if value := corsSetting(setFlags, "cors-origins", *corsOrigins, "CORS_ORIGINS"); value != "" {
+		corsConfig.AllowedOrigins = strings.Split(value, ",")
 	}
-
-	corsHeadersEnv := os.Getenv("CORS_HEADERS")
-	if corsHeadersEnv != "" {
-		*corsHeaders = corsHeadersEnv
+	if value := corsSetting(setFlags, "cors-methods", *corsMethods, "CORS_METHODS"); value != "" {
+		corsConfig.AllowedMethods = strings.Split(value, ",")
 	}
-
-	corsMaxAgeEnv := os.Getenv("CORS_MAX_AGE")
-	if corsMaxAgeEnv != "" {
-		if maxAge, err := fmt.Sscanf(corsMaxAgeEnv, "%d", corsMaxAge); err == nil && maxAge == 1 {
-			// Successfully parsed
+	if value := corsSetting(setFlags, "cors-headers", *corsHeaders, "CORS_HEADERS"); value != "" {
+		corsConfig.AllowedHeaders = strings.Split(value, ",")
+	}
+	if setFlags["cors-max-age"] {
+		corsConfig.MaxAge = *corsMaxAge
+	} else if corsMaxAgeEnv := os.Getenv("CORS_MAX_AGE"); corsMaxAgeEnv != "" {
+		if maxAge, err := strconv.Atoi(corsMaxAgeEnv); err == nil && maxAge >= 0 {
+			corsConfig.MaxAge = maxAge
 		} else {
-			logger.Warn("Invalid CORS_MAX_AGE value, using default", zap.String("value", corsMaxAgeEnv))
+			logger.Warn("Invalid CORS_MAX_AGE value, ignoring it", zap.String("value", corsMaxAgeEnv))
 		}
 	}
 
-	corsConfig := &mcp.CORSConfig{
-		AllowedOrigins: strings.Split(*corsOrigins, ","),
-		AllowedMethods: strings.Split(*corsMethods, ","),
-		AllowedHeaders: strings.Split(*corsHeaders, ","),
-		MaxAge:         *corsMaxAge,
-	}
-
 	// Create MCP server
 	server, err := mcp.NewServer(cfg, logger)
 	if err != nil {
@@ -170,13 +166,17 @@ func main() {
 		}
 	}
 
-	// Create HTTP/HTTPS server
+	// Create HTTP/HTTPS server; responses may take as long as the longest tool timeout
 	addr := fmt.Sprintf("%s:%s", *host, *port)
+	writeTimeout := 30 * time.Second
+	if toolTimeout := cfg.OperationTimeouts.Max() + 5*time.Second; toolTimeout > writeTimeout {
+		writeTimeout = toolTimeout
+	}
 	httpServer := &http.Server{
 		Addr:         addr,
 		Handler:      server.Handler(),
 		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  120 * time.Second,
 	}
 
@@ -253,6 +253,11 @@ func main() {
 		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Wait for tool calls and async jobs still running, such as bulk deletes
+	if err := server.Drain(ctx); err != nil {
+		logger.Error("Tool calls did not finish before shutdown", zap.Error(err))
+	}
+
 	// Cleanup MCP server
 	if err := server.Cleanup(); err != nil {
 		logger.Error("Failed to cleanup MCP server", zap.Error(err))
@@ -260,3 +265,12 @@ func main() {
 
 	logger.Info("Server stopped")
 }
+
+// corsSetting returns a CORS flag's value when it was set on the command
+// line, otherwise the environment variable's value, or "" when neither is set
+func corsSetting(setFlags map[string]bool, flagName, flagValue, envName string) string {
+	if setFlags[flagName] {
+		return flagValue
+	}
+	return os.Getenv(envName)
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -24,11 +27,110 @@ const (
 	VectorDBTypeSupabase VectorDBType = "supabase"
 )
 
+// Weaviate authentication modes
+const (
+	AuthModeAPIKey = "api_key" // Authorization: Bearer <api_key>
+	AuthModeNone   = "none"    // anonymous access
+	AuthModeOIDC   = "oidc"    // OIDC client credentials flow
+)
+
+// Weaviate search fallbacks, tried in the configured order when a search mode fails
+const (
+	SearchFallbackHybrid = "hybrid" // hybrid search
+	SearchFallbackSimple = "simple" // where-clause text match; results have no score
+	SearchFallbackNone   = "none"   // no fallback, return an error
+)
+
+// SearchFallback is the search_fallback setting of a database. It accepts a
+// single value (e.g. none) or a list (e.g. [hybrid]).
+type SearchFallback []string
+
+// UnmarshalYAML accepts a scalar or a sequence
+func (f *SearchFallback) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*f = SearchFallback{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*f = list
+	return nil
+}
+
+// DefaultMaxDeleteAllDocuments is the number of documents delete_all_documents
+// may delete without an explicit confirm_count
+const DefaultMaxDeleteAllDocuments = 1000
+
+// DefaultMaxBatchSize is the number of documents batch_create_documents
+// accepts in one call
+const DefaultMaxBatchSize = 1000
+
+// DefaultMultiCollectionConcurrency is the number of collections processed at
+// once by operations that span all collections
+const DefaultMultiCollectionConcurrency = 5
+
+// DefaultMaxSSEConnections is the number of streaming connections (job
+// event streams and exports) served at once
+const DefaultMaxSSEConnections = 100
+
+// DefaultToolTimeout is the time an HTTP tool call may run when
+// operation_timeouts does not set one
+const DefaultToolTimeout = 30 * time.Second
+
+// OperationTimeoutDefaultKey is the operation_timeouts entry used for tools
+// without their own entry
+const OperationTimeoutDefaultKey = "default"
+
+// OperationTimeouts maps tool names, plus OperationTimeoutDefaultKey, to
+// timeouts in seconds
+type OperationTimeouts map[string]int
+
+// Default returns the timeout for tools without their own entry
+func (t OperationTimeouts) Default() time.Duration {
+	if seconds := t[OperationTimeoutDefaultKey]; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return DefaultToolTimeout
+}
+
+// For returns the timeout for a tool, falling back to Default
+func (t OperationTimeouts) For(tool string) time.Duration {
+	if seconds := t[tool]; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return t.Default()
+}
+
+// Max returns the longest configured timeout, or Default when none is longer
+func (t OperationTimeouts) Max() time.Duration {
+	longest := t.Default()
+	for tool := range t {
+		if timeout := t.For(tool); timeout > longest {
+			longest = timeout
+		}
+	}
+	return longest
+}
+
+// validate rejects negative timeouts
+func (t OperationTimeouts) validate() error {
+	for tool, seconds := range t {
+		if seconds < 0 {
+			return fmt.Errorf("operation_timeouts.%s must not be negative (got %d)", tool, seconds)
+		}
+	}
+	return nil
+}
+
 // Collection represents a collection configuration
 type Collection struct {
-	Name        string `yaml:"name"`
-	Type        string `yaml:"type"`
-	Description string `yaml:"description,omitempty"`
+	Name          string   `yaml:"name"`
+	Type          string   `yaml:"type"`
+	Description   string   `yaml:"description,omitempty"`
+	URLField      string   `yaml:"url_field,omitempty"`      // Property holding the document source locator (default: auto-detect)
+	ContentFields []string `yaml:"content_fields,omitempty"` // Properties tried, in order, for document content (default: the database's content_fields)
 }
 
 // MockCollection represents a mock collection (for backward compatibility)
@@ -46,20 +148,38 @@ type MockConfig struct {
 	Collections        []MockCollection `yaml:"collections"`
 }
 
+// OIDCConfig holds OIDC client credentials used to obtain Weaviate access tokens
+type OIDCConfig struct {
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes,omitempty"`
+}
+
 // VectorDBConfig holds vector database configuration
 type VectorDBConfig struct {
-	Name               string       `yaml:"name"`
-	Type               VectorDBType `yaml:"type"`
-	URL                string       `yaml:"url,omitempty"`
-	APIKey             string       `yaml:"api_key,omitempty"`
-	OpenAIAPIKey       string       `yaml:"openai_api_key,omitempty"`
-	DatabaseURL        string       `yaml:"database_url,omitempty"` // Supabase: PostgreSQL connection URL
-	DatabaseKey        string       `yaml:"database_key,omitempty"` // Supabase: service role key or anon key
-	Timeout            int          `yaml:"timeout,omitempty"`      // Connection timeout in seconds
-	Enabled            bool         `yaml:"enabled,omitempty"`
-	SimulateEmbeddings bool         `yaml:"simulate_embeddings,omitempty"`
-	EmbeddingDimension int          `yaml:"embedding_dimension,omitempty"`
-	Collections        []Collection `yaml:"collections"`
+	Name                string         `yaml:"name"`
+	Type                VectorDBType   `yaml:"type"`
+	URL                 string         `yaml:"url,omitempty"`
+	APIKey              string         `yaml:"api_key,omitempty"`
+	AuthMode            string         `yaml:"auth_mode,omitempty"` // api_key, none, or oidc (default: api_key if api_key is set, else none)
+	OIDC                *OIDCConfig    `yaml:"oidc,omitempty"`
+	SearchFallback      SearchFallback `yaml:"search_fallback,omitempty"`         // Weaviate: hybrid, simple, or none (default: [hybrid, simple])
+	MaxFallbackDepth    int            `yaml:"max_fallback_depth,omitempty"`      // Weaviate: fallbacks tried before failing (default: the whole chain)
+	ScoreNormalization  string         `yaml:"score_normalization,omitempty"`     // Weaviate: none, quadratic, linear, or sigmoid (default: quadratic)
+	MaxIdleConnsPerHost int            `yaml:"max_idle_conns_per_host,omitempty"` // Weaviate: idle REST connections kept for reuse (default: 16)
+	IdleConnTimeout     int            `yaml:"idle_conn_timeout,omitempty"`       // Weaviate: seconds before idle REST connections close (default: 90)
+	ContentFields       []string       `yaml:"content_fields,omitempty"`          // Properties tried, in order, for document content (default: text, content, body, description, title, name, chunk, pageContent, document)
+	OpenAIAPIKey        string         `yaml:"openai_api_key,omitempty"`
+	DatabaseURL         string         `yaml:"database_url,omitempty"` // Supabase: PostgreSQL connection URL
+	DatabaseKey         string         `yaml:"database_key,omitempty"` // Supabase: service role key or anon key
+	Timeout             int            `yaml:"timeout,omitempty"`      // Connection timeout in seconds
+	Enabled             bool           `yaml:"enabled,omitempty"`
+	SimulateEmbeddings  bool           `yaml:"simulate_embeddings,omitempty"`
+	EmbeddingDimension  int            `yaml:"embedding_dimension,omitempty"`
+	EmbeddingTimeout    int            `yaml:"embedding_timeout,omitempty"` // Extra seconds allowed for server-side vectorization
+	EmbeddingRetries    int            `yaml:"embedding_retries,omitempty"` // Retries on embedding provider 429/5xx (0 = default, negative disables)
+	Collections         []Collection   `yaml:"collections"`
 }
 
 // SchemaDefinition represents a named schema that can be used to create collections
@@ -69,6 +189,37 @@ type SchemaDefinition struct {
 	Metadata map[string]interface{} `yaml:"metadata,omitempty"`
 }
 
+// CollectionTemplate is a named preset of create_collection parameters.
+// Fields use snake_case because the config loader lowercases map keys.
+type CollectionTemplate struct {
+	Name              string                     `yaml:"name"`
+	Type              string                     `yaml:"type,omitempty"` // text or image
+	Description       string                     `yaml:"description,omitempty"`
+	Vectorizer        string                     `yaml:"vectorizer,omitempty"`
+	VectorIndexConfig *TemplateVectorIndexConfig `yaml:"vector_index_config,omitempty"`
+	Properties        []TemplateProperty         `yaml:"properties,omitempty"`
+	// Tokenization of the default text, url, metadata, and image properties;
+	// custom properties set their own
+	Tokenization map[string]string `yaml:"tokenization,omitempty"`
+}
+
+// TemplateVectorIndexConfig holds the HNSW settings of a collection template
+// (zero values are left to Weaviate)
+type TemplateVectorIndexConfig struct {
+	Distance       string `yaml:"distance,omitempty"`
+	EfConstruction int    `yaml:"ef_construction,omitempty"`
+	MaxConnections int    `yaml:"max_connections,omitempty"`
+	Ef             int    `yaml:"ef,omitempty"`
+}
+
+// TemplateProperty is a custom property of a collection template
+type TemplateProperty struct {
+	Name         string `yaml:"name"`
+	DataType     string `yaml:"data_type"`
+	Description  string `yaml:"description,omitempty"`
+	Tokenization string `yaml:"tokenization,omitempty"`
+}
+
 // DatabasesConfig holds multiple databases configuration
 type DatabasesConfig struct {
 	Default         string             `yaml:"default"`
@@ -84,11 +235,79 @@ type TLSConfig struct {
 	AutoRedirect bool   `yaml:"auto_redirect,omitempty"`
 }
 
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// CORSConfig holds the HTTP server's CORS settings. Empty fields keep the
+// server defaults; CORS_* environment variables and --cors-* flags override
+// them.
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins,omitempty"`
+	AllowedMethods []string `yaml:"allowed_methods,omitempty"`
+	AllowedHeaders []string `yaml:"allowed_headers,omitempty"`
+	MaxAge         int      `yaml:"max_age,omitempty"` // Preflight cache lifetime in seconds
+}
+
+// validate rejects a negative max_age
+func (c CORSConfig) validate() error {
+	if c.MaxAge < 0 {
+		return fmt.Errorf("cors.max_age must not be negative (got %d)", c.MaxAge)
+	}
+	return nil
+}
+
 // Config holds the complete application configuration
 type Config struct {
-	Databases  DatabasesConfig `yaml:"databases"`
-	SchemasDir string          `yaml:"schemas_dir,omitempty"`
-	TLS        TLSConfig       `yaml:"tls,omitempty"`
+	Databases    DatabasesConfig `yaml:"databases"`
+	SchemasDir   string          `yaml:"schemas_dir,omitempty"`
+	WatchSchemas bool            `yaml:"watch_schemas,omitempty"` // Reload schemas when files in SchemasDir change
+	TLS          TLSConfig       `yaml:"tls,omitempty"`
+
+	// Metrics enables the Prometheus /metrics endpoint (default: disabled)
+	Metrics MetricsConfig `yaml:"metrics,omitempty"`
+
+	// CORS sets the HTTP server's CORS defaults, below CORS_* environment
+	// variables and --cors-* flags in precedence
+	CORS CORSConfig `yaml:"cors,omitempty"`
+
+	// MaxDeleteAllDocuments caps delete_all_documents without confirmation
+	// (0 uses DefaultMaxDeleteAllDocuments, negative disables the cap)
+	MaxDeleteAllDocuments int `yaml:"max_delete_all_documents,omitempty"`
+
+	// MaxBatchSize caps the documents in one batch_create_documents call
+	// (0 uses DefaultMaxBatchSize, negative disables the cap)
+	MaxBatchSize int `yaml:"max_batch_size,omitempty"`
+
+	// MultiCollectionConcurrency bounds the collections processed at once by
+	// operations that span all collections (0 or less uses
+	// DefaultMultiCollectionConcurrency)
+	MultiCollectionConcurrency int `yaml:"multi_collection_concurrency,omitempty"`
+
+	// MaxSSEConnections caps the long-lived streaming connections (GET
+	// /mcp/jobs/events and GET /mcp/export) served at once; further requests
+	// get 503 (0 uses DefaultMaxSSEConnections, negative disables the cap)
+	MaxSSEConnections int `yaml:"max_sse_connections,omitempty"`
+
+	// CollectionTemplates are named create_collection presets; more can be
+	// saved at runtime with save_collection_template
+	CollectionTemplates []CollectionTemplate `yaml:"collection_templates,omitempty"`
+
+	// OperationTimeouts sets per-tool timeouts in seconds for HTTP tool calls,
+	// with a "default" entry for other tools (default: DefaultToolTimeout)
+	OperationTimeouts OperationTimeouts `yaml:"operation_timeouts,omitempty"`
+
+	// DefaultCollection is used by document and query tools when a call
+	// omits collection; set_server_default_collection changes it at runtime
+	DefaultCollection string `yaml:"default_collection,omitempty"`
+
+	// schemasMu guards Databases.Schemas against concurrent reloads
+	schemasMu sync.RWMutex
+	// templatesMu guards CollectionTemplates against concurrent saves
+	templatesMu sync.RWMutex
+	// directorySchemas records the schema names loaded from SchemasDir
+	directorySchemas map[string]bool
 }
 
 // LoadConfig loads configuration from files and environment variables
@@ -186,6 +405,18 @@ func LoadConfigWithOptions(opts LoadConfigOptions) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal interpolated config: %w", err)
 	}
 
+	if err := config.OperationTimeouts.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.CORS.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateCollectionTemplates(config.CollectionTemplates); err != nil {
+		return nil, err
+	}
+
 	// Load schemas from directory if schemas_dir is specified
 	if config.SchemasDir != "" {
 		if err := config.loadSchemasFromDirectory(); err != nil {
@@ -324,6 +555,119 @@ func (c *Config) GetDatabase(name string) (*VectorDBConfig, error) {
 	return nil, fmt.Errorf("database '%s' not found", name)
 }
 
+// GetCollection returns the configuration for a collection, or nil if the
+// collection is not configured for this database
+func (d *VectorDBConfig) GetCollection(name string) *Collection {
+	for i := range d.Collections {
+		if d.Collections[i].Name == name {
+			return &d.Collections[i]
+		}
+	}
+	return nil
+}
+
+// ResolvedAuthMode returns the Weaviate authentication mode of the database,
+// defaulting to api_key when an API key is set and none otherwise, and checks
+// that the settings the mode needs are present
+func (d *VectorDBConfig) ResolvedAuthMode() (string, error) {
+	mode := d.AuthMode
+	if mode == "" {
+		if d.APIKey != "" {
+			return AuthModeAPIKey, nil
+		}
+		return AuthModeNone, nil
+	}
+
+	switch mode {
+	case AuthModeAPIKey:
+		if d.APIKey == "" {
+			return "", fmt.Errorf("database '%s': auth_mode api_key requires api_key", d.Name)
+		}
+	case AuthModeNone:
+	case AuthModeOIDC:
+		if d.OIDC == nil || d.OIDC.TokenURL == "" || d.OIDC.ClientID == "" || d.OIDC.ClientSecret == "" {
+			return "", fmt.Errorf("database '%s': auth_mode oidc requires oidc.token_url, oidc.client_id, and oidc.client_secret", d.Name)
+		}
+	default:
+		return "", fmt.Errorf("database '%s': unknown auth_mode '%s' (expected api_key, none, or oidc)", d.Name, mode)
+	}
+	return mode, nil
+}
+
+// ResolvedSearchFallback returns the Weaviate search fallback chain of the
+// database: nil when unset (use the default chain) and empty for none
+func (d *VectorDBConfig) ResolvedSearchFallback() ([]string, error) {
+	if d.SearchFallback == nil {
+		return nil, nil
+	}
+
+	chain := []string{}
+	seen := make(map[string]bool, len(d.SearchFallback))
+	for _, mode := range d.SearchFallback {
+		switch mode {
+		case SearchFallbackNone:
+			if len(d.SearchFallback) > 1 {
+				return nil, fmt.Errorf("database '%s': search_fallback none cannot be combined with other fallbacks", d.Name)
+			}
+			continue
+		case SearchFallbackHybrid, SearchFallbackSimple:
+		default:
+			return nil, fmt.Errorf("database '%s': unknown search_fallback '%s' (expected hybrid, simple, or none)", d.Name, mode)
+		}
+		if seen[mode] {
+			return nil, fmt.Errorf("database '%s': search_fallback '%s' is listed more than once", d.Name, mode)
+		}
+		seen[mode] = true
+		chain = append(chain, mode)
+	}
+	return chain, nil
+}
+
+// DeleteAllDocumentsCap returns the number of documents delete_all_documents
+// may delete without confirmation, or -1 when the cap is disabled
+func (c *Config) DeleteAllDocumentsCap() int {
+	switch {
+	case c.MaxDeleteAllDocuments < 0:
+		return -1
+	case c.MaxDeleteAllDocuments == 0:
+		return DefaultMaxDeleteAllDocuments
+	}
+	return c.MaxDeleteAllDocuments
+}
+
+// BatchSizeLimit returns the number of documents batch_create_documents
+// accepts in one call, or -1 when the cap is disabled
+func (c *Config) BatchSizeLimit() int {
+	switch {
+	case c.MaxBatchSize < 0:
+		return -1
+	case c.MaxBatchSize == 0:
+		return DefaultMaxBatchSize
+	}
+	return c.MaxBatchSize
+}
+
+// CollectionConcurrency returns the number of collections operations that
+// span all collections process at once
+func (c *Config) CollectionConcurrency() int {
+	if c.MultiCollectionConcurrency <= 0 {
+		return DefaultMultiCollectionConcurrency
+	}
+	return c.MultiCollectionConcurrency
+}
+
+// SSEConnectionLimit returns the number of streaming connections served at
+// once, or -1 when the cap is disabled
+func (c *Config) SSEConnectionLimit() int {
+	switch {
+	case c.MaxSSEConnections < 0:
+		return -1
+	case c.MaxSSEConnections == 0:
+		return DefaultMaxSSEConnections
+	}
+	return c.MaxSSEConnections
+}
+
 // ListDatabases returns a list of all configured database names
 func (c *Config) ListDatabases() []string {
 	if len(c.Databases.VectorDatabases) == 0 {
@@ -354,6 +698,9 @@ func (c *Config) GetDatabaseNames() map[string]VectorDBType {
 
 // GetSchema returns a specific schema definition by name
 func (c *Config) GetSchema(name string) (*SchemaDefinition, error) {
+	c.schemasMu.RLock()
+	defer c.schemasMu.RUnlock()
+
 	if len(c.Databases.Schemas) == 0 {
 		return nil, fmt.Errorf("no schemas configured")
 	}
@@ -369,6 +716,9 @@ func (c *Config) GetSchema(name string) (*SchemaDefinition, error) {
 
 // ListSchemas returns a list of all configured schema names
 func (c *Config) ListSchemas() []string {
+	c.schemasMu.RLock()
+	defer c.schemasMu.RUnlock()
+
 	if len(c.Databases.Schemas) == 0 {
 		return []string{}
 	}
@@ -383,54 +733,177 @@ func (c *Config) ListSchemas() []string {
 
 // GetAllSchemas returns all configured schema definitions
 func (c *Config) GetAllSchemas() []SchemaDefinition {
+	c.schemasMu.RLock()
+	defer c.schemasMu.RUnlock()
+
 	return c.Databases.Schemas
 }
 
+// validateCollectionTemplates checks that templates are named and that the
+// names are unique
+func validateCollectionTemplates(templates []CollectionTemplate) error {
+	seen := make(map[string]bool, len(templates))
+	for i, template := range templates {
+		if template.Name == "" {
+			return fmt.Errorf("collection_templates[%d] has no name", i)
+		}
+		if seen[template.Name] {
+			return fmt.Errorf("duplicate collection template '%s'", template.Name)
+		}
+		seen[template.Name] = true
+	}
+	return nil
+}
+
+// GetCollectionTemplate returns a copy of the named collection template
+func (c *Config) GetCollectionTemplate(name string) (*CollectionTemplate, error) {
+	c.templatesMu.RLock()
+	defer c.templatesMu.RUnlock()
+
+	for _, template := range c.CollectionTemplates {
+		if template.Name == name {
+			return &template, nil
+		}
+	}
+	return nil, fmt.Errorf("collection template '%s' not found", name)
+}
+
+// ListCollectionTemplates returns the collection templates, sorted by name
+func (c *Config) ListCollectionTemplates() []CollectionTemplate {
+	c.templatesMu.RLock()
+	defer c.templatesMu.RUnlock()
+
+	templates := make([]CollectionTemplate, len(c.CollectionTemplates))
+	copy(templates, c.CollectionTemplates)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// SaveCollectionTemplate adds a collection template, or replaces the one with
+// the same name, reporting whether it replaced one. Saved templates are kept
+// in memory only and are not written back to config.yaml.
+func (c *Config) SaveCollectionTemplate(template CollectionTemplate) (bool, error) {
+	if template.Name == "" {
+		return false, fmt.Errorf("template name is required")
+	}
+
+	c.templatesMu.Lock()
+	defer c.templatesMu.Unlock()
+
+	for i := range c.CollectionTemplates {
+		if c.CollectionTemplates[i].Name == template.Name {
+			c.CollectionTemplates[i] = template
+			return true, nil
+		}
+	}
+	c.CollectionTemplates = append(c.CollectionTemplates, template)
+	return false, nil
+}
+
 // loadSchemasFromDirectory loads schema files from the schemas directory
 // Schemas defined in config.yaml take precedence over directory schemas with same name
 func (c *Config) loadSchemasFromDirectory() error {
+	dirSchemas, err := c.readSchemasDirectory()
+	if err != nil {
+		return err
+	}
+
+	c.mergeDirectorySchemas(dirSchemas)
+	return nil
+}
+
+// ReloadSchemas re-reads the schemas directory, replacing previously loaded
+// directory schemas so added, edited, and deleted files take effect. Schemas
+// defined in config.yaml keep precedence. If any file fails to load, the
+// current schemas are left unchanged. Returns the current schema names.
+func (c *Config) ReloadSchemas() ([]string, error) {
+	if c.SchemasDir == "" {
+		return nil, fmt.Errorf("schemas_dir is not configured")
+	}
+
+	dirSchemas, err := c.readSchemasDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	c.schemasMu.Lock()
+	// Drop the previous directory schemas, keeping those from config.yaml
+	configSchemas := make([]SchemaDefinition, 0, len(c.Databases.Schemas))
+	for _, schema := range c.Databases.Schemas {
+		if !c.directorySchemas[schema.Name] {
+			configSchemas = append(configSchemas, schema)
+		}
+	}
+	c.Databases.Schemas = configSchemas
+	c.directorySchemas = nil
+	c.schemasMu.Unlock()
+
+	c.mergeDirectorySchemas(dirSchemas)
+	return c.ListSchemas(), nil
+}
+
+// readSchemasDirectory reads and parses all YAML files in the schemas directory
+func (c *Config) readSchemasDirectory() ([]SchemaDefinition, error) {
 	// Check if directory exists
 	if _, err := os.Stat(c.SchemasDir); os.IsNotExist(err) {
 		// Directory doesn't exist, skip loading
-		return nil
+		return nil, nil
 	}
 
 	// Read all YAML files in the directory
 	files, err := filepath.Glob(filepath.Join(c.SchemasDir, "*.yaml"))
 	if err != nil {
-		return fmt.Errorf("failed to glob schema files: %w", err)
+		return nil, fmt.Errorf("failed to glob schema files: %w", err)
 	}
 
 	// Also check for .yml extension
 	ymlFiles, err := filepath.Glob(filepath.Join(c.SchemasDir, "*.yml"))
 	if err != nil {
-		return fmt.Errorf("failed to glob schema files: %w", err)
+		return nil, fmt.Errorf("failed to glob schema files: %w", err)
 	}
 	files = append(files, ymlFiles...)
 
-	// Build a map of existing schema names for precedence checking
-	existingSchemas := make(map[string]bool)
-	for _, schema := range c.Databases.Schemas {
-		existingSchemas[schema.Name] = true
-	}
-
 	// Load each schema file
+	schemas := make([]SchemaDefinition, 0, len(files))
 	for _, file := range files {
 		schemaData, err := os.ReadFile(file)
 		if err != nil {
-			return fmt.Errorf("failed to read schema file %s: %w", file, err)
+			return nil, fmt.Errorf("failed to read schema file %s: %w", file, err)
 		}
 
 		var schemaDef SchemaDefinition
 		if err := yaml.Unmarshal(schemaData, &schemaDef); err != nil {
-			return fmt.Errorf("failed to parse schema file %s: %w", file, err)
+			return nil, fmt.Errorf("failed to parse schema file %s: %w", file, err)
 		}
+		schemas = append(schemas, schemaDef)
+	}
+
+	return schemas, nil
+}
 
+// mergeDirectorySchemas adds directory schemas not already defined in config.yaml
+func (c *Config) mergeDirectorySchemas(dirSchemas []SchemaDefinition) {
+	c.schemasMu.Lock()
+	defer c.schemasMu.Unlock()
+
+	// Build a map of existing schema names for precedence checking
+	existingSchemas := make(map[string]bool)
+	for _, schema := range c.Databases.Schemas {
+		existingSchemas[schema.Name] = true
+	}
+
+	if c.directorySchemas == nil {
+		c.directorySchemas = make(map[string]bool)
+	}
+
+	// Build a new slice so schemas handed out by GetSchema are never modified
+	schemas := append([]SchemaDefinition{}, c.Databases.Schemas...)
+	for _, schemaDef := range dirSchemas {
 		// Only add if not already defined in config.yaml (precedence)
 		if !existingSchemas[schemaDef.Name] {
-			c.Databases.Schemas = append(c.Databases.Schemas, schemaDef)
+			schemas = append(schemas, schemaDef)
+			c.directorySchemas[schemaDef.Name] = true
 		}
 	}
-
-	return nil
+	c.Databases.Schemas = schemas
 }
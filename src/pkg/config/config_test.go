@@ -6,7 +6,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	yaml "gopkg.in/yaml.v3"
 )
@@ -244,3 +247,494 @@ func TestGetAllSchemas(t *testing.T) {
 		t.Error("Schema names don't match expected values")
 	}
 }
+
+func TestGetCollection(t *testing.T) {
+	dbConfig := &VectorDBConfig{
+		Collections: []Collection{
+			{Name: "Docs", Type: "text", URLField: "source"},
+		},
+	}
+
+	collection := dbConfig.GetCollection("Docs")
+	if collection == nil {
+		t.Fatal("Expected collection, got nil")
+	}
+	if collection.URLField != "source" {
+		t.Errorf("Expected url_field 'source', got '%s'", collection.URLField)
+	}
+
+	if dbConfig.GetCollection("Missing") != nil {
+		t.Error("Expected nil for unconfigured collection")
+	}
+}
+
+func TestReloadSchemas(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeSchema := func(file, name, class string) {
+		data, _ := yaml.Marshal(SchemaDefinition{
+			Name:   name,
+			Schema: map[string]interface{}{"class": class},
+		})
+		if err := os.WriteFile(filepath.Join(tmpDir, file), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+
+	writeSchema("first.yaml", "First", "FirstV1")
+	writeSchema("override.yaml", "Inline", "FromDirectory")
+
+	config := &Config{
+		SchemasDir: tmpDir,
+		Databases: DatabasesConfig{
+			Schemas: []SchemaDefinition{
+				{Name: "Inline", Schema: map[string]interface{}{"class": "FromConfig"}},
+			},
+		},
+	}
+	if err := config.loadSchemasFromDirectory(); err != nil {
+		t.Fatalf("Failed to load schemas from directory: %v", err)
+	}
+
+	// Add a schema, edit one, and reload
+	writeSchema("second.yaml", "Second", "Second")
+	writeSchema("first.yaml", "First", "FirstV2")
+
+	names, err := config.ReloadSchemas()
+	if err != nil {
+		t.Fatalf("Failed to reload schemas: %v", err)
+	}
+	if len(names) != 3 {
+		t.Errorf("Expected 3 schemas after reload, got %d: %v", len(names), names)
+	}
+
+	first, err := config.GetSchema("First")
+	if err != nil {
+		t.Fatalf("First schema not found after reload: %v", err)
+	}
+	if first.Schema["class"] != "FirstV2" {
+		t.Errorf("Expected edited class 'FirstV2', got '%v'", first.Schema["class"])
+	}
+
+	inline, err := config.GetSchema("Inline")
+	if err != nil {
+		t.Fatalf("Inline schema not found after reload: %v", err)
+	}
+	if inline.Schema["class"] != "FromConfig" {
+		t.Errorf("Expected class 'FromConfig', got '%v' - inline schema should take precedence", inline.Schema["class"])
+	}
+
+	// Removing a file removes its schema on the next reload
+	if err := os.Remove(filepath.Join(tmpDir, "second.yaml")); err != nil {
+		t.Fatalf("Failed to remove second.yaml: %v", err)
+	}
+	names, err = config.ReloadSchemas()
+	if err != nil {
+		t.Fatalf("Failed to reload schemas: %v", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("Expected 2 schemas after removing a file, got %d: %v", len(names), names)
+	}
+
+	// A broken file leaves the current schemas unchanged
+	if err := os.WriteFile(filepath.Join(tmpDir, "broken.yaml"), []byte("name: [unclosed"), 0644); err != nil {
+		t.Fatalf("Failed to write broken.yaml: %v", err)
+	}
+	if _, err := config.ReloadSchemas(); err == nil {
+		t.Error("Expected an error for a broken schema file")
+	}
+	if len(config.ListSchemas()) != 2 {
+		t.Errorf("Expected schemas to be unchanged after a failed reload, got %v", config.ListSchemas())
+	}
+}
+
+func TestReloadSchemas_NoSchemasDir(t *testing.T) {
+	config := &Config{}
+	if _, err := config.ReloadSchemas(); err == nil {
+		t.Error("Expected an error when schemas_dir is not configured")
+	}
+}
+
+func TestDeleteAllDocumentsCap(t *testing.T) {
+	tests := []struct {
+		configured int
+		expected   int
+	}{
+		{0, DefaultMaxDeleteAllDocuments},
+		{50, 50},
+		{-1, -1},
+		{-10, -1},
+	}
+
+	for _, tt := range tests {
+		config := &Config{MaxDeleteAllDocuments: tt.configured}
+		if got := config.DeleteAllDocumentsCap(); got != tt.expected {
+			t.Errorf("DeleteAllDocumentsCap() with %d = %d, expected %d", tt.configured, got, tt.expected)
+		}
+	}
+}
+
+func TestBatchSizeLimit(t *testing.T) {
+	tests := []struct {
+		configured int
+		expected   int
+	}{
+		{0, DefaultMaxBatchSize},
+		{100, 100},
+		{-1, -1},
+	}
+
+	for _, tt := range tests {
+		config := &Config{MaxBatchSize: tt.configured}
+		if got := config.BatchSizeLimit(); got != tt.expected {
+			t.Errorf("BatchSizeLimit() with %d = %d, expected %d", tt.configured, got, tt.expected)
+		}
+	}
+}
+
+func TestCollectionConcurrency(t *testing.T) {
+	tests := []struct {
+		configured int
+		expected   int
+	}{
+		{0, DefaultMultiCollectionConcurrency},
+		{2, 2},
+		{-1, DefaultMultiCollectionConcurrency},
+	}
+
+	for _, tt := range tests {
+		config := &Config{MultiCollectionConcurrency: tt.configured}
+		if got := config.CollectionConcurrency(); got != tt.expected {
+			t.Errorf("CollectionConcurrency() with %d = %d, expected %d", tt.configured, got, tt.expected)
+		}
+	}
+}
+
+func TestSSEConnectionLimit(t *testing.T) {
+	tests := []struct {
+		configured int
+		expected   int
+	}{
+		{0, DefaultMaxSSEConnections},
+		{10, 10},
+		{-1, -1},
+	}
+
+	for _, tt := range tests {
+		config := &Config{MaxSSEConnections: tt.configured}
+		if got := config.SSEConnectionLimit(); got != tt.expected {
+			t.Errorf("SSEConnectionLimit() with %d = %d, expected %d", tt.configured, got, tt.expected)
+		}
+	}
+}
+
+// loadTestConfig writes content to a config file and loads it
+func loadTestConfig(t *testing.T, content string) (*Config, error) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.yaml")
+	envFile := filepath.Join(tmpDir, ".env")
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config.yaml: %v", err)
+	}
+	if err := os.WriteFile(envFile, nil, 0644); err != nil {
+		t.Fatalf("Failed to write .env: %v", err)
+	}
+	return LoadConfig(configFile, envFile)
+}
+
+func TestLoadOperationTimeouts(t *testing.T) {
+	config, err := loadTestConfig(t, `
+databases:
+  default: weaviate-local
+  vector_databases:
+    - name: weaviate-local
+      type: weaviate-local
+      url: http://localhost:8080
+
+operation_timeouts:
+  default: 45
+  create_collection: 60
+  delete_all_documents: 600
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		tool     string
+		expected time.Duration
+	}{
+		{"create_collection", 60 * time.Second},
+		{"delete_all_documents", 600 * time.Second},
+		{"query_documents", 45 * time.Second},
+		{"unknown_tool", 45 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := config.OperationTimeouts.For(tt.tool); got != tt.expected {
+			t.Errorf("OperationTimeouts.For(%q) = %v, expected %v", tt.tool, got, tt.expected)
+		}
+	}
+	if got := config.OperationTimeouts.Max(); got != 600*time.Second {
+		t.Errorf("OperationTimeouts.Max() = %v, expected %v", got, 600*time.Second)
+	}
+}
+
+func TestLoadContentFields(t *testing.T) {
+	config, err := loadTestConfig(t, `
+databases:
+  default: weaviate-local
+  vector_databases:
+    - name: weaviate-local
+      type: weaviate-local
+      url: http://localhost:8080
+      content_fields: [body, text]
+      collections:
+        - name: Articles
+          type: text
+          content_fields: [summary, body]
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	dbConfig, err := config.GetDefaultDatabase()
+	if err != nil {
+		t.Fatalf("Failed to get default database: %v", err)
+	}
+	if got := strings.Join(dbConfig.ContentFields, ","); got != "body,text" {
+		t.Errorf("Expected content_fields body,text, got %s", got)
+	}
+	collection := dbConfig.GetCollection("Articles")
+	if collection == nil {
+		t.Fatal("Expected collection Articles, got nil")
+	}
+	if got := strings.Join(collection.ContentFields, ","); got != "summary,body" {
+		t.Errorf("Expected collection content_fields summary,body, got %s", got)
+	}
+}
+
+func TestOperationTimeoutsDefaults(t *testing.T) {
+	config, err := loadTestConfig(t, `
+operation_timeouts:
+  list_documents: 10
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if got := config.OperationTimeouts.For("list_documents"); got != 10*time.Second {
+		t.Errorf("OperationTimeouts.For(list_documents) = %v, expected 10s", got)
+	}
+	if got := config.OperationTimeouts.For("query_documents"); got != DefaultToolTimeout {
+		t.Errorf("OperationTimeouts.For(query_documents) = %v, expected %v", got, DefaultToolTimeout)
+	}
+
+	var unset OperationTimeouts
+	if got := unset.For("query_documents"); got != DefaultToolTimeout {
+		t.Errorf("unset OperationTimeouts.For(query_documents) = %v, expected %v", got, DefaultToolTimeout)
+	}
+	if got := unset.Max(); got != DefaultToolTimeout {
+		t.Errorf("unset OperationTimeouts.Max() = %v, expected %v", got, DefaultToolTimeout)
+	}
+}
+
+func TestOperationTimeoutsRejectNegative(t *testing.T) {
+	_, err := loadTestConfig(t, `
+operation_timeouts:
+  delete_all_documents: -5
+`)
+	if err == nil || !strings.Contains(err.Error(), "operation_timeouts.delete_all_documents") {
+		t.Errorf("Expected a negative timeout error, got %v", err)
+	}
+}
+
+func TestLoadCORSConfig(t *testing.T) {
+	config, err := loadTestConfig(t, `
+cors:
+  allowed_origins:
+    - https://app.example.com
+    - https://admin.example.com
+  max_age: 600
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	expected := []string{"https://app.example.com", "https://admin.example.com"}
+	if !reflect.DeepEqual(config.CORS.AllowedOrigins, expected) {
+		t.Errorf("Expected allowed origins %v, got %v", expected, config.CORS.AllowedOrigins)
+	}
+	if len(config.CORS.AllowedMethods) != 0 {
+		t.Errorf("Expected no allowed methods, got %v", config.CORS.AllowedMethods)
+	}
+	if config.CORS.MaxAge != 600 {
+		t.Errorf("Expected max_age 600, got %d", config.CORS.MaxAge)
+	}
+}
+
+func TestCORSConfigRejectsNegativeMaxAge(t *testing.T) {
+	_, err := loadTestConfig(t, `
+cors:
+  max_age: -1
+`)
+	if err == nil || !strings.Contains(err.Error(), "cors.max_age") {
+		t.Errorf("Expected a negative max_age error, got %v", err)
+	}
+}
+
+func TestLoadCollectionTemplates(t *testing.T) {
+	config, err := loadTestConfig(t, `
+collection_templates:
+  - name: articles
+    type: text
+    vectorizer: text2vec-openai
+    vector_index_config:
+      distance: dot
+      ef_construction: 256
+    properties:
+      - name: authorName
+        data_type: text
+        tokenization: field
+    tokenization:
+      url: field
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	template, err := config.GetCollectionTemplate("articles")
+	if err != nil {
+		t.Fatalf("GetCollectionTemplate() error: %v", err)
+	}
+	if template.Type != "text" || template.Vectorizer != "text2vec-openai" {
+		t.Errorf("Unexpected template %+v", template)
+	}
+	if template.VectorIndexConfig == nil || template.VectorIndexConfig.Distance != "dot" || template.VectorIndexConfig.EfConstruction != 256 {
+		t.Errorf("Unexpected vector_index_config %+v", template.VectorIndexConfig)
+	}
+	if len(template.Properties) != 1 || template.Properties[0].Name != "authorName" || template.Properties[0].Tokenization != "field" {
+		t.Errorf("Unexpected properties %+v", template.Properties)
+	}
+	if template.Tokenization["url"] != "field" {
+		t.Errorf("Unexpected tokenization %v", template.Tokenization)
+	}
+
+	if _, err := config.GetCollectionTemplate("missing"); err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+}
+
+func TestCollectionTemplatesRejectDuplicates(t *testing.T) {
+	_, err := loadTestConfig(t, `
+collection_templates:
+  - name: articles
+    type: text
+  - name: articles
+    type: image
+`)
+	if err == nil || !strings.Contains(err.Error(), "duplicate collection template 'articles'") {
+		t.Errorf("Expected a duplicate template error, got %v", err)
+	}
+}
+
+func TestSaveCollectionTemplate(t *testing.T) {
+	config := &Config{}
+
+	replaced, err := config.SaveCollectionTemplate(CollectionTemplate{Name: "b", Type: "text"})
+	if err != nil || replaced {
+		t.Fatalf("SaveCollectionTemplate(b) = %v, %v", replaced, err)
+	}
+	if _, err := config.SaveCollectionTemplate(CollectionTemplate{Name: "a", Type: "text"}); err != nil {
+		t.Fatalf("SaveCollectionTemplate(a) error: %v", err)
+	}
+	replaced, err = config.SaveCollectionTemplate(CollectionTemplate{Name: "b", Type: "image"})
+	if err != nil || !replaced {
+		t.Fatalf("SaveCollectionTemplate(b) again = %v, %v", replaced, err)
+	}
+	if _, err := config.SaveCollectionTemplate(CollectionTemplate{}); err == nil {
+		t.Error("Expected an error for a template without a name")
+	}
+
+	templates := config.ListCollectionTemplates()
+	if len(templates) != 2 || templates[0].Name != "a" || templates[1].Name != "b" || templates[1].Type != "image" {
+		t.Errorf("Unexpected templates %+v", templates)
+	}
+}
+
+func TestResolvedAuthMode(t *testing.T) {
+	oidc := &OIDCConfig{TokenURL: "https://idp.example.com/token", ClientID: "id", ClientSecret: "secret"}
+
+	tests := []struct {
+		name     string
+		db       VectorDBConfig
+		expected string
+		wantErr  bool
+	}{
+		{"default with api key", VectorDBConfig{APIKey: "key"}, AuthModeAPIKey, false},
+		{"default without api key", VectorDBConfig{}, AuthModeNone, false},
+		{"explicit none ignores api key", VectorDBConfig{AuthMode: AuthModeNone, APIKey: "key"}, AuthModeNone, false},
+		{"api_key without key", VectorDBConfig{AuthMode: AuthModeAPIKey}, "", true},
+		{"oidc", VectorDBConfig{AuthMode: AuthModeOIDC, OIDC: oidc}, AuthModeOIDC, false},
+		{"oidc without credentials", VectorDBConfig{AuthMode: AuthModeOIDC}, "", true},
+		{"unknown mode", VectorDBConfig{AuthMode: "basic"}, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.db.ResolvedAuthMode()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ResolvedAuthMode() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: ResolvedAuthMode() = %q, expected %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestResolvedSearchFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected []string
+		wantErr  bool
+	}{
+		{"unset uses the default", "name: db", nil, false},
+		{"scalar none", "search_fallback: none", []string{}, false},
+		{"list", "search_fallback: [hybrid]", []string{"hybrid"}, false},
+		{"scalar mode", "search_fallback: simple", []string{"simple"}, false},
+		{"none with others", "search_fallback: [none, hybrid]", nil, true},
+		{"unknown mode", "search_fallback: [nearVector]", nil, true},
+		{"duplicate mode", "search_fallback: [hybrid, hybrid]", nil, true},
+	}
+
+	for _, tt := range tests {
+		var db VectorDBConfig
+		if err := yaml.Unmarshal([]byte(tt.yaml), &db); err != nil {
+			t.Errorf("%s: unmarshal error = %v", tt.name, err)
+			continue
+		}
+		got, err := db.ResolvedSearchFallback()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ResolvedSearchFallback() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if (got == nil) != (tt.expected == nil) || strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: ResolvedSearchFallback() = %#v, expected %#v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadDefaultCollection(t *testing.T) {
+	config, err := loadTestConfig(t, `
+default_collection: Docs
+`)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.DefaultCollection != "Docs" {
+		t.Errorf("Expected default collection Docs, got %q", config.DefaultCollection)
+	}
+}
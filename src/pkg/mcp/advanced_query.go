@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// defaultMoveForce is the force of a move_to or move_away without one
+const defaultMoveForce = 0.5
+
+// parseNearTextMove parses a move_to or move_away argument of the form
+// {"concepts": [...], "force": 0.5}; it returns nil when the argument is unset
+func parseNearTextMove(args map[string]interface{}, key string) (*weaviate.NearTextMove, error) {
+	raw, ok := args[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	entry, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object with concepts and force", key)
+	}
+
+	concepts, err := stringList(entry["concepts"])
+	if err != nil {
+		return nil, fmt.Errorf("%s concepts: %w", key, err)
+	}
+	return &weaviate.NearTextMove{
+		Concepts: concepts,
+		Force:    getFloatArg(entry, "force", defaultMoveForce),
+	}, nil
+}
+
+// handleQueryDocumentsAdvanced handles the query_documents_advanced tool: a
+// Weaviate nearText search over several concepts, optionally steered toward
+// or away from other concepts with moveTo and moveAway
+func (s *Server) handleQueryDocumentsAdvanced(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, _ := args["query"].(string)
+	var concepts []string
+	if raw, ok := args["concepts"]; ok && raw != nil {
+		parsed, err := stringList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("concepts: %w", err)
+		}
+		concepts = parsed
+	}
+	if query == "" && len(concepts) == 0 {
+		return nil, fmt.Errorf("query or concepts is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	moveTo, err := parseNearTextMove(args, "move_to")
+	if err != nil {
+		return nil, err
+	}
+	moveAway, err := parseNearTextMove(args, "move_away")
+	if err != nil {
+		return nil, err
+	}
+
+	options := weaviate.QueryOptions{
+		TopK:     limit,
+		Concepts: concepts,
+		MoveTo:   moveTo,
+		MoveAway: moveAway,
+	}
+	if err := weaviate.ValidateNearText(options); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireWeaviateDatabase(ctx, "query_documents_advanced"); err != nil {
+		return nil, err
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weaviateResults, searchMode, steered, err := client.QueryWithSteering(timeoutCtx, collection, query, options)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to query documents", err)
+	}
+
+	// Convert results to the query_documents format
+	result := make([]map[string]interface{}, 0, len(weaviateResults))
+	for _, res := range weaviateResults {
+		doc := vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata}
+		entry := map[string]interface{}{
+			"id":       doc.ID,
+			"content":  doc.Content,
+			"text":     doc.Text,
+			"url":      s.documentURL(ctx, collection, &doc),
+			"metadata": doc.Metadata,
+			"score":    res.Score,
+		}
+		markFallbackResult(entry, searchMode)
+		result = append(result, entry)
+	}
+
+	response := map[string]interface{}{
+		"results":     result,
+		"count":       len(result),
+		"collection":  collection,
+		"query":       query,
+		"search_mode": searchMode,
+	}
+	if len(concepts) > 0 {
+		response["concepts"] = concepts
+	}
+	if moveTo != nil || moveAway != nil {
+		steering := map[string]interface{}{"applied": steered}
+		if moveTo != nil {
+			steering["move_to"] = moveTo
+		}
+		if moveAway != nil {
+			steering["move_away"] = moveAway
+		}
+		if !steered {
+			reason := fmt.Sprintf("the %s fallback does not support steering", searchMode)
+			if searchMode == weaviate.SearchModeNearText {
+				reason = "the collection's vectorizer rejected them"
+			}
+			steering["note"] = "move_to and move_away were ignored: " + reason
+		}
+		response["steering"] = steering
+	}
+	if message := s.queryResultMessage(timeoutCtx, collection, searchMode, len(result)); message != "" {
+		response["message"] = message
+	}
+	return response, nil
+}
@@ -0,0 +1,348 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"go.uber.org/zap"
+)
+
+// Upload formats accepted by POST /mcp/import
+const (
+	importFormatJSONL = "jsonl"
+	importFormatCSV   = "csv"
+)
+
+const (
+	// importBatchSize is the number of documents inserted per batch, lowered
+	// to max_batch_size when that is smaller
+	importBatchSize = 100
+	// maxImportRecordBytes bounds a single JSONL line
+	maxImportRecordBytes = 10 * 1024 * 1024
+	// maxImportFailures bounds the failed records listed in an import result
+	maxImportFailures = 100
+	// maxImportFieldBytes bounds the form fields sent before the file
+	maxImportFieldBytes = 64 * 1024
+)
+
+// importRecordReader reads an upload one record at a time, returning io.EOF
+// after the last record
+type importRecordReader interface {
+	next() (map[string]interface{}, error)
+}
+
+// importRecordError is a record that could not be parsed; the import skips it
+type importRecordError struct {
+	err error
+}
+
+func (e *importRecordError) Error() string { return e.err.Error() }
+
+// jsonlRecordReader reads one batch_create_documents document object per line
+type jsonlRecordReader struct {
+	scanner *bufio.Scanner
+}
+
+func newJSONLRecordReader(r io.Reader) *jsonlRecordReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportRecordBytes)
+	return &jsonlRecordReader{scanner: scanner}
+}
+
+func (j *jsonlRecordReader) next() (map[string]interface{}, error) {
+	for j.scanner.Scan() {
+		line := strings.TrimSpace(j.scanner.Text())
+		if line == "" {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			return nil, &importRecordError{fmt.Errorf("invalid JSON: %w", err)}
+		}
+		return fields, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// newImportRecordReader returns the reader for an upload's format. The
+// columns and types fields configure CSV uploads.
+func newImportRecordReader(format string, file io.Reader, fields map[string]string) (importRecordReader, error) {
+	if format != importFormatCSV {
+		if fields["columns"] != "" || fields["types"] != "" {
+			return nil, fmt.Errorf("columns and types only apply to CSV uploads")
+		}
+		return newJSONLRecordReader(file), nil
+	}
+
+	mapping, err := parseCSVColumnMapping(fields["columns"])
+	if err != nil {
+		return nil, err
+	}
+	types, err := parseCSVTypes(fields["types"])
+	if err != nil {
+		return nil, err
+	}
+	return newCSVRecordReader(file, mapping, types)
+}
+
+// importFormat resolves the upload format from the format field, or else the
+// file name's extension
+func importFormat(format, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".jsonl", ".ndjson":
+			format = importFormatJSONL
+		case ".csv":
+			format = importFormatCSV
+		default:
+			return "", fmt.Errorf("cannot tell the format of '%s'; set the format field to jsonl or csv", filename)
+		}
+	}
+	format = strings.ToLower(format)
+	if format != importFormatJSONL && format != importFormatCSV {
+		return "", fmt.Errorf("unsupported format '%s' (use jsonl or csv)", format)
+	}
+	return format, nil
+}
+
+// handleImport handles POST /mcp/import, a multipart/form-data upload of a
+// JSONL or CSV file with a collection field. The file is parsed as it is
+// received and inserted in batches by an async job, whose status is streamed
+// back as newline-delimited JSON: a snapshot every jobProgressInterval and a
+// final one with the result. The job can also be followed with get_job_status
+// or GET /mcp/jobs/events, and stopped with cancel_job.
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Expected a multipart/form-data upload", http.StatusBadRequest)
+		return
+	}
+
+	// Fields must come before the file so it can be streamed rather than buffered
+	fields := map[string]string{}
+	var file *multipart.Part
+	for file == nil {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid multipart upload: %v", err), http.StatusBadRequest)
+			return
+		}
+		switch part.FormName() {
+		case "file":
+			file = part
+		case "collection", "format", "columns", "types":
+			value, err := io.ReadAll(io.LimitReader(part, maxImportFieldBytes))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid multipart upload: %v", err), http.StatusBadRequest)
+				return
+			}
+			fields[part.FormName()] = strings.TrimSpace(string(value))
+		}
+	}
+	collection := fields["collection"]
+	if file == nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	if collection == "" {
+		http.Error(w, "collection is required and must come before the file", http.StatusBadRequest)
+		return
+	}
+	format, err := importFormat(fields["format"], file.FileName())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	records, err := newImportRecordReader(format, file, fields)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Large uploads outlast the server's read and write timeouts
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	j, err := s.jobs.start("import", 0, func(ctx context.Context, progress func(processed int64)) (interface{}, error) {
+		return s.importDocuments(ctx, collection, format, records, progress)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	s.logger.Info("Started import",
+		zap.String("job_id", j.id),
+		zap.String("collection", collection),
+		zap.String("format", format))
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("X-Job-ID", j.id)
+
+	encoder := json.NewEncoder(w)
+	writeSnapshot := func() {
+		if err := encoder.Encode(j.snapshot()); err != nil {
+			s.logger.Debug("Failed to write import progress", zap.String("job_id", j.id), zap.Error(err))
+			return
+		}
+		flusher.Flush()
+	}
+
+	ticker := time.NewTicker(jobProgressInterval)
+	defer ticker.Stop()
+
+	// The job reads the request body, so the handler waits for it to end
+	writeSnapshot()
+	for {
+		select {
+		case <-r.Context().Done():
+			_ = j.requestCancel()
+			<-j.finished
+			return
+		case <-j.finished:
+			writeSnapshot()
+			return
+		case <-ticker.C:
+			writeSnapshot()
+		}
+	}
+}
+
+// importDocuments reads records and creates them in batches, reporting the
+// records read as progress. Records that fail to parse or insert are listed
+// in the result and skipped.
+func (s *Server) importDocuments(ctx context.Context, collection, format string, records importRecordReader, progress func(processed int64)) (interface{}, error) {
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	schemaCtx, schemaCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+	metadataFormat := s.schemaMetadataFormat(schemaCtx, collection)
+	schemaCancel()
+
+	batchSize := importBatchSize
+	if maxBatch := s.config.BatchSizeLimit(); maxBatch > 0 && maxBatch < batchSize {
+		batchSize = maxBatch
+	}
+
+	var (
+		processed   int
+		created     int
+		failedCount int
+		failed      = []map[string]interface{}{}
+		batch       = make([]*vectordb.Document, 0, batchSize)
+		batchRecord = make([]int, 0, batchSize) // record number of each batch entry
+	)
+	recordFailure := func(record int, err error) {
+		failedCount++
+		if len(failed) < maxImportFailures {
+			failed = append(failed, map[string]interface{}{"record": record, "error": err.Error()})
+		}
+	}
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.createDocumentBatch(ctx, collection, batch, metadataFormat); err != nil {
+			// The batch reports a single error, so every document in it is reported failed
+			batchErr := s.enhanceError(ctx, "failed to create documents in batch", err)
+			for _, record := range batchRecord {
+				recordFailure(record, batchErr)
+			}
+		} else {
+			created += len(batch)
+		}
+		batch = batch[:0]
+		batchRecord = batchRecord[:0]
+	}
+	result := func() map[string]interface{} {
+		status := "created"
+		switch {
+		case created == 0:
+			status = "failed"
+		case failedCount > 0:
+			status = "partial"
+		}
+		return map[string]interface{}{
+			"collection":   collection,
+			"format":       format,
+			"processed":    processed,
+			"count":        created,
+			"failed_count": failedCount,
+			"failed":       failed,
+			"status":       status,
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result(), err
+		}
+
+		fields, err := records.next()
+		if err == io.EOF {
+			break
+		}
+		processed++
+		progress(int64(processed))
+		var recordErr *importRecordError
+		switch {
+		case errors.As(err, &recordErr):
+			recordFailure(processed, recordErr)
+			continue
+		case err != nil:
+			return result(), fmt.Errorf("failed to read record %d: %w", processed, err)
+		}
+
+		doc, err := parseBatchDocument(fields)
+		if err != nil {
+			recordFailure(processed, err)
+			continue
+		}
+		batch = append(batch, doc)
+		batchRecord = append(batchRecord, processed)
+		if len(batch) == batchSize {
+			flush()
+		}
+	}
+	flush()
+
+	if processed == 0 {
+		return nil, fmt.Errorf("no records found in upload")
+	}
+	return result(), nil
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// handleClusterStatus handles the cluster_status tool, reporting the nodes of
+// a Weaviate cluster with their shards and object counts
+func (s *Server) handleClusterStatus(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, _ := args["collection"].(string)
+
+	if err := s.requireWeaviateDatabase(ctx, "cluster_status"); err != nil {
+		return nil, err
+	}
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
+	defer cancel()
+
+	nodes, err := client.GetNodes(timeoutCtx, collection)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get cluster nodes", err)
+	}
+
+	var shardCount, objectCount int64
+	healthyNodes := 0
+	nodeStatuses := make([]map[string]interface{}, len(nodes))
+	for i, node := range nodes {
+		shards := make([]map[string]interface{}, len(node.Shards))
+		for j, shard := range node.Shards {
+			shards[j] = map[string]interface{}{
+				"name":                   shard.Name,
+				"collection":             shard.Class,
+				"object_count":           shard.ObjectCount,
+				"vector_indexing_status": shard.VectorIndexingStatus,
+				"vector_queue_length":    shard.VectorQueueLength,
+			}
+		}
+		nodeStatuses[i] = map[string]interface{}{
+			"name":         node.Name,
+			"status":       node.Status,
+			"version":      node.Version,
+			"shard_count":  node.Stats.ShardCount,
+			"object_count": node.Stats.ObjectCount,
+			"shards":       shards,
+		}
+
+		shardCount += node.Stats.ShardCount
+		objectCount += node.Stats.ObjectCount
+		if node.Status == weaviate.NodeStatusHealthy {
+			healthyNodes++
+		}
+	}
+
+	status := "healthy"
+	if healthyNodes < len(nodes) {
+		status = "degraded"
+	}
+
+	result := map[string]interface{}{
+		"nodes":         nodeStatuses,
+		"node_count":    len(nodes),
+		"healthy_nodes": healthyNodes,
+		"shard_count":   shardCount,
+		"object_count":  objectCount,
+		"status":        status,
+	}
+	if collection != "" {
+		result["collection"] = collection
+	}
+	if len(nodes) == 1 {
+		result["message"] = "single-node deployment: all shards live on one node, so there is no replication or shard distribution to report"
+	}
+	return result, nil
+}
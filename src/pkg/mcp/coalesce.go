@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// Concurrent identical read requests are coalesced so they share a single
+// backend call. The shared call runs with the context of the first caller,
+// and all waiting callers receive the same result (including the same
+// schema pointer, which callers must treat as read-only).
+
+// getCollectionCount returns the document count for a collection, sharing
+// one backend call between concurrent callers for the same collection
+func (s *Server) getCollectionCount(ctx context.Context, collectionName string) (int64, error) {
+	result, err, _ := s.inflight.Do(s.inflightKey(ctx, "count", collectionName), func() (interface{}, error) {
+		return s.db(ctx).GetCollectionCount(ctx, collectionName)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int64), nil
+}
+
+// getSchema returns the schema for a collection, sharing one backend call
+// between concurrent callers for the same collection
+func (s *Server) getSchema(ctx context.Context, collectionName string) (*vectordb.CollectionSchema, error) {
+	result, err, _ := s.inflight.Do(s.inflightKey(ctx, "schema", collectionName), func() (interface{}, error) {
+		return s.db(ctx).GetSchema(ctx, collectionName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	schema, _ := result.(*vectordb.CollectionSchema)
+	return schema, nil
+}
+
+// inflightKey returns the coalescing key of a request, scoped to the database
+// used by the call so same-named collections in other databases are not shared
+func (s *Server) inflightKey(ctx context.Context, kind, collectionName string) string {
+	return kind + ":" + s.databaseName(ctx) + ":" + collectionName
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// collectionLocks provides per-collection advisory locks that serialize
+// write and destructive operations on the same collection of a database.
+//
+// The locks are in-process only: they protect against concurrent tool calls
+// handled by this server instance, not against other weave-mcp replicas or
+// clients writing to the same database. Read operations do not take a lock.
+type collectionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*collectionLock
+}
+
+// collectionLock is a lock that can be waited for with a context. Entries
+// are dropped once no call holds or waits for them.
+type collectionLock struct {
+	held  chan struct{} // one slot, full while the lock is held
+	users int           // calls holding or waiting for the lock, guarded by collectionLocks.mu
+}
+
+func collectionLockKey(database, collection string) string {
+	return database + "\x00" + collection
+}
+
+// acquire takes the lock for key, waiting until it is free or ctx is done
+func (l *collectionLocks) acquire(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*collectionLock)
+	}
+	lock, exists := l.locks[key]
+	if !exists {
+		lock = &collectionLock{held: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.users++
+	l.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+		return func() {
+			<-lock.held
+			l.release(key, lock)
+		}, nil
+	case <-ctx.Done():
+		l.release(key, lock)
+		return nil, ctx.Err()
+	}
+}
+
+// release drops a call's use of a lock, removing the lock when unused
+func (l *collectionLocks) release(key string, lock *collectionLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.users--
+	if lock.users == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// lockCollection acquires the advisory lock for a collection of the call's
+// database and returns a function that releases it. It fails when ctx ends
+// before another operation on the collection releases the lock.
+func (s *Server) lockCollection(ctx context.Context, collection string) (func(), error) {
+	unlock, err := s.collectionLocks.acquire(ctx, collectionLockKey(s.databaseName(ctx), collection))
+	if err != nil {
+		return nil, fmt.Errorf("collection '%s' is locked by another write operation: %w", collection, err)
+	}
+	return unlock, nil
+}
@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// templateDefaultProperties are the properties create_collection adds to
+// every collection (image only to image collections). Template tokenization
+// may target them besides the template's own properties.
+var templateDefaultProperties = []vectordb.SchemaProperty{
+	{Name: "text", DataType: []string{"text"}},
+	{Name: "url", DataType: []string{"text"}},
+	{Name: "metadata", DataType: []string{"text"}},
+	{Name: "image", DataType: []string{"text"}},
+}
+
+// applyCollectionTemplate resolves the template argument of create_collection:
+// the named template's parameters are used wherever the call does not set
+// them. vector_index_config and tokenization are merged key by key and
+// properties by name, with the call's values winning. It returns the
+// arguments unchanged when no template is given.
+func (s *Server) applyCollectionTemplate(args map[string]interface{}) (map[string]interface{}, error) {
+	name, _ := args["template"].(string)
+	if name == "" {
+		return args, nil
+	}
+
+	template, err := s.config.GetCollectionTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := templateArguments(template)
+	for key, value := range args {
+		switch key {
+		case "template":
+			continue
+		case "vector_index_config", "tokenization":
+			base, baseOK := merged[key].(map[string]interface{})
+			override, overrideOK := value.(map[string]interface{})
+			if baseOK && overrideOK {
+				combined := make(map[string]interface{}, len(base)+len(override))
+				for k, v := range base {
+					combined[k] = v
+				}
+				for k, v := range override {
+					combined[k] = v
+				}
+				merged[key] = combined
+				continue
+			}
+		case "properties":
+			base, baseOK := merged[key].([]interface{})
+			override, overrideOK := value.([]interface{})
+			if baseOK && overrideOK {
+				merged[key] = mergeTemplateProperties(base, override)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+// mergeTemplateProperties returns the template's properties with same-named
+// call properties replacing them, followed by the call's other properties
+func mergeTemplateProperties(base, override []interface{}) []interface{} {
+	overrides := make(map[string]interface{}, len(override))
+	var order []string
+	for _, item := range override {
+		name := propertyEntryName(item)
+		if _, exists := overrides[name]; !exists {
+			order = append(order, name)
+		}
+		overrides[name] = item
+	}
+
+	merged := make([]interface{}, 0, len(base)+len(override))
+	used := make(map[string]bool, len(override))
+	for _, item := range base {
+		name := propertyEntryName(item)
+		if replacement, ok := overrides[name]; ok {
+			merged = append(merged, replacement)
+			used[name] = true
+			continue
+		}
+		merged = append(merged, item)
+	}
+	for _, name := range order {
+		if !used[name] {
+			merged = append(merged, overrides[name])
+		}
+	}
+	return merged
+}
+
+// propertyEntryName returns the name of a {name, data_type} property entry
+func propertyEntryName(item interface{}) string {
+	entry, _ := item.(map[string]interface{})
+	name, _ := entry["name"].(string)
+	return name
+}
+
+// templateArguments converts a collection template into create_collection
+// arguments
+func templateArguments(template *config.CollectionTemplate) map[string]interface{} {
+	args := make(map[string]interface{})
+	if template.Type != "" {
+		args["type"] = template.Type
+	}
+	if template.Description != "" {
+		args["description"] = template.Description
+	}
+	if template.Vectorizer != "" {
+		args["vectorizer"] = template.Vectorizer
+	}
+
+	if index := template.VectorIndexConfig; index != nil {
+		indexConfig := make(map[string]interface{})
+		if index.Distance != "" {
+			indexConfig["distance"] = index.Distance
+		}
+		if index.EfConstruction != 0 {
+			indexConfig["efConstruction"] = index.EfConstruction
+		}
+		if index.MaxConnections != 0 {
+			indexConfig["maxConnections"] = index.MaxConnections
+		}
+		if index.Ef != 0 {
+			indexConfig["ef"] = index.Ef
+		}
+		if len(indexConfig) > 0 {
+			args["vector_index_config"] = indexConfig
+		}
+	}
+
+	tokenization := make(map[string]interface{})
+	for property, value := range template.Tokenization {
+		tokenization[property] = value
+	}
+
+	if len(template.Properties) > 0 {
+		properties := make([]interface{}, len(template.Properties))
+		for i, prop := range template.Properties {
+			entry := map[string]interface{}{
+				"name":      prop.Name,
+				"data_type": prop.DataType,
+			}
+			if prop.Description != "" {
+				entry["description"] = prop.Description
+			}
+			properties[i] = entry
+			if prop.Tokenization != "" {
+				tokenization[prop.Name] = prop.Tokenization
+			}
+		}
+		args["properties"] = properties
+	}
+
+	if len(tokenization) > 0 {
+		args["tokenization"] = tokenization
+	}
+	return args
+}
+
+// templateFromArguments validates create_collection-style arguments and
+// converts them into a collection template
+func templateFromArguments(name string, args map[string]interface{}) (config.CollectionTemplate, error) {
+	template := config.CollectionTemplate{Name: name}
+
+	if collectionType, ok := args["type"].(string); ok && collectionType != "" {
+		if collectionType != "text" && collectionType != "image" {
+			return template, fmt.Errorf("type must be text or image")
+		}
+		template.Type = collectionType
+	}
+	template.Description, _ = args["description"].(string)
+	template.Vectorizer, _ = args["vectorizer"].(string)
+
+	if rawIndexConfig, ok := args["vector_index_config"].(map[string]interface{}); ok && len(rawIndexConfig) > 0 {
+		parsed, err := weaviate.ParseVectorIndexConfig(rawIndexConfig)
+		if err != nil {
+			return template, err
+		}
+		template.VectorIndexConfig = &config.TemplateVectorIndexConfig{
+			Distance:       parsed.Distance,
+			EfConstruction: parsed.EfConstruction,
+			MaxConnections: parsed.MaxConnections,
+			Ef:             parsed.Ef,
+		}
+	}
+
+	var properties []vectordb.SchemaProperty
+	if rawProperties, ok := args["properties"]; ok && rawProperties != nil {
+		parsed, err := parseCollectionProperties(rawProperties, templateDefaultProperties)
+		if err != nil {
+			return template, err
+		}
+		properties = parsed
+	}
+
+	var tokenization map[string]string
+	if rawTokenization, ok := args["tokenization"].(map[string]interface{}); ok && len(rawTokenization) > 0 {
+		all := append(append([]vectordb.SchemaProperty{}, templateDefaultProperties...), properties...)
+		parsed, err := parseTokenization(rawTokenization, all)
+		if err != nil {
+			return template, err
+		}
+		tokenization = parsed
+	}
+
+	// Tokenization of custom properties is kept on the property
+	for _, prop := range properties {
+		entry := config.TemplateProperty{
+			Name:        prop.Name,
+			DataType:    prop.DataType[0],
+			Description: prop.Description,
+		}
+		if value, ok := tokenization[prop.Name]; ok {
+			entry.Tokenization = value
+			delete(tokenization, prop.Name)
+		}
+		template.Properties = append(template.Properties, entry)
+	}
+	if len(tokenization) > 0 {
+		template.Tokenization = tokenization
+	}
+	return template, nil
+}
+
+// handleSaveCollectionTemplate handles the save_collection_template tool
+func (s *Server) handleSaveCollectionTemplate(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("template name is required")
+	}
+
+	template, err := templateFromArguments(name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced, err := s.config.SaveCollectionTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "saved"
+	if replaced {
+		status = "replaced"
+	}
+	return map[string]interface{}{
+		"name":     name,
+		"template": templateArguments(&template),
+		"status":   status,
+	}, nil
+}
+
+// handleListCollectionTemplates handles the list_collection_templates tool
+func (s *Server) handleListCollectionTemplates(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	templates := s.config.ListCollectionTemplates()
+
+	entries := make([]map[string]interface{}, len(templates))
+	for i := range templates {
+		entries[i] = map[string]interface{}{
+			"name":     templates[i].Name,
+			"template": templateArguments(&templates[i]),
+		}
+	}
+	return map[string]interface{}{
+		"templates": entries,
+		"count":     len(entries),
+	}, nil
+}
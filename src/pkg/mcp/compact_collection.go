@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// Statuses of the maintenance operations reported by compact_collection
+const (
+	maintenanceCompleted    = "completed"
+	maintenanceSkipped      = "skipped"
+	maintenanceNotNeeded    = "not_needed"
+	maintenanceNotSupported = "not_supported"
+)
+
+// handleCompactCollection handles the compact_collection tool. Weaviate
+// compacts LSM segments and flushes memtables on its own and exposes no REST
+// trigger for either, so those operations are reported as not supported. The
+// maintenance it does expose is shard status: shards Weaviate marked
+// READONLY, typically under disk pressure, can be returned to READY.
+func (s *Server) handleCompactCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+	resetReadOnly, _ := args["reset_readonly"].(bool)
+
+	if err := s.requireWeaviateDatabase(ctx, "compact_collection"); err != nil {
+		return nil, err
+	}
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize with writes to the collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
+	defer cancel()
+
+	shards, err := client.GetShards(timeoutCtx, collection)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get shards", err)
+	}
+
+	var readOnly []string
+	for _, shard := range shards {
+		if shard.Status == weaviate.ShardStatusReadOnly {
+			readOnly = append(readOnly, shard.Name)
+		}
+	}
+
+	reset := map[string]interface{}{"operation": "reset_readonly_shards"}
+	switch {
+	case len(readOnly) == 0:
+		reset["status"] = maintenanceNotNeeded
+	case !resetReadOnly:
+		reset["status"] = maintenanceSkipped
+		reset["shards"] = readOnly
+		reset["message"] = fmt.Sprintf("%d shard(s) are READONLY; set reset_readonly to return them to READY once the cause (usually low disk space) is fixed", len(readOnly))
+	default:
+		for _, name := range readOnly {
+			if err := client.UpdateShardStatus(timeoutCtx, collection, name, weaviate.ShardStatusReady); err != nil {
+				return nil, s.enhanceError(ctx, "failed to reset shard "+name, err)
+			}
+		}
+		reset["status"] = maintenanceCompleted
+		reset["shards"] = readOnly
+
+		if shards, err = client.GetShards(timeoutCtx, collection); err != nil {
+			return nil, s.enhanceError(ctx, "failed to get shards", err)
+		}
+	}
+
+	healthy := true
+	shardStatuses := make([]map[string]interface{}, len(shards))
+	for i, shard := range shards {
+		shardStatuses[i] = map[string]interface{}{
+			"name":              shard.Name,
+			"status":            shard.Status,
+			"vector_queue_size": shard.VectorQueueSize,
+		}
+		if shard.Status != weaviate.ShardStatusReady {
+			healthy = false
+		}
+	}
+
+	status := "healthy"
+	if !healthy {
+		status = "degraded"
+	}
+
+	return map[string]interface{}{
+		"collection": collection,
+		"shards":     shardStatuses,
+		"operations": []map[string]interface{}{
+			reset,
+			{
+				"operation": "compaction",
+				"status":    maintenanceNotSupported,
+				"message":   "not supported by backend: Weaviate compacts segments in the background and exposes no trigger",
+			},
+			{
+				"operation": "flush",
+				"status":    maintenanceNotSupported,
+				"message":   "not supported by backend: Weaviate flushes memtables automatically and exposes no trigger",
+			},
+		},
+		"status": status,
+	}, nil
+}
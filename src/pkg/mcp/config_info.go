@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+)
+
+// configInfoEnvVars are the environment variables reported by config_info
+var configInfoEnvVars = []string{
+	"VECTOR_DB_TYPE",
+	"WEAVIATE_URL",
+	"WEAVIATE_API_KEY",
+	"OPENAI_API_KEY",
+	"MCP_SERVER_HOST",
+	"MCP_SERVER_PORT",
+	"MCP_API_KEY",
+	"CORS_ORIGINS",
+	"CORS_METHODS",
+	"CORS_HEADERS",
+	"CORS_MAX_AGE",
+	"LOG_LEVEL",
+	"ENV_FILE",
+}
+
+// timeoutOperationTypes names the operation types reported by config_info
+var timeoutOperationTypes = []struct {
+	name   string
+	opType vectordb.OperationType
+}{
+	{"health", vectordb.OperationTypeHealth},
+	{"collection", vectordb.OperationTypeCollection},
+	{"document", vectordb.OperationTypeDocument},
+	{"bulk", vectordb.OperationTypeBulk},
+	{"query", vectordb.OperationTypeQuery},
+	{"schema", vectordb.OperationTypeSchema},
+}
+
+// maskSecret hides a secret, keeping the last four characters of long values
+// so operators can tell keys apart
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// redactURL masks any password embedded in a URL and the values of query
+// parameters that hold secrets, such as api_key
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	redacted := false
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for name := range query {
+			if isSecretEnvVar(strings.ToUpper(name)) {
+				query.Set(name, "xxxxx")
+				redacted = true
+			}
+		}
+		if redacted {
+			parsed.RawQuery = query.Encode()
+		}
+	}
+
+	if parsed.User == nil && !redacted {
+		return raw
+	}
+	return parsed.Redacted()
+}
+
+// isSecretEnvVar reports whether an environment variable holds a secret
+func isSecretEnvVar(name string) bool {
+	for _, marker := range []string{"KEY", "PASSWORD", "SECRET", "TOKEN"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// databaseInfo returns a database configuration with secrets masked
+func databaseInfo(db *config.VectorDBConfig) map[string]interface{} {
+	collections := make([]string, len(db.Collections))
+	for i, collection := range db.Collections {
+		collections[i] = collection.Name
+	}
+
+	info := map[string]interface{}{
+		"name":        db.Name,
+		"type":        db.Type,
+		"enabled":     db.Enabled,
+		"url":         redactURL(db.URL),
+		"collections": collections,
+	}
+	if db.APIKey != "" {
+		info["api_key"] = maskSecret(db.APIKey)
+	}
+	if db.AuthMode != "" {
+		info["auth_mode"] = db.AuthMode
+	}
+	if db.SearchFallback != nil {
+		info["search_fallback"] = []string(db.SearchFallback)
+	}
+	if db.MaxFallbackDepth != 0 {
+		info["max_fallback_depth"] = db.MaxFallbackDepth
+	}
+	if db.ScoreNormalization != "" {
+		info["score_normalization"] = db.ScoreNormalization
+	}
+	if db.MaxIdleConnsPerHost != 0 {
+		info["max_idle_conns_per_host"] = db.MaxIdleConnsPerHost
+	}
+	if db.IdleConnTimeout != 0 {
+		info["idle_conn_timeout"] = db.IdleConnTimeout
+	}
+	if db.OIDC != nil {
+		info["oidc"] = map[string]interface{}{
+			"token_url":     redactURL(db.OIDC.TokenURL),
+			"client_id":     db.OIDC.ClientID,
+			"client_secret": maskSecret(db.OIDC.ClientSecret),
+			"scopes":        db.OIDC.Scopes,
+		}
+	}
+	if db.OpenAIAPIKey != "" {
+		info["openai_api_key"] = maskSecret(db.OpenAIAPIKey)
+	}
+	if db.DatabaseURL != "" {
+		info["database_url"] = redactURL(db.DatabaseURL)
+	}
+	if db.DatabaseKey != "" {
+		info["database_key"] = maskSecret(db.DatabaseKey)
+	}
+	if db.Timeout > 0 {
+		info["timeout_seconds"] = db.Timeout
+	}
+	return info
+}
+
+// handleConfigInfo returns the effective server configuration with secrets masked
+func (s *Server) handleConfigInfo(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	defaultConfig, defaultErr := s.defaultDatabaseConfig()
+	defaultName := ""
+	if defaultErr == nil {
+		defaultName = defaultConfig.Name
+	}
+
+	databases := make([]interface{}, 0, len(s.config.Databases.VectorDatabases))
+	for i := range s.config.Databases.VectorDatabases {
+		db := &s.config.Databases.VectorDatabases[i]
+		info := databaseInfo(db)
+		info["default"] = db.Name == defaultName
+		databases = append(databases, info)
+	}
+
+	result := map[string]interface{}{
+		"default_database":   defaultName,
+		"databases":          databases,
+		"default_vectorizer": defaultVectorizer,
+		"default_collection": s.defaultCollection(),
+		"tls": map[string]interface{}{
+			"enabled":       s.config.TLS.Enabled,
+			"cert_file":     s.config.TLS.CertFile,
+			"key_file":      s.config.TLS.KeyFile,
+			"auto_redirect": s.config.TLS.AutoRedirect,
+		},
+	}
+
+	if defaultErr == nil {
+		result["active_database"] = databaseInfo(defaultConfig)
+
+		timeouts := make(map[string]interface{}, len(timeoutOperationTypes))
+		for _, op := range timeoutOperationTypes {
+			timeouts[op.name] = vectordb.GetTimeoutForOperation(op.opType, isCloudDatabase(defaultConfig), defaultConfig.Timeout).Seconds()
+		}
+		embeddingTimeout, embeddingRetries := s.embeddingSettings(ctx)
+		timeouts["embedding"] = embeddingTimeout.Seconds()
+		result["timeouts_seconds"] = timeouts
+		result["embedding_retries"] = embeddingRetries
+	}
+
+	s.mu.RLock()
+	if s.corsConfig != nil {
+		result["cors"] = map[string]interface{}{
+			"allowed_origins": s.corsConfig.AllowedOrigins,
+			"allowed_methods": s.corsConfig.AllowedMethods,
+			"allowed_headers": s.corsConfig.AllowedHeaders,
+			"max_age":         s.corsConfig.MaxAge,
+		}
+	}
+	s.mu.RUnlock()
+
+	environment := make(map[string]interface{}, len(configInfoEnvVars))
+	for _, name := range configInfoEnvVars {
+		value, set := os.LookupEnv(name)
+		if !set {
+			environment[name] = nil
+			continue
+		}
+		if isSecretEnvVar(name) {
+			value = maskSecret(value)
+		} else {
+			value = redactURL(value)
+		}
+		environment[name] = value
+	}
+	result["environment"] = environment
+
+	return result, nil
+}
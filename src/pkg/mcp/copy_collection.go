@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// handleCopyCollection handles the copy_collection tool
+func (s *Server) handleCopyCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	source, ok := args["source"].(string)
+	if !ok || source == "" {
+		return nil, fmt.Errorf("source collection name is required")
+	}
+
+	destination, ok := args["destination"].(string)
+	if !ok || destination == "" {
+		return nil, fmt.Errorf("destination collection name is required")
+	}
+	if destination == source {
+		return nil, fmt.Errorf("destination must differ from source")
+	}
+
+	limit := getIntArg(args, "limit", 0)
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	overwrite, _ := args["overwrite"].(bool)
+
+	// Serialize writes to the destination (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	start := time.Now()
+
+	collectionCtx, collectionCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
+	defer collectionCancel()
+
+	exists, err := s.db(ctx).CollectionExists(collectionCtx, destination)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to check destination collection", err)
+	}
+	if exists {
+		if !overwrite {
+			return nil, fmt.Errorf("destination collection '%s' already exists; set overwrite to replace it", destination)
+		}
+		err := s.db(ctx).DeleteCollection(collectionCtx, destination)
+		s.invalidateCollectionCaches(ctx, destination)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to delete destination collection", err)
+		}
+	}
+
+	vectorizer, err := s.copyCollectionSchema(collectionCtx, source, destination)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to copy collection schema", err)
+	}
+
+	metadataFormat := s.schemaMetadataFormat(collectionCtx, destination)
+
+	batchSize := importBatchSize
+	if maxBatch := s.config.BatchSizeLimit(); maxBatch > 0 && maxBatch < batchSize {
+		batchSize = maxBatch
+	}
+
+	copied := 0
+	for limit == 0 || copied < limit {
+		pageSize := batchSize
+		if limit > 0 && limit-copied < pageSize {
+			pageSize = limit - copied
+		}
+
+		listCtx, listCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+		documents, err := s.db(ctx).ListDocuments(listCtx, source, pageSize, copied)
+		listCancel()
+		if err != nil {
+			return nil, s.enhanceError(ctx, fmt.Sprintf("failed to list source documents after copying %d", copied), err)
+		}
+		if len(documents) == 0 {
+			break
+		}
+
+		batch := make([]*vectordb.Document, len(documents))
+		for i, doc := range documents {
+			batch[i] = &vectordb.Document{
+				ID:        doc.ID,
+				Text:      doc.Text,
+				Content:   doc.Content,
+				Image:     doc.Image,
+				ImageData: doc.ImageData,
+				URL:       doc.URL,
+				Metadata:  doc.Metadata,
+			}
+		}
+		if err := s.createDocumentBatch(ctx, destination, batch, metadataFormat); err != nil {
+			return nil, s.enhanceError(ctx, fmt.Sprintf("failed to copy documents after copying %d", copied), err)
+		}
+		copied += len(batch)
+
+		if len(documents) < pageSize {
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"source":      source,
+		"destination": destination,
+		"vectorizer":  vectorizer,
+		"count":       copied,
+		"overwritten": exists,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"status":      "copied",
+	}, nil
+}
+
+// copyCollectionSchema creates destination with the source collection's
+// schema and returns its vectorizer. On Weaviate the full schema is copied,
+// including vector index and tokenization settings the vectordb schema lacks.
+func (s *Server) copyCollectionSchema(ctx context.Context, source, destination string) (string, error) {
+	if s.requireWeaviateDatabase(ctx, "copy_collection") == nil {
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return "", err
+		}
+		schema, err := client.GetFullCollectionSchema(ctx, source)
+		if err != nil {
+			return "", err
+		}
+		schema.Class = destination
+		return schema.Vectorizer, client.CreateCollectionFromSchema(ctx, schema)
+	}
+
+	schema, err := s.getSchema(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	if schema == nil {
+		return "", fmt.Errorf("collection '%s' has no schema", source)
+	}
+	copied := *schema
+	copied.Class = destination
+	err = s.db(ctx).CreateCollection(ctx, destination, &copied)
+	s.invalidateCollectionCaches(ctx, destination)
+	return copied.Vectorizer, err
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSV metadata types, named after the Weaviate data types they produce
+const (
+	csvTypeText    = "text"
+	csvTypeInt     = "int"
+	csvTypeNumber  = "number"
+	csvTypeBoolean = "boolean"
+)
+
+// csvNumberPattern matches the decimal numbers inferred as numbers, leaving
+// words strconv accepts, such as NaN and Inf, as text
+var csvNumberPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// csvColumnMapping maps CSV columns to document fields. Metadata maps column
+// names to metadata keys; when it is empty, every column other than the text
+// and url columns becomes metadata under its own name.
+type csvColumnMapping struct {
+	Text     string            `json:"text"`
+	URL      string            `json:"url"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// parseCSVColumnMapping parses the columns field of an import, defaulting the
+// text and url columns to their own names
+func parseCSVColumnMapping(value string) (csvColumnMapping, error) {
+	var mapping csvColumnMapping
+	if value != "" {
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&mapping); err != nil {
+			return mapping, fmt.Errorf("columns must be a JSON object with text, url, and metadata: %v", err)
+		}
+	}
+	if mapping.Text == "" {
+		mapping.Text = "text"
+	}
+	if mapping.URL == "" {
+		mapping.URL = "url"
+	}
+	return mapping, nil
+}
+
+// parseCSVTypes parses the types field of an import, a JSON object of
+// metadata keys to text, int, number, or boolean
+func parseCSVTypes(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var types map[string]string
+	if err := json.Unmarshal([]byte(value), &types); err != nil {
+		return nil, fmt.Errorf("types must be a JSON object of metadata keys to types: %v", err)
+	}
+	for key, dataType := range types {
+		switch dataType {
+		case csvTypeText, csvTypeInt, csvTypeNumber, csvTypeBoolean:
+		default:
+			return nil, fmt.Errorf("invalid type '%s' for '%s' (use text, int, number, or boolean)", dataType, key)
+		}
+	}
+	return types, nil
+}
+
+// csvMetadataColumn is a CSV column imported as metadata
+type csvMetadataColumn struct {
+	index    int
+	name     string
+	key      string
+	dataType string // empty to infer the type from each value
+}
+
+// csvRecordReader reads RFC 4180 rows, including quoted fields with commas,
+// quotes, and newlines, under a header naming their columns
+type csvRecordReader struct {
+	reader   *csv.Reader
+	text     int
+	url      int
+	metadata []csvMetadataColumn
+}
+
+func newCSVRecordReader(r io.Reader, mapping csvColumnMapping, types map[string]string) (*csvRecordReader, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV upload is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		columns[strings.TrimSpace(column)] = i
+	}
+	column := func(name string) (int, error) {
+		index, ok := columns[name]
+		if !ok {
+			return 0, fmt.Errorf("CSV header has no '%s' column", name)
+		}
+		return index, nil
+	}
+
+	c := &csvRecordReader{reader: reader}
+	if c.text, err = column(mapping.Text); err != nil {
+		return nil, err
+	}
+	if c.url, err = column(mapping.URL); err != nil {
+		return nil, err
+	}
+
+	if len(mapping.Metadata) > 0 {
+		for name, key := range mapping.Metadata {
+			index, err := column(name)
+			if err != nil {
+				return nil, err
+			}
+			c.metadata = append(c.metadata, csvMetadataColumn{index: index, name: name, key: key})
+		}
+	} else {
+		for name, index := range columns {
+			if index != c.text && index != c.url {
+				c.metadata = append(c.metadata, csvMetadataColumn{index: index, name: name, key: name})
+			}
+		}
+	}
+	sort.Slice(c.metadata, func(i, j int) bool { return c.metadata[i].index < c.metadata[j].index })
+
+	keys := make(map[string]bool, len(c.metadata))
+	for i := range c.metadata {
+		keys[c.metadata[i].key] = true
+		c.metadata[i].dataType = types[c.metadata[i].key]
+	}
+	for key := range types {
+		if !keys[key] {
+			return nil, fmt.Errorf("types names '%s', which is not an imported metadata key", key)
+		}
+	}
+	return c, nil
+}
+
+func (c *csvRecordReader) next() (map[string]interface{}, error) {
+	row, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &importRecordError{err}
+		}
+		return nil, err
+	}
+
+	metadata := make(map[string]interface{}, len(c.metadata))
+	for _, column := range c.metadata {
+		value, err := csvValue(row[column.index], column.dataType)
+		if err != nil {
+			return nil, &importRecordError{fmt.Errorf("column '%s': %v", column.name, err)}
+		}
+		metadata[column.key] = value
+	}
+	return map[string]interface{}{
+		"url":      row[c.url],
+		"text":     row[c.text],
+		"metadata": metadata,
+	}, nil
+}
+
+// csvValue converts a CSV field to a metadata value of the given type, or
+// infers the type when none is given. Inference keeps values with leading
+// zeros, such as postal codes, as text.
+func csvValue(value, dataType string) (interface{}, error) {
+	switch dataType {
+	case csvTypeText:
+		return value, nil
+	case csvTypeInt:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int '%s'", value)
+		}
+		return parsed, nil
+	case csvTypeNumber:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			return nil, fmt.Errorf("invalid number '%s'", value)
+		}
+		return parsed, nil
+	case csvTypeBoolean:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean '%s'", value)
+		}
+		return parsed, nil
+	}
+
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || (len(trimmed) > 1 && trimmed[0] == '0' && trimmed[1] != '.') {
+		return value, nil
+	}
+	if parsed, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		return parsed, nil
+	}
+	if csvNumberPattern.MatchString(trimmed) {
+		if parsed, err := strconv.ParseFloat(trimmed, 64); err == nil && !math.IsInf(parsed, 0) {
+			return parsed, nil
+		}
+	}
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return value, nil
+}
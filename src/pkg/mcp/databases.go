@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"go.uber.org/zap"
+)
+
+// databaseTools accept an optional database argument naming a configured
+// vector database to use instead of the default one
+var databaseTools = map[string]bool{
+	"list_collections":                   true,
+	"create_collection":                  true,
+	"create_collection_from_schema_file": true,
+	"delete_collection":                  true,
+	"list_documents":                     true,
+	"create_document":                    true,
+	"validate_document":                  true,
+	"batch_create_documents":             true,
+	"get_document":                       true,
+	"get_full_document":                  true,
+	"preview_document":                   true,
+	"delete_document":                    true,
+	"delete_documents":                   true,
+	"delete_documents_by_query":          true,
+	"bulk_update_metadata":               true,
+	"count_documents":                    true,
+	"update_document":                    true,
+	"upsert_document":                    true,
+	"reembed_document":                   true,
+	"query_documents":                    true,
+	"query_documents_filtered":           true,
+	"query_documents_advanced":           true,
+	"search_by_vector":                   true,
+	"get_documents_by_metadata":          true,
+	"warm_cache":                         true,
+	"search_hybrid":                      true,
+	"search_bm25":                        true,
+	"count_collections":                  true,
+	"show_collection":                    true,
+	"compare_collections":                true,
+	"get_collection_config":              true,
+	"get_search_capabilities":            true,
+	"show_collection_embeddings":         true,
+	"get_collection_stats":               true,
+	"delete_all_documents":               true,
+	"show_document_by_name":              true,
+	"find_document":                      true,
+	"delete_document_by_name":            true,
+	"execute_query":                      true,
+	"smart_search":                       true,
+	"generative_search":                  true,
+	"nearest_neighbors_graph":            true,
+	"copy_collection":                    true,
+	"compact_collection":                 true,
+	"cluster_status":                     true,
+}
+
+// databaseContextKey is the context key of the database selected for a tool call
+type databaseContextKey struct{}
+
+// selectedDatabase is a non-default database chosen with the database argument
+type selectedDatabase struct {
+	config *config.VectorDBConfig
+	client vectordb.VectorDBClient
+}
+
+// withDatabaseArgument adds the database property to a tool's input schema and
+// wraps its handler to run against the named database when the argument is set
+func (s *Server) withDatabaseArgument(tool Tool) Tool {
+	if properties, ok := tool.InputSchema["properties"].(map[string]interface{}); ok {
+		properties["database"] = map[string]interface{}{
+			"type":        "string",
+			"description": "Name of a configured vector database to use instead of the default (see list_databases)",
+		}
+	}
+
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		ctx, err := s.selectDatabase(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, args)
+	}
+	return tool
+}
+
+// selectDatabase returns a context carrying the database named by the
+// database argument. The default database needs no selection.
+func (s *Server) selectDatabase(ctx context.Context, args map[string]interface{}) (context.Context, error) {
+	raw, present := args["database"]
+	if !present || raw == nil {
+		return ctx, nil
+	}
+	name, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("database must be a string")
+	}
+	if name == "" {
+		return ctx, nil
+	}
+
+	dbConfig, err := s.config.GetDatabase(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w; configured databases: %v", err, s.config.ListDatabases())
+	}
+	if defaultConfig, err := s.defaultDatabaseConfig(); err == nil && defaultConfig.Name == dbConfig.Name {
+		return ctx, nil
+	}
+	if !dbConfig.Enabled {
+		return nil, fmt.Errorf("database '%s' is disabled; set enabled: true in its configuration to use it", name)
+	}
+
+	client, err := s.databaseClient(dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("database '%s' is not available: %w", name, err)
+	}
+	return context.WithValue(ctx, databaseContextKey{}, &selectedDatabase{config: dbConfig, client: client}), nil
+}
+
+// databaseClient returns the cached client of a non-default database,
+// creating it on first use
+func (s *Server) databaseClient(dbConfig *config.VectorDBConfig) (vectordb.VectorDBClient, error) {
+	s.mu.RLock()
+	client, ok := s.dbClients[dbConfig.Name]
+	s.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	created, err := s.createVectorDBClient(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Another call may have created the client meanwhile; keep the first one
+	if client, ok := s.dbClients[dbConfig.Name]; ok {
+		return client, nil
+	}
+	if s.dbClients == nil {
+		s.dbClients = make(map[string]vectordb.VectorDBClient)
+	}
+	s.dbClients[dbConfig.Name] = created
+	s.logger.Info("Vector database client created",
+		zap.String("type", string(dbConfig.Type)),
+		zap.String("name", dbConfig.Name))
+	return created, nil
+}
+
+// db returns the vector database client for a call: the database selected
+// with the database argument, or the default database
+func (s *Server) db(ctx context.Context) vectordb.VectorDBClient {
+	var client vectordb.VectorDBClient
+	if selected, ok := ctx.Value(databaseContextKey{}).(*selectedDatabase); ok {
+		client = selected.client
+	} else {
+		s.mu.RLock()
+		client = s.dbClient
+		s.mu.RUnlock()
+	}
+	if adapter, ok := client.(*oidcAdapter); ok {
+		return s.currentAdapter(adapter)
+	}
+	return client
+}
+
+// databaseConfig returns the configuration of the database used by a call
+func (s *Server) databaseConfig(ctx context.Context) (*config.VectorDBConfig, error) {
+	if selected, ok := ctx.Value(databaseContextKey{}).(*selectedDatabase); ok {
+		return selected.config, nil
+	}
+	return s.defaultDatabaseConfig()
+}
+
+// defaultDatabaseConfig returns the configuration of the default database,
+// which switch_default_database changes at runtime
+func (s *Server) defaultDatabaseConfig() (*config.VectorDBConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.GetDefaultDatabase()
+}
+
+// databaseName returns the name of the database used by a call
+func (s *Server) databaseName(ctx context.Context) string {
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return ""
+	}
+	return dbConfig.Name
+}
+
+// withDatabaseOf carries the database selected in from over to ctx, for work
+// that outlives the request context such as async jobs
+func withDatabaseOf(ctx, from context.Context) context.Context {
+	if selected, ok := from.Value(databaseContextKey{}).(*selectedDatabase); ok {
+		return context.WithValue(ctx, databaseContextKey{}, selected)
+	}
+	return ctx
+}
+
+// handleListDatabases handles the list_databases tool, listing the databases
+// that can be named in the database argument of other tools
+func (s *Server) handleListDatabases(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	defaultName := ""
+	if dbConfig, err := s.defaultDatabaseConfig(); err == nil {
+		defaultName = dbConfig.Name
+	}
+
+	databases := make([]map[string]interface{}, len(s.config.Databases.VectorDatabases))
+	for i, db := range s.config.Databases.VectorDatabases {
+		databases[i] = map[string]interface{}{
+			"name":    db.Name,
+			"type":    string(db.Type),
+			"default": db.Name == defaultName,
+			"enabled": db.Enabled,
+			"url":     redactURL(db.URL),
+		}
+	}
+
+	return map[string]interface{}{
+		"databases":        databases,
+		"default_database": defaultName,
+		"count":            len(databases),
+	}, nil
+}
+
+// handleSwitchDefaultDatabase handles the switch_default_database tool. The
+// new client must pass a health check before it replaces the default one, so
+// a failed switch leaves the server on the previous database.
+func (s *Server) handleSwitchDefaultDatabase(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("database name is required")
+	}
+
+	dbConfig, err := s.config.GetDatabase(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w; configured databases: %v", err, s.config.ListDatabases())
+	}
+	if !dbConfig.Enabled {
+		return nil, fmt.Errorf("database '%s' is disabled; set enabled: true in its configuration to use it", name)
+	}
+
+	client, err := s.createVectorDBClient(dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("database '%s' is not available: %w", name, err)
+	}
+
+	healthCtx, cancel := context.WithTimeout(ctx,
+		vectordb.GetTimeoutForOperation(vectordb.OperationTypeHealth, isCloudDatabase(dbConfig), dbConfig.Timeout))
+	defer cancel()
+	if err := client.Health(healthCtx); err != nil {
+		return nil, fmt.Errorf("database '%s' failed its health check; the default database was not changed: %w", name, err)
+	}
+
+	s.mu.Lock()
+	previous := ""
+	if previousConfig, err := s.config.GetDefaultDatabase(); err == nil {
+		previous = previousConfig.Name
+	}
+	// Keep the previous client for calls that still name it with the database argument
+	if previous != "" && previous != name {
+		if s.dbClients == nil {
+			s.dbClients = make(map[string]vectordb.VectorDBClient)
+		}
+		if _, cached := s.dbClients[previous]; !cached {
+			s.dbClients[previous] = s.dbClient
+		}
+	}
+	s.dbClient = client
+	s.config.Databases.Default = name
+	s.mu.Unlock()
+
+	s.logger.Info("Default vector database switched",
+		zap.String("previous", previous),
+		zap.String("name", name),
+		zap.String("type", string(dbConfig.Type)))
+
+	return map[string]interface{}{
+		"previous": previous,
+		"current":  name,
+		"type":     string(dbConfig.Type),
+		"status":   "switched",
+	}, nil
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// deepHealthCollectionPrefix prefixes the throwaway collections created by
+// deep_health_check; a leftover one can always be deleted safely
+const deepHealthCollectionPrefix = "WeaveMcpHealthCheck"
+
+// Step statuses reported by deep_health_check
+const (
+	healthStepOK      = "ok"
+	healthStepFailed  = "failed"
+	healthStepSkipped = "skipped"
+)
+
+// writeFailureHints map substrings of write errors to likely causes
+var writeFailureHints = []struct {
+	markers []string
+	hint    string
+}{
+	{[]string{"read-only", "readonly", "read only"}, "the database is read-only; Weaviate marks shards READONLY under disk pressure (see compact_collection)"},
+	{[]string{"disk", "no space"}, "the database reports a disk problem, such as low free space"},
+	{[]string{"mutations"}, "mutations are disabled on this database"},
+	{[]string{"unauthorized", "forbidden", "401", "403", "permission"}, "the credentials may allow reads but not writes"},
+}
+
+// writeFailureHint suggests a cause for a failed write, or returns ""
+func writeFailureHint(err error) string {
+	message := strings.ToLower(err.Error())
+	for _, candidate := range writeFailureHints {
+		for _, marker := range candidate.markers {
+			if strings.Contains(message, marker) {
+				return candidate.hint
+			}
+		}
+	}
+	return ""
+}
+
+// handleDeepHealthCheck handles the deep_health_check tool: beyond the
+// connectivity check of health_check, it creates a throwaway collection,
+// writes, reads, and deletes a document in it, and deletes it again, to
+// verify the database accepts writes. Each step is timed and reported.
+func (s *Server) handleDeepHealthCheck(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	dbConfig, err := s.defaultDatabaseConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database config: %w", err)
+	}
+
+	collection := deepHealthCollectionPrefix + strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+	documentID := uuid.New().String()
+
+	steps := []map[string]interface{}{}
+	failed := false
+	// run times one step; after a failure, later steps are skipped unless
+	// they are cleanup
+	run := func(name string, operation vectordb.OperationType, cleanup bool, fn func(ctx context.Context) error) bool {
+		step := map[string]interface{}{"step": name}
+		steps = append(steps, step)
+		if failed && !cleanup {
+			step["status"] = healthStepSkipped
+			return false
+		}
+
+		timeoutCtx, cancel := s.createContextWithTimeout(ctx, operation)
+		defer cancel()
+
+		start := time.Now()
+		err := fn(timeoutCtx)
+		step["latency_ms"] = durationMillis(time.Since(start))
+		if err != nil {
+			failed = true
+			step["status"] = healthStepFailed
+			step["error"] = err.Error()
+			if hint := writeFailureHint(err); hint != "" {
+				step["hint"] = hint
+			}
+			return false
+		}
+		step["status"] = healthStepOK
+		return true
+	}
+
+	start := time.Now()
+	connected := run("connectivity", vectordb.OperationTypeHealth, false, func(ctx context.Context) error {
+		return s.db(ctx).Health(ctx)
+	})
+
+	created := run("create_collection", vectordb.OperationTypeCollection, false, func(ctx context.Context) error {
+		// No vectorizer, so an embedding provider outage is not reported as a database failure
+		return s.db(ctx).CreateCollection(ctx, collection, &vectordb.CollectionSchema{
+			Class:      collection,
+			Vectorizer: "none",
+			Properties: []vectordb.SchemaProperty{
+				{Name: "text", DataType: []string{"text"}},
+			},
+		})
+	})
+
+	run("create_document", vectordb.OperationTypeDocument, false, func(ctx context.Context) error {
+		return s.db(ctx).CreateDocument(ctx, collection, &vectordb.Document{
+			ID:      documentID,
+			Text:    "deep health check",
+			Content: "deep health check",
+		})
+	})
+
+	run("read_document", vectordb.OperationTypeDocument, false, func(ctx context.Context) error {
+		doc, err := s.db(ctx).GetDocument(ctx, collection, documentID)
+		if err != nil {
+			return err
+		}
+		if doc.ID != documentID {
+			return fmt.Errorf("read back document %s instead of %s", doc.ID, documentID)
+		}
+		return nil
+	})
+
+	run("delete_document", vectordb.OperationTypeDocument, false, func(ctx context.Context) error {
+		return s.db(ctx).DeleteDocument(ctx, collection, documentID)
+	})
+
+	// Always remove the collection once it exists
+	var leftover bool
+	if created {
+		leftover = !run("delete_collection", vectordb.OperationTypeCollection, true, func(ctx context.Context) error {
+			return s.db(ctx).DeleteCollection(ctx, collection)
+		})
+	} else {
+		steps = append(steps, map[string]interface{}{"step": "delete_collection", "status": healthStepSkipped})
+	}
+
+	status := "healthy"
+	if failed {
+		status = "unhealthy"
+	}
+	response := map[string]interface{}{
+		"status":     status,
+		"connected":  connected,
+		"writable":   !failed,
+		"database":   dbConfig.Name,
+		"type":       string(dbConfig.Type),
+		"url":        dbConfig.URL,
+		"collection": collection,
+		"steps":      steps,
+		"total_ms":   durationMillis(time.Since(start)),
+	}
+	if leftover {
+		response["leftover_collection"] = collection
+	}
+	return response, nil
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// defaultCollectionTools are the document and query tools whose required
+// collection argument falls back to the default collection. Tools where an
+// omitted collection means all collections are deliberately left out.
+var defaultCollectionTools = map[string]bool{
+	"list_documents":            true,
+	"create_document":           true,
+	"validate_document":         true,
+	"batch_create_documents":    true,
+	"get_document":              true,
+	"get_full_document":         true,
+	"preview_document":          true,
+	"delete_document":           true,
+	"delete_documents":          true,
+	"delete_documents_by_query": true,
+	"bulk_update_metadata":      true,
+	"count_documents":           true,
+	"update_document":           true,
+	"upsert_document":           true,
+	"reembed_document":          true,
+	"query_documents":           true,
+	"query_documents_filtered":  true,
+	"query_documents_advanced":  true,
+	"search_by_vector":          true,
+	"get_documents_by_metadata": true,
+	"search_hybrid":             true,
+	"search_bm25":               true,
+	"show_document_by_name":     true,
+	"find_document":             true,
+	"delete_document_by_name":   true,
+	"generative_search":         true,
+	"nearest_neighbors_graph":   true,
+}
+
+// defaultCollection returns the collection used when a call omits the
+// collection argument, or "" when none is set
+func (s *Server) defaultCollection() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.config == nil {
+		return ""
+	}
+	return s.config.DefaultCollection
+}
+
+// withDefaultCollection makes a tool's collection argument optional and
+// wraps its handler to fill it in with the default collection. The
+// collection used is added to map results that do not already report it.
+func (s *Server) withDefaultCollection(tool Tool) Tool {
+	if properties, ok := tool.InputSchema["properties"].(map[string]interface{}); ok {
+		if property, ok := properties["collection"].(map[string]interface{}); ok {
+			description, _ := property["description"].(string)
+			property["description"] = description + " (default: the default collection, see set_server_default_collection)"
+		}
+	}
+	if required, ok := tool.InputSchema["required"].([]string); ok {
+		remaining := make([]string, 0, len(required))
+		for _, name := range required {
+			if name != "collection" {
+				remaining = append(remaining, name)
+			}
+		}
+		tool.InputSchema["required"] = remaining
+	}
+
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		collection, _ := args["collection"].(string)
+		if collection == "" {
+			collection = s.defaultCollection()
+			if collection == "" {
+				return nil, fmt.Errorf("collection is required; set default_collection in the config or call set_server_default_collection to omit it")
+			}
+			withCollection := make(map[string]interface{}, len(args)+1)
+			for key, value := range args {
+				withCollection[key] = value
+			}
+			withCollection["collection"] = collection
+			args = withCollection
+		}
+
+		result, err := handler(ctx, args)
+		if response, ok := result.(map[string]interface{}); ok && err == nil {
+			if _, reported := response["collection"]; !reported {
+				response["collection"] = collection
+			}
+		}
+		return result, err
+	}
+	return tool
+}
+
+// handleSetServerDefaultCollection handles the set_server_default_collection
+// tool. The default is server-wide: there are no client sessions to scope it
+// to, so the change applies to every client. An empty name clears it, so
+// calls must name their collection again.
+func (s *Server) handleSetServerDefaultCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	raw, present := args["name"]
+	if !present {
+		return nil, fmt.Errorf("collection name is required (use an empty name to clear the default)")
+	}
+	name, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("name must be a string")
+	}
+
+	s.mu.Lock()
+	previous := s.config.DefaultCollection
+	s.config.DefaultCollection = name
+	s.mu.Unlock()
+
+	s.logger.Info("Default collection changed",
+		zap.String("previous", previous),
+		zap.String("name", name))
+
+	status := "set"
+	if name == "" {
+		status = "cleared"
+	}
+	return map[string]interface{}{
+		"previous": previous,
+		"current":  name,
+		"status":   status,
+	}, nil
+}
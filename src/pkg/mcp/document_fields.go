@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// commonURLFields are the metadata properties scanned, in order, for a
+// document's source locator when no url_field is configured
+var commonURLFields = []string{"url", "source", "uri", "link", "source_url"}
+
+// collectionConfig returns the configuration of a collection in the default
+// database, or nil if it is not configured
+func (s *Server) collectionConfig(ctx context.Context, collectionName string) *config.Collection {
+	if s.config == nil {
+		return nil
+	}
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil || dbConfig == nil {
+		return nil
+	}
+	return dbConfig.GetCollection(collectionName)
+}
+
+// documentURL returns the source locator of a document. A url_field configured
+// for the collection takes precedence, then the document's URL, then the
+// first non-empty common URL field in its metadata.
+func (s *Server) documentURL(ctx context.Context, collectionName string, doc *vectordb.Document) string {
+	if doc == nil {
+		return ""
+	}
+
+	if collection := s.collectionConfig(ctx, collectionName); collection != nil && collection.URLField != "" {
+		if value, ok := doc.Metadata[collection.URLField].(string); ok {
+			return value
+		}
+		return ""
+	}
+
+	if doc.URL != "" {
+		return doc.URL
+	}
+
+	for _, field := range commonURLFields {
+		if value, ok := doc.Metadata[field].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// contentFields returns the properties tried, in order, for the content of a
+// collection's documents: the collection's content_fields, then the
+// database's, then weaviate.DefaultContentFields
+func (s *Server) contentFields(ctx context.Context, collectionName string) []string {
+	if collection := s.collectionConfig(ctx, collectionName); collection != nil && len(collection.ContentFields) > 0 {
+		return collection.ContentFields
+	}
+	if dbConfig, err := s.databaseConfig(ctx); err == nil && dbConfig != nil && len(dbConfig.ContentFields) > 0 {
+		return dbConfig.ContentFields
+	}
+	return weaviate.DefaultContentFields
+}
+
+// documentContent returns the content of a document and the property it was
+// taken from, the first non-empty one of the collection's content fields.
+// Documents with none of them keep their content and report no field.
+func (s *Server) documentContent(ctx context.Context, collectionName string, doc *vectordb.Document) (string, string) {
+	properties := make(map[string]interface{}, len(doc.Metadata)+2)
+	for key, value := range doc.Metadata {
+		// Listings hold placeholders for large fields they left out
+		if value == weaviate.ExcludedContentPlaceholder || value == weaviate.ExcludedBase64Placeholder {
+			continue
+		}
+		properties[key] = value
+	}
+	if doc.Text != "" {
+		properties["text"] = doc.Text
+	}
+	// Adapters substitute this summary when a document has no content
+	if doc.Content != "" && doc.Content != fmt.Sprintf("Document ID: %s", doc.ID) {
+		properties["content"] = doc.Content
+	}
+
+	if content, field := weaviate.ExtractContent(properties, s.contentFields(ctx, collectionName)); field != "" {
+		return content, field
+	}
+	return doc.Content, ""
+}
+
+// documentMatchesFilename reports whether a document's URL contains filename
+// or its metadata filename equals it
+func (s *Server) documentMatchesFilename(ctx context.Context, collectionName string, doc *vectordb.Document, filename string) bool {
+	if url := s.documentURL(ctx, collectionName, doc); url != "" && strings.Contains(url, filename) {
+		return true
+	}
+	if doc.Metadata != nil {
+		if filenameVal, ok := doc.Metadata["filename"].(string); ok && filenameVal == filename {
+			return true
+		}
+	}
+	return false
+}
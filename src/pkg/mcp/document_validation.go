@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// validationIssue is a single validate_document error or warning
+type validationIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// documentValidator collects issues while checking a document against a schema
+type documentValidator struct {
+	properties map[string]vectordb.SchemaProperty
+	errors     []validationIssue
+	warnings   []validationIssue
+	checked    int
+}
+
+func newDocumentValidator(schema *vectordb.CollectionSchema) *documentValidator {
+	v := &documentValidator{properties: make(map[string]vectordb.SchemaProperty)}
+	for _, prop := range schema.Properties {
+		v.properties[prop.Name] = prop
+	}
+	return v
+}
+
+func (v *documentValidator) addError(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, validationIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *documentValidator) addWarning(field, format string, args ...interface{}) {
+	v.warnings = append(v.warnings, validationIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// similarProperty returns a schema property whose name differs from name
+// only by case, to point out typos like "Title" vs "title"
+func similarProperty(name string, properties map[string]vectordb.SchemaProperty) string {
+	for propName := range properties {
+		if strings.EqualFold(propName, name) {
+			return propName
+		}
+	}
+	return ""
+}
+
+// checkProperties validates top-level property values, which must all be in
+// the collection's schema
+func (v *documentValidator) checkProperties(collection string, properties map[string]interface{}) {
+	for _, key := range sortedKeys(properties) {
+		prop, inSchema := v.properties[key]
+		if !inSchema {
+			if similar := similarProperty(key, v.properties); similar != "" {
+				v.addError(key, "not in the schema of collection '%s'; did you mean %q?", collection, similar)
+			} else {
+				v.addError(key, "not in the schema of collection '%s'", collection)
+			}
+			continue
+		}
+		v.checkProperty(key, prop, properties[key])
+	}
+}
+
+// checkProperty validates a value against a schema property, recursing into
+// nested object properties
+func (v *documentValidator) checkProperty(field string, prop vectordb.SchemaProperty, value interface{}) {
+	v.checked++
+	if value == nil {
+		return
+	}
+	if len(prop.DataType) == 0 {
+		v.addWarning(field, "property has no data type in the schema; not checked")
+		return
+	}
+
+	dataType := prop.DataType[0]
+	elemType, isArray := strings.CutSuffix(dataType, "[]")
+	if !isArray {
+		v.checkValue(field, prop, dataType, value)
+		return
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		v.addError(field, "expected an array of %s, got %s", elemType, jsonTypeName(value))
+		return
+	}
+	for i, item := range items {
+		v.checkValue(fmt.Sprintf("%s[%d]", field, i), prop, elemType, item)
+	}
+}
+
+// checkValue validates a single (non-array) value against a Weaviate data type
+func (v *documentValidator) checkValue(field string, prop vectordb.SchemaProperty, dataType string, value interface{}) {
+	switch dataType {
+	case "text", "string", "blob":
+		if _, ok := value.(string); !ok {
+			v.addError(field, "expected %s (string), got %s", dataType, jsonTypeName(value))
+		}
+	case "uuid":
+		str, ok := value.(string)
+		if !ok || len(str) != 36 || strings.Count(str, "-") != 4 {
+			v.addError(field, "expected a UUID string, got %s", describeValue(value))
+		}
+	case "date":
+		str, ok := value.(string)
+		if !ok {
+			v.addError(field, "expected an RFC 3339 date string, got %s", jsonTypeName(value))
+		} else if _, err := time.Parse(time.RFC3339, str); err != nil {
+			v.addError(field, "expected an RFC 3339 date (e.g. 2006-01-02T15:04:05Z), got %q", str)
+		}
+	case "int":
+		number, ok := jsonNumber(value)
+		if !ok || number != math.Trunc(number) {
+			v.addError(field, "expected an integer, got %s", describeValue(value))
+		}
+	case "number":
+		if _, ok := jsonNumber(value); !ok {
+			v.addError(field, "expected a number, got %s", jsonTypeName(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.addError(field, "expected a boolean, got %s", jsonTypeName(value))
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			// A scalar stored in an object property later fails queries with
+			// "must not have a sub selection"
+			v.addError(field, "expected an object, got %s", jsonTypeName(value))
+			return
+		}
+		v.checkNested(field, prop, obj)
+	case "geoCoordinates":
+		obj, ok := value.(map[string]interface{})
+		_, hasLat := obj["latitude"]
+		_, hasLon := obj["longitude"]
+		if !ok || !hasLat || !hasLon {
+			v.addError(field, "expected an object with latitude and longitude, got %s", jsonTypeName(value))
+		}
+	case "phoneNumber":
+		if _, ok := value.(map[string]interface{}); !ok {
+			v.addError(field, "expected a phone number object (e.g. {\"input\": \"...\"}), got %s", jsonTypeName(value))
+		}
+	default:
+		// Cross-references use the target class name as data type
+		v.addWarning(field, "data type %q is not checked", dataType)
+	}
+}
+
+// checkNested validates the keys of an object value against its nested properties
+func (v *documentValidator) checkNested(field string, prop vectordb.SchemaProperty, obj map[string]interface{}) {
+	if len(prop.NestedProperties) == 0 {
+		return
+	}
+
+	nested := make(map[string]vectordb.SchemaProperty, len(prop.NestedProperties))
+	for _, nestedProp := range prop.NestedProperties {
+		nested[nestedProp.Name] = nestedProp
+	}
+
+	for _, key := range sortedKeys(obj) {
+		nestedField := field + "." + key
+		nestedProp, exists := nested[key]
+		if !exists {
+			if similar := similarProperty(key, nested); similar != "" {
+				v.addError(nestedField, "not a nested property of %s; did you mean %q?", field, similar)
+			} else {
+				v.addError(nestedField, "not a nested property of %s", field)
+			}
+			continue
+		}
+		v.checkProperty(nestedField, nestedProp, obj[key])
+	}
+}
+
+// jsonNumber returns a numeric JSON value as float64
+func jsonNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonTypeName names the JSON type of a value for error messages
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, int, int64, json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// describeValue names a value's type, including scalar values for context
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case string, bool, float64, float32, int, int64, json.Number:
+		return fmt.Sprintf("%s %v", jsonTypeName(value), value)
+	}
+	return jsonTypeName(value)
+}
+
+// sortedKeys returns map keys in sorted order so issues are reported deterministically
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// handleValidateDocument handles the validate_document tool. It checks a
+// document against the collection schema without writing it.
+func (s *Server) handleValidateDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	// Create context with schema operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+	defer cancel()
+
+	schema, err := s.getSchema(timeoutCtx, collection)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get collection schema", err)
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("schema not available for collection '%s'", collection)
+	}
+
+	v := newDocumentValidator(schema)
+
+	// url and text are required by create_document and stored as properties
+	// of the same name when the schema has them
+	for _, field := range []string{"url", "text"} {
+		value, exists := args[field]
+		if !exists {
+			v.addError(field, "required by create_document")
+			continue
+		}
+		if prop, inSchema := v.properties[field]; inSchema {
+			v.checkProperty(field, prop, value)
+		} else if _, isString := value.(string); !isString {
+			v.addError(field, "expected a string, got %s", jsonTypeName(value))
+		}
+	}
+
+	if rawMetadata, exists := args["metadata"]; exists && rawMetadata != nil {
+		metadata, ok := rawMetadata.(map[string]interface{})
+		if !ok {
+			v.addError("metadata", "expected an object, got %s", jsonTypeName(rawMetadata))
+		} else if prop, inSchema := v.properties["metadata"]; inSchema && len(prop.DataType) > 0 && prop.DataType[0] == "object" {
+			// Object metadata is stored natively, so its keys must match nested properties
+			v.checkProperty("metadata", prop, metadata)
+		} else {
+			// Text metadata is stored as JSON; keys matching top-level
+			// properties may be promoted, so check their types
+			for _, key := range sortedKeys(metadata) {
+				if prop, inSchema := v.properties[key]; inSchema {
+					v.checkProperty("metadata."+key, prop, metadata[key])
+				}
+			}
+		}
+	}
+
+	if rawProperties, exists := args["properties"]; exists && rawProperties != nil {
+		properties, ok := rawProperties.(map[string]interface{})
+		if !ok {
+			v.addError("properties", "expected an object, got %s", jsonTypeName(rawProperties))
+		} else {
+			v.checkProperties(collection, properties)
+		}
+	}
+
+	errors := v.errors
+	if errors == nil {
+		errors = []validationIssue{}
+	}
+	warnings := v.warnings
+	if warnings == nil {
+		warnings = []validationIssue{}
+	}
+
+	return map[string]interface{}{
+		"collection":         collection,
+		"valid":              len(v.errors) == 0,
+		"errors":             errors,
+		"warnings":           warnings,
+		"checked_properties": v.checked,
+	}, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// Limits on the number of documents get_documents_by_metadata returns
+const (
+	defaultMetadataMatchLimit = 100
+	maxMetadataMatchLimit     = 1000
+)
+
+// handleGetDocumentsByMetadata handles the get_documents_by_metadata tool,
+// returning every document whose metadata matches the filters
+func (s *Server) handleGetDocumentsByMetadata(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	filters, err := parseFiltersArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := getIntArg(args, "limit", defaultMetadataMatchLimit)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	if limit > maxMetadataMatchLimit {
+		return nil, fmt.Errorf("limit must be at most %d", maxMetadataMatchLimit)
+	}
+	idsOnly, _ := args["ids_only"].(bool)
+
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	var documents []*vectordb.Document
+	if s.requireWeaviateDatabase(ctx, "metadata filters") == nil {
+		documents, err = s.weaviateDocumentsByMetadata(timeoutCtx, collection, filters.expression(), limit, idsOnly)
+	} else {
+		// Other databases only support exact metadata matches
+		var results []*vectordb.QueryResult
+		results, err = s.queryMetadataFiltered(timeoutCtx, collection, limit, filters)
+		for _, result := range results {
+			documents = append(documents, &result.Document)
+		}
+	}
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get documents by metadata", err)
+	}
+
+	response := map[string]interface{}{
+		"collection":    collection,
+		"filters":       filters.applied(),
+		"count":         len(documents),
+		"limit_reached": len(documents) >= limit,
+	}
+	if idsOnly {
+		ids := make([]string, len(documents))
+		for i, doc := range documents {
+			ids[i] = doc.ID
+		}
+		response["ids"] = ids
+		return response, nil
+	}
+
+	formatted := make([]map[string]interface{}, len(documents))
+	for i, doc := range documents {
+		formatted[i] = map[string]interface{}{
+			"id":       doc.ID,
+			"content":  doc.Content,
+			"url":      s.documentURL(ctx, collection, doc),
+			"metadata": doc.Metadata,
+		}
+	}
+	response["documents"] = formatted
+	return response, nil
+}
+
+// weaviateDocumentsByMetadata finds matching document IDs with one GraphQL
+// query and, unless idsOnly is set, fetches each matching document
+func (s *Server) weaviateDocumentsByMetadata(ctx context.Context, collection string, filter weaviate.FilterExpression, limit int, idsOnly bool) ([]*vectordb.Document, error) {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := client.GetDocumentIDsByFilter(ctx, collection, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*vectordb.Document, 0, len(ids))
+	for _, id := range ids {
+		if idsOnly {
+			documents = append(documents, &vectordb.Document{ID: id})
+			continue
+		}
+		doc, err := s.db(ctx).GetDocument(ctx, collection, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document %s: %w", id, err)
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
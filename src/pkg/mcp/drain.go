@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// callTracker counts in-flight tool calls and async jobs so shutdown can
+// wait for them
+type callTracker struct {
+	wg     sync.WaitGroup
+	active atomic.Int64
+}
+
+// begin records the start of a call; the returned func records its end
+func (t *callTracker) begin() func() {
+	t.wg.Add(1)
+	t.active.Add(1)
+	return func() {
+		t.active.Add(-1)
+		t.wg.Done()
+	}
+}
+
+// Drain waits for in-flight tool calls and running async jobs to finish, so
+// a shutdown does not cut bulk operations off halfway. It returns an error
+// when ctx expires first. Call it after the HTTP server has stopped
+// accepting requests and before Cleanup.
+func (s *Server) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.calls.wg.Wait()
+		close(done)
+	}()
+
+	if active := s.calls.active.Load(); active > 0 {
+		s.logger.Info("Waiting for in-flight tool calls", zap.Int64("active", active))
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d tool calls still running: %w", s.calls.active.Load(), ctx.Err())
+	}
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultEmbeddingTimeout is the extra time allowed for server-side vectorization
+	defaultEmbeddingTimeout = 30 * time.Second
+	// defaultEmbeddingRetries is the number of retries on embedding provider 429/5xx
+	defaultEmbeddingRetries = 2
+	// embeddingRetryBaseDelay is the first retry delay, doubled on each retry
+	embeddingRetryBaseDelay = 500 * time.Millisecond
+)
+
+// vectorizerErrorPrefixes start the messages Weaviate returns when a
+// vectorizer module or its embedding provider fails
+var vectorizerErrorPrefixes = []string{
+	"vectorize",
+	"update vector",
+	"text2vec-",
+	"multi2vec-",
+	"img2vec-",
+	"ref2vec-",
+}
+
+// embeddingStatusPattern matches the provider HTTP status reported in a
+// vectorizer error, as in "connection to: OpenAI API failed with status: 429"
+var embeddingStatusPattern = regexp.MustCompile(`\bstatus(?: code)?:? (\d{3})\b`)
+
+// embeddingError is a failure from the embedding provider after retries
+type embeddingError struct {
+	attempts int
+	err      error
+}
+
+func (e *embeddingError) Error() string {
+	return fmt.Sprintf("embedding provider error after %d attempt(s): %v", e.attempts, e.err)
+}
+
+func (e *embeddingError) Unwrap() error {
+	return e.err
+}
+
+// isEmbeddingError reports whether an error came from the embedding provider
+func isEmbeddingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var embErr *embeddingError
+	if errors.As(err, &embErr) {
+		return true
+	}
+	return vectorizerMessage(err) != ""
+}
+
+// isRetryableEmbeddingError reports whether an embedding provider error is a
+// rate limit or server error that may succeed on retry
+func isRetryableEmbeddingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := vectorizerMessage(err)
+	if message == "" {
+		return false
+	}
+	if match := embeddingStatusPattern.FindStringSubmatch(message); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+	}
+	return strings.Contains(message, "rate limit") ||
+		strings.Contains(message, "too many requests") ||
+		strings.Contains(message, "service unavailable") ||
+		strings.Contains(message, "bad gateway")
+}
+
+// vectorizerMessage returns the lowercased vectorizer failure reported in
+// err, from its first vectorizer prefix on, or "" when err does not report
+// one. Weaviate status errors are read from their JSON error messages;
+// other errors from their text.
+func vectorizerMessage(err error) string {
+	messages := []string{err.Error()}
+	var clientErr *fault.WeaviateClientError
+	if errors.As(err, &clientErr) {
+		var body struct {
+			Error []struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal([]byte(clientErr.Msg), &body) == nil && len(body.Error) > 0 {
+			messages = messages[:0]
+			for _, e := range body.Error {
+				messages = append(messages, e.Message)
+			}
+		}
+	}
+
+	for _, message := range messages {
+		segments := strings.Split(strings.ToLower(message), ": ")
+		for i, segment := range segments {
+			segment = strings.TrimSpace(segment)
+			for _, prefix := range vectorizerErrorPrefixes {
+				if strings.HasPrefix(segment, prefix) {
+					return strings.Join(segments[i:], ": ")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// embeddingSettings returns the embedding timeout and retry count for the
+// default database
+func (s *Server) embeddingSettings(ctx context.Context) (time.Duration, int) {
+	timeout, retries := defaultEmbeddingTimeout, defaultEmbeddingRetries
+
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return timeout, retries
+	}
+	if dbConfig.EmbeddingTimeout > 0 {
+		timeout = time.Duration(dbConfig.EmbeddingTimeout) * time.Second
+	}
+	switch {
+	case dbConfig.EmbeddingRetries < 0:
+		retries = 0
+	case dbConfig.EmbeddingRetries > 0:
+		retries = dbConfig.EmbeddingRetries
+	}
+	return timeout, retries
+}
+
+// withEmbedding runs a write that makes the database vectorize content. Each
+// attempt gets the operation timeout plus the embedding timeout. When retry is
+// set, embedding provider 429/5xx errors are retried with exponential backoff.
+// Embedding failures are returned as *embeddingError so they can be told
+// apart from database failures.
+func (s *Server) withEmbedding(ctx context.Context, opType vectordb.OperationType, retry bool, fn func(ctx context.Context) error) error {
+	embeddingTimeout, retries := s.embeddingSettings(ctx)
+	if !retry {
+		retries = 0
+	}
+	timeout := s.operationTimeout(ctx, opType) + embeddingTimeout
+
+	var err error
+	attempt := 0
+	for {
+		attempt++
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = fn(attemptCtx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if attempt > retries || !isRetryableEmbeddingError(err) {
+			break
+		}
+
+		delay := embeddingRetryBaseDelay << (attempt - 1)
+		s.logger.Warn("Embedding provider error, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return &embeddingError{attempts: attempt, err: err}
+		case <-time.After(delay):
+		}
+	}
+
+	if isEmbeddingError(err) {
+		return &embeddingError{attempts: attempt, err: err}
+	}
+	return err
+}
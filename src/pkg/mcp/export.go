@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+	"go.uber.org/zap"
+)
+
+const (
+	// exportPageSize is the number of documents fetched per page by an export
+	exportPageSize = 100
+	// exportRetries is the number of retries of a page or document fetch that
+	// fails with a transient error
+	exportRetries = 3
+	// exportRetryBaseDelay is the first retry delay, doubled on each retry
+	exportRetryBaseDelay = 500 * time.Millisecond
+)
+
+// transientStatusPattern matches HTTP statuses in database errors worth retrying
+var transientStatusPattern = regexp.MustCompile(`status code:? (429|5\d\d)\b`)
+
+// transientErrorMarkers identify network failures worth retrying
+var transientErrorMarkers = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"unexpected eof",
+	"i/o timeout",
+	"too many requests",
+	"service unavailable",
+	"bad gateway",
+	"gateway timeout",
+}
+
+// handleExport handles GET /mcp/export, which streams the documents of the
+// collection query parameter as newline-delimited JSON, one document per
+// line, fetching them a page at a time so neither side holds the whole
+// collection. With include_vectors=true each document also carries its
+// vector (Weaviate only).
+//
+// Fetches failing with transient errors are retried. An export that still
+// fails after the first document ends with an {"error": ..., "cursor": ...}
+// line, since the status has already been sent; passing the cursor as after
+// resumes it. With progress=true a {"cursor": ...} line follows each page so
+// clients can checkpoint. The cursor is the offset of the next document, so
+// resuming assumes the collection did not change in between.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	collection := query.Get("collection")
+	if collection == "" {
+		http.Error(w, "collection is required", http.StatusBadRequest)
+		return
+	}
+
+	includeVectors := false
+	if value := query.Get("include_vectors"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "include_vectors must be true or false", http.StatusBadRequest)
+			return
+		}
+		includeVectors = parsed
+	}
+
+	after := 0
+	if value := query.Get("after"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			http.Error(w, "after must be a cursor from a previous export", http.StatusBadRequest)
+			return
+		}
+		after = parsed
+	}
+
+	progress := false
+	if value := query.Get("progress"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "progress must be true or false", http.StatusBadRequest)
+			return
+		}
+		progress = parsed
+	}
+
+	ctx := r.Context()
+
+	// The vectordb adapter does not return vectors, so read them through the
+	// Weaviate client
+	var vectorClient *weaviate.Client
+	if includeVectors {
+		if err := s.requireWeaviateDatabase(ctx, "include_vectors"); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		vectorClient = client
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	release, ok := s.acquireStream(w, r, streamExport)
+	if !ok {
+		return
+	}
+	defer release()
+
+	// Large collections outlast the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	// http.Error replaces the content type if the export fails before any output
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	encoder := json.NewEncoder(w)
+	exported := 0
+	cursor := func() string { return strconv.Itoa(after + exported) }
+	fail := func(err error) {
+		s.logger.Warn("Export failed",
+			zap.String("collection", collection),
+			zap.Int("exported", exported),
+			zap.String("cursor", cursor()),
+			zap.Error(err))
+		if exported == 0 {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = encoder.Encode(map[string]interface{}{"error": err.Error(), "exported": exported, "cursor": cursor()})
+	}
+
+	for offset := after; ; offset += exportPageSize {
+		var documents []*vectordb.Document
+		err := s.retryTransient(ctx, "list documents", func() error {
+			listCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+			defer cancel()
+			var err error
+			documents, err = s.db(ctx).ListDocuments(listCtx, collection, exportPageSize, offset)
+			return err
+		})
+		if err != nil {
+			fail(s.enhanceError(ctx, "failed to list documents", err))
+			return
+		}
+
+		for _, doc := range documents {
+			entry, err := s.exportDocument(ctx, collection, doc, vectorClient)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if err := encoder.Encode(entry); err != nil {
+				// The client went away
+				return
+			}
+			exported++
+		}
+		if progress && len(documents) > 0 {
+			if err := encoder.Encode(map[string]interface{}{"cursor": cursor(), "exported": exported}); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		if len(documents) < exportPageSize {
+			break
+		}
+	}
+
+	s.logger.Info("Exported documents",
+		zap.String("collection", collection),
+		zap.Int("count", exported))
+}
+
+// exportDocument returns the exported form of a listed document. Listings
+// leave out large fields, so documents with placeholders are fetched whole,
+// as are all documents when vectorClient is set, to read their vectors.
+func (s *Server) exportDocument(ctx context.Context, collection string, doc *vectordb.Document, vectorClient *weaviate.Client) (map[string]interface{}, error) {
+	var vector []float32
+	if _, hasLargeFields := listedDocumentSizes(doc); hasLargeFields || vectorClient != nil {
+		if vectorClient != nil {
+			var weaviateDoc *weaviate.Document
+			err := s.retryTransient(ctx, "get document", func() error {
+				timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+				defer cancel()
+				var err error
+				weaviateDoc, err = vectorClient.GetDocumentWithVector(timeoutCtx, collection, doc.ID)
+				return err
+			})
+			if err != nil {
+				return nil, s.enhanceError(ctx, "failed to get document "+doc.ID, err)
+			}
+			doc = &vectordb.Document{
+				ID:        weaviateDoc.ID,
+				Text:      weaviateDoc.Text,
+				Content:   weaviateDoc.Content,
+				Image:     weaviateDoc.Image,
+				ImageData: weaviateDoc.ImageData,
+				URL:       weaviateDoc.URL,
+				Metadata:  weaviateDoc.Metadata,
+			}
+			vector = weaviateDoc.Vector
+		} else {
+			var full *vectordb.Document
+			err := s.retryTransient(ctx, "get document", func() error {
+				timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+				defer cancel()
+				var err error
+				full, err = s.db(ctx).GetDocument(timeoutCtx, collection, doc.ID)
+				return err
+			})
+			if err != nil {
+				return nil, s.enhanceError(ctx, "failed to get document "+doc.ID, err)
+			}
+			doc = full
+		}
+	}
+
+	entry := map[string]interface{}{
+		"id":       doc.ID,
+		"url":      s.documentURL(ctx, collection, doc),
+		"text":     doc.Text,
+		"content":  doc.Content,
+		"metadata": doc.Metadata,
+	}
+	if doc.Image != "" {
+		entry["image"] = doc.Image
+	}
+	if vectorClient != nil {
+		addVector(entry, vector)
+	}
+	return entry, nil
+}
+
+// retryTransient runs fn, retrying transient failures with exponential
+// backoff until exportRetries retries have been made
+func (s *Server) retryTransient(ctx context.Context, operation string, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt > exportRetries || ctx.Err() != nil || !isTransientError(err) {
+			return err
+		}
+
+		delay := exportRetryBaseDelay << (attempt - 1)
+		s.logger.Warn("Transient error, retrying",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isTransientError reports whether a database error is a timeout, network
+// failure, or rate limit or server error that may succeed on retry
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	errStr := strings.ToLower(err.Error())
+	if transientStatusPattern.MatchString(errStr) {
+		return true
+	}
+	for _, marker := range transientErrorMarkers {
+		if strings.Contains(errStr, marker) {
+			return true
+		}
+	}
+	return false
+}
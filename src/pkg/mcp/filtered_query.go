@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// handleQueryDocumentsFiltered handles the query_documents_filtered tool
+func (s *Server) handleQueryDocumentsFiltered(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	filters, err := parseFiltersArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	var results []*vectordb.QueryResult
+	var note string
+	if s.requireWeaviateDatabase(ctx, "filtered semantic search") == nil {
+		results, err = s.queryWeaviateFiltered(timeoutCtx, collection, query, limit, filters.expression())
+	} else {
+		// Other databases only support exact metadata matches, without ranking by the query
+		results, err = s.queryMetadataFiltered(timeoutCtx, collection, limit, filters)
+		note = "this database does not support filtered semantic search; results match the filters but are not ranked by the query"
+	}
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to query documents", err)
+	}
+
+	// Convert results to the query_documents format
+	var result []map[string]interface{}
+	for _, res := range results {
+		result = append(result, map[string]interface{}{
+			"id":       res.Document.ID,
+			"content":  res.Document.Content,
+			"text":     res.Document.Text,
+			"url":      s.documentURL(ctx, collection, &res.Document),
+			"metadata": res.Document.Metadata,
+			"score":    res.Score,
+		})
+	}
+
+	response := map[string]interface{}{
+		"results":    result,
+		"count":      len(result),
+		"collection": collection,
+		"query":      query,
+		"filters":    filters.applied(),
+	}
+	if note != "" {
+		response["note"] = note
+	}
+	return response, nil
+}
+
+// queryWeaviateFiltered runs a semantic search restricted by filters through the Weaviate client
+func (s *Server) queryWeaviateFiltered(ctx context.Context, collection, query string, limit int, filter weaviate.FilterExpression) ([]*vectordb.QueryResult, error) {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	weaviateResults, err := client.QueryWithFilterExpression(ctx, collection, query, weaviate.QueryOptions{TopK: limit}, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*vectordb.QueryResult, len(weaviateResults))
+	for i, res := range weaviateResults {
+		results[i] = &vectordb.QueryResult{
+			Document: vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata},
+			Score:    res.Score,
+		}
+	}
+	return results, nil
+}
+
+// queryMetadataFiltered finds documents matching equality filters with SearchByMetadata
+func (s *Server) queryMetadataFiltered(ctx context.Context, collection string, limit int, filters *filtersArg) ([]*vectordb.QueryResult, error) {
+	if filters.nested != nil {
+		return nil, fmt.Errorf("nested filters with operator and operands are only supported for Weaviate databases")
+	}
+	metadata := make(map[string]interface{}, len(filters.flat))
+	for _, filter := range filters.flat {
+		if filter.Operator != "Equal" {
+			return nil, fmt.Errorf("filter operator %s on '%s' is only supported for Weaviate databases", filter.Operator, filter.Key)
+		}
+		metadata[filter.Key] = filter.Value
+	}
+	return s.db(ctx).SearchByMetadata(ctx, collection, metadata, &vectordb.QueryOptions{TopK: limit})
+}
+
+// filtersArg is a parsed filters argument: either property filters that
+// must all match, or a nested And/Or/Not expression
+type filtersArg struct {
+	flat   []weaviate.MetadataFilter
+	nested *weaviate.FilterExpression
+	raw    map[string]interface{}
+}
+
+// parseFiltersArg parses the filters argument. An object with "operator" and
+// "operands" is a nested expression (see weaviate.ParseFilterExpression);
+// any other object is parsed as property filters (see weaviate.ParseFilterMap).
+func parseFiltersArg(args map[string]interface{}) (*filtersArg, error) {
+	raw, ok := args["filters"].(map[string]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("filters are required")
+	}
+
+	if isFilterExpression(raw) {
+		expression, err := weaviate.ParseFilterExpression(raw)
+		if err != nil {
+			return nil, err
+		}
+		return &filtersArg{nested: &expression, raw: raw}, nil
+	}
+
+	flat, err := weaviate.ParseFilterMap(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &filtersArg{flat: flat, raw: raw}, nil
+}
+
+// isFilterExpression reports whether a filters object is a nested expression
+// rather than property filters
+func isFilterExpression(raw map[string]interface{}) bool {
+	_, hasOperator := raw["operator"]
+	_, hasOperands := raw["operands"]
+	return hasOperator && hasOperands
+}
+
+// expression returns the filters as a single expression
+func (f *filtersArg) expression() weaviate.FilterExpression {
+	if f.nested != nil {
+		return *f.nested
+	}
+	return weaviate.AllOf(f.flat...)
+}
+
+// applied echoes the filters in responses: property filters as parsed, a
+// nested expression as given
+func (f *filtersArg) applied() map[string]interface{} {
+	if f.nested != nil {
+		return f.raw
+	}
+	return appliedFilters(f.flat)
+}
+
+// appliedFilters echoes parsed filters as {operator, value} objects keyed by property
+func appliedFilters(filters []weaviate.MetadataFilter) map[string]interface{} {
+	applied := make(map[string]interface{}, len(filters))
+	for _, filter := range filters {
+		applied[filter.Key] = map[string]interface{}{
+			"operator": filter.Operator,
+			"value":    filter.Value,
+		}
+	}
+	return applied
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+const (
+	// sourceDocumentKey is the chunk metadata naming the document a chunk belongs to
+	sourceDocumentKey = "source_document"
+	// chunkIndexKey is the chunk metadata giving a chunk's position in its document
+	chunkIndexKey = "chunk_index"
+	// maxFullDocumentChunks caps the chunks get_full_document assembles
+	maxFullDocumentChunks = 1000
+)
+
+// documentChunk is one chunk of a document being assembled
+type documentChunk struct {
+	id      string
+	index   int
+	indexed bool
+	content string
+}
+
+// handleGetFullDocument handles the get_full_document tool
+func (s *Server) handleGetFullDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	documentID, _ := args["document_id"].(string)
+	source, _ := args["source"].(string)
+	if documentID == "" && source == "" {
+		return nil, fmt.Errorf("document_id or source is required")
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	// Resolve the source document from the given chunk
+	if source == "" {
+		doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to get document", err)
+		}
+		source, _ = doc.Metadata[sourceDocumentKey].(string)
+		if source == "" {
+			// Not a chunk: the document is already complete
+			content := documentContent(doc)
+			return map[string]interface{}{
+				"collection":  collection,
+				"document_id": documentID,
+				"text":        content,
+				"chunk_count": 1,
+				"chunk_ids":   []string{doc.ID},
+				"chunked":     false,
+			}, nil
+		}
+	}
+
+	results, err := s.db(ctx).SearchByMetadata(timeoutCtx, collection,
+		map[string]interface{}{sourceDocumentKey: source},
+		&vectordb.QueryOptions{TopK: maxFullDocumentChunks})
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to find document chunks", err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no chunks found for source '%s' in collection %s", source, collection)
+	}
+
+	chunks := make([]documentChunk, 0, len(results))
+	seen := make(map[string]bool, len(results))
+	for _, res := range results {
+		if seen[res.Document.ID] {
+			continue
+		}
+		seen[res.Document.ID] = true
+		index, indexed := chunkIndex(res.Document.Metadata)
+		chunks = append(chunks, documentChunk{
+			id:      res.Document.ID,
+			index:   index,
+			indexed: indexed,
+			content: documentContent(&res.Document),
+		})
+	}
+
+	// Order by chunk_index; chunks without one keep their order after the rest
+	sort.SliceStable(chunks, func(i, j int) bool {
+		if chunks[i].indexed != chunks[j].indexed {
+			return chunks[i].indexed
+		}
+		return chunks[i].index < chunks[j].index
+	})
+
+	texts := make([]string, len(chunks))
+	chunkIDs := make([]string, len(chunks))
+	var unindexed []string
+	for i, chunk := range chunks {
+		texts[i] = chunk.content
+		chunkIDs[i] = chunk.id
+		if !chunk.indexed {
+			unindexed = append(unindexed, chunk.id)
+		}
+	}
+
+	response := map[string]interface{}{
+		"collection":  collection,
+		"source":      source,
+		"text":        strings.Join(texts, "\n"),
+		"chunk_count": len(chunks),
+		"chunk_ids":   chunkIDs,
+		"chunked":     true,
+		"truncated":   len(results) >= maxFullDocumentChunks,
+	}
+	if documentID != "" {
+		response["document_id"] = documentID
+	}
+	if len(unindexed) > 0 {
+		response["unindexed_chunk_ids"] = unindexed
+	}
+	return response, nil
+}
+
+// documentContent returns a document's content, falling back to its text
+func documentContent(doc *vectordb.Document) string {
+	if doc.Content != "" {
+		return doc.Content
+	}
+	return doc.Text
+}
+
+// chunkIndex reads the chunk_index metadata of a chunk, which may arrive as a
+// JSON number, an int, or a string
+func chunkIndex(metadata map[string]interface{}) (int, bool) {
+	switch v := metadata[chunkIndexKey].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case string:
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
@@ -7,17 +7,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os/exec"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/maximilien/weave-cli/src/pkg/agents"
 	"github.com/maximilien/weave-cli/src/pkg/logging"
 	"github.com/maximilien/weave-cli/src/pkg/metrics"
 	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
 )
 
 // generateCorrelationID generates a unique correlation ID for request tracking
@@ -32,7 +42,7 @@ func (s *Server) withMetrics(operation string, handler func(ctx context.Context,
 		correlationID := generateCorrelationID()
 
 		// Get VDB type for metrics
-		dbConfig, _ := s.config.GetDefaultDatabase()
+		dbConfig, _ := s.databaseConfig(ctx)
 		vdbType := "unknown"
 		if dbConfig != nil {
 			vdbType = string(dbConfig.Type)
@@ -55,8 +65,14 @@ func (s *Server) withMetrics(operation string, handler func(ctx context.Context,
 		metrics.RecordRequest(vdbType, operation, duration, err)
 
 		if err != nil {
-			logger.Error("Operation failed: %v", err)
-			metrics.RecordError(vdbType, operation, categorizeError(err))
+			category := categorizeError(err)
+			if category == "auth" {
+				// Some databases echo the API key back in auth errors
+				logger.Error("Operation failed: the database rejected the credentials")
+			} else {
+				logger.Error("Operation failed: %v", err)
+			}
+			metrics.RecordError(vdbType, operation, category)
 		} else {
 			logger.Debug("Operation completed successfully in %v", duration)
 		}
@@ -76,19 +92,25 @@ func (s *Server) withMetrics(operation string, handler func(ctx context.Context,
 	}
 }
 
+// authStatusPattern matches a 401 or 403 status in an error message, as in
+// "status code: 401" or "HTTP 403"
+var authStatusPattern = regexp.MustCompile(`\b(?:status(?: code)?:?|http) (401|403)\b`)
+
 // categorizeError categorizes errors for metrics
 func categorizeError(err error) string {
 	if err == nil {
 		return "none"
 	}
-	errStr := err.Error()
+	errStr := strings.ToLower(err.Error())
 	switch {
+	case isEmbeddingError(err):
+		return "embedding"
+	case isAuthError(err, errStr):
+		return "auth"
 	case strings.Contains(errStr, "connection refused"), strings.Contains(errStr, "dial tcp"):
 		return "connection"
 	case strings.Contains(errStr, "timeout"), strings.Contains(errStr, "deadline exceeded"):
 		return "timeout"
-	case strings.Contains(errStr, "authentication"), strings.Contains(errStr, "unauthorized"):
-		return "auth"
 	case strings.Contains(errStr, "not found"):
 		return "not_found"
 	default:
@@ -96,14 +118,30 @@ func categorizeError(err error) string {
 	}
 }
 
+// isAuthError reports whether the database rejected a call's credentials,
+// from the error's type or status or, failing that, its lowercased text
+func isAuthError(err error, errStr string) bool {
+	var dbErr *vectordb.VectorDBError
+	if errors.As(err, &dbErr) && dbErr.Type == vectordb.ErrorTypeAuthentication {
+		return true
+	}
+	var clientErr *fault.WeaviateClientError
+	if errors.As(err, &clientErr) && (clientErr.StatusCode == http.StatusUnauthorized || clientErr.StatusCode == http.StatusForbidden) {
+		return true
+	}
+	return strings.Contains(errStr, "authentication") ||
+		strings.Contains(errStr, "unauthorized") ||
+		authStatusPattern.MatchString(errStr)
+}
+
 // enhanceError adds helpful context to database errors with VDB type prefix
-func (s *Server) enhanceError(operation string, err error) error {
+func (s *Server) enhanceError(ctx context.Context, operation string, err error) error {
 	if err == nil {
 		return nil
 	}
 
 	// Get database type from config
-	dbConfig, configErr := s.config.GetDefaultDatabase()
+	dbConfig, configErr := s.databaseConfig(ctx)
 	if configErr != nil {
 		// Fallback if we can't get config
 		return fmt.Errorf("%s: %w", operation, err)
@@ -112,6 +150,12 @@ func (s *Server) enhanceError(operation string, err error) error {
 	vdbType := string(dbConfig.Type)
 	errStr := err.Error()
 
+	// Check for embedding provider errors first: they can mention timeouts or
+	// connections too, but the database itself is reachable
+	if isEmbeddingError(err) {
+		return fmt.Errorf("%s: %s: embedding provider failed - the database is reachable but its vectorizer (e.g. OpenAI) returned an error. Original error: %w", vdbType, operation, err)
+	}
+
 	// Check for common connection/network errors
 	if strings.Contains(errStr, "connection refused") ||
 		strings.Contains(errStr, "dial tcp") ||
@@ -138,23 +182,83 @@ func (s *Server) enhanceError(operation string, err error) error {
 
 // createContextWithTimeout creates a context with operation-specific timeout
 func (s *Server) createContextWithTimeout(ctx context.Context, opType vectordb.OperationType) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.operationTimeout(ctx, opType))
+}
+
+// operationTimeout returns the timeout for an operation type on the default database
+func (s *Server) operationTimeout(ctx context.Context, opType vectordb.OperationType) time.Duration {
 	// Get database config to determine if cloud or local
-	dbConfig, err := s.config.GetDefaultDatabase()
+	dbConfig, err := s.databaseConfig(ctx)
 	if err != nil {
 		// Fallback to default timeout
-		return context.WithTimeout(ctx, 30*time.Second)
+		return 30 * time.Second
 	}
 
-	// Determine if cloud deployment (heuristic based on type)
-	isCloud := strings.Contains(string(dbConfig.Type), "cloud") ||
+	// Get timeout for this operation type
+	return vectordb.GetTimeoutForOperation(opType, isCloudDatabase(dbConfig), dbConfig.Timeout)
+}
+
+// isCloudDatabase reports whether a database is a cloud deployment (heuristic based on type)
+func isCloudDatabase(dbConfig *config.VectorDBConfig) bool {
+	return strings.Contains(string(dbConfig.Type), "cloud") ||
 		dbConfig.Type == "supabase" ||
 		dbConfig.Type == "mongodb" ||
 		dbConfig.Type == "pinecone"
+}
 
-	// Get timeout for this operation type
-	timeout := vectordb.GetTimeoutForOperation(opType, isCloud, dbConfig.Timeout)
+// getIntArg reads an integer argument that may arrive as a JSON number, int, or string
+func getIntArg(args map[string]interface{}, key string, defaultValue int) int {
+	switch v := args[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case string:
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getFloatArg extracts a float argument, handling JSON numbers and strings
+func getFloatArg(args map[string]interface{}, key string, defaultValue float64) float64 {
+	switch v := args[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
 
-	return context.WithTimeout(ctx, timeout)
+// floatArg reads an optional float argument that may arrive as a JSON number
+// or a numeric string, returning defaultValue when it is absent and an error
+// when it is not a number
+func floatArg(args map[string]interface{}, key string, defaultValue float64) (float64, error) {
+	switch v := args[key].(type) {
+	case nil:
+		return defaultValue, nil
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed, nil
+		}
+	}
+	return 0, fmt.Errorf("%s must be a number, got %v", key, args[key])
 }
 
 // handleListCollections handles the list_collections tool
@@ -163,9 +267,32 @@ func (s *Server) handleListCollections(ctx context.Context, args map[string]inte
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
 	defer cancel()
 
-	collections, err := s.dbClient.ListCollections(timeoutCtx)
+	collections, err := s.db(ctx).ListCollections(timeoutCtx)
 	if err != nil {
-		return nil, s.enhanceError("failed to list collections", err)
+		return nil, s.enhanceError(ctx, "failed to list collections", err)
+	}
+
+	// The listing already carries counts and vectorizers, saving a
+	// count_documents call per collection
+	if includeDetails, _ := args["include_details"].(bool); includeDetails {
+		details := make([]map[string]interface{}, 0, len(collections))
+		for _, coll := range collections {
+			detail := map[string]interface{}{
+				"name":  coll.Name,
+				"count": coll.Count,
+			}
+			if coll.Vectorizer != "" {
+				detail["vectorizer"] = coll.Vectorizer
+			}
+			if coll.Description != "" {
+				detail["description"] = coll.Description
+			}
+			details = append(details, detail)
+		}
+		return map[string]interface{}{
+			"collections": details,
+			"count":       len(details),
+		}, nil
 	}
 
 	// Convert to string array for consistent output
@@ -180,8 +307,18 @@ func (s *Server) handleListCollections(ctx context.Context, args map[string]inte
 	}, nil
 }
 
+// defaultVectorizer is the vectorizer used when create_collection is not given one
+const defaultVectorizer = "text2vec-openai"
+
 // handleCreateCollection handles the create_collection tool
 func (s *Server) handleCreateCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	// Fill in the parameters the call leaves to its template
+	templateName, _ := args["template"].(string)
+	args, err := s.applyCollectionTemplate(args)
+	if err != nil {
+		return nil, err
+	}
+
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("collection name is required")
@@ -189,17 +326,30 @@ func (s *Server) handleCreateCollection(ctx context.Context, args map[string]int
 
 	collectionType, ok := args["type"].(string)
 	if !ok {
-		return nil, fmt.Errorf("collection type is required")
+		return nil, fmt.Errorf("collection type is required (as an argument or from the template)")
 	}
 
 	description, _ := args["description"].(string)
 
 	// Get vectorizer/embedding model (default to text2vec-openai)
-	vectorizer := "text2vec-openai"
+	vectorizer := defaultVectorizer
 	if v, ok := args["vectorizer"].(string); ok && v != "" {
 		vectorizer = v
 	}
 
+	// Optional HNSW vector index tuning (Weaviate only)
+	var indexConfig *weaviate.VectorIndexConfig
+	if rawIndexConfig, ok := args["vector_index_config"].(map[string]interface{}); ok && len(rawIndexConfig) > 0 {
+		parsed, err := weaviate.ParseVectorIndexConfig(rawIndexConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.requireWeaviateDatabase(ctx, "vector_index_config"); err != nil {
+			return nil, err
+		}
+		indexConfig = parsed
+	}
+
 	// Create basic schema based on type
 	schema := &vectordb.CollectionSchema{
 		Class:      name,
@@ -228,22 +378,263 @@ func (s *Server) handleCreateCollection(ctx context.Context, args map[string]int
 		})
 	}
 
+	// Optional custom properties, merged with the defaults
+	var customProperties []vectordb.SchemaProperty
+	if rawProperties, ok := args["properties"]; ok && rawProperties != nil {
+		parsed, err := parseCollectionProperties(rawProperties, schema.Properties)
+		if err != nil {
+			return nil, err
+		}
+		customProperties = parsed
+		schema.Properties = append(schema.Properties, customProperties...)
+	}
+
+	// Optional per-property tokenization (Weaviate only)
+	var tokenization map[string]string
+	if rawTokenization, ok := args["tokenization"].(map[string]interface{}); ok && len(rawTokenization) > 0 {
+		parsed, err := parseTokenization(rawTokenization, schema.Properties)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.requireWeaviateDatabase(ctx, "tokenization"); err != nil {
+			return nil, err
+		}
+		tokenization = parsed
+	}
+
+	// Return the resolved schema without creating anything
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		preview := map[string]interface{}{
+			"name":              name,
+			"type":              collectionType,
+			"description":       description,
+			"vectorizer":        vectorizer,
+			"collection_schema": weaviateCollectionSchema(schema, indexConfig, tokenization),
+			"dry_run":           true,
+			"status":            "preview",
+		}
+		if templateName != "" {
+			preview["template"] = templateName
+		}
+		return preview, nil
+	}
+
 	// Create context with collection operation timeout
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
 	defer cancel()
 
-	err := s.dbClient.CreateCollection(timeoutCtx, name, schema)
+	if indexConfig != nil || tokenization != nil || (len(customProperties) > 0 && s.requireWeaviateDatabase(ctx, "properties") == nil) {
+		// The vectordb schema has no index or tokenization settings, and the
+		// Weaviate adapter ignores custom properties, so create through the Weaviate REST API
+		err = s.createWeaviateCollection(timeoutCtx, schema, indexConfig, tokenization)
+	} else {
+		err = s.db(ctx).CreateCollection(timeoutCtx, name, schema)
+	}
+	s.invalidateCollectionCaches(ctx, name)
 	if err != nil {
-		return nil, s.enhanceError("failed to create collection", err)
+		return nil, s.enhanceError(ctx, "failed to create collection", err)
 	}
 
-	return map[string]interface{}{
+	response := map[string]interface{}{
 		"name":        name,
 		"type":        collectionType,
 		"description": description,
 		"vectorizer":  vectorizer,
 		"status":      "created",
-	}, nil
+	}
+	if indexConfig != nil {
+		response["vector_index_config"] = indexConfig
+	}
+	if tokenization != nil {
+		response["tokenization"] = tokenization
+	}
+	if len(customProperties) > 0 {
+		response["properties"] = customProperties
+	}
+	if templateName != "" {
+		response["template"] = templateName
+	}
+	return response, nil
+}
+
+// collectionDataTypes are the data types accepted for custom collection properties
+var collectionDataTypes = []string{"text", "string", "int", "number", "boolean", "date", "object"}
+
+// parseCollectionProperties converts the properties argument of
+// create_collection, an array of {name, data_type} objects, into schema
+// properties, rejecting unknown data types and names already in use
+func parseCollectionProperties(raw interface{}, defaults []vectordb.SchemaProperty) ([]vectordb.SchemaProperty, error) {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("properties must be an array of {name, data_type} objects")
+	}
+
+	used := make(map[string]bool, len(defaults)+len(items))
+	for _, prop := range defaults {
+		used[prop.Name] = true
+	}
+
+	properties := make([]vectordb.SchemaProperty, 0, len(items))
+	for i, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("properties[%d] must be an object with name and data_type", i)
+		}
+		name, _ := entry["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("properties[%d].name is required", i)
+		}
+		if used[name] {
+			return nil, fmt.Errorf("property '%s' is already defined by the collection", name)
+		}
+		dataType, _ := entry["data_type"].(string)
+		known := false
+		for _, valid := range collectionDataTypes {
+			if dataType == valid {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("property '%s' has unknown data_type '%s': must be one of %s",
+				name, dataType, strings.Join(collectionDataTypes, ", "))
+		}
+		used[name] = true
+
+		property := vectordb.SchemaProperty{Name: name, DataType: []string{dataType}}
+		property.Description, _ = entry["description"].(string)
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// parseTokenization validates a property name to tokenization map against the
+// collection's text properties
+func parseTokenization(raw map[string]interface{}, properties []vectordb.SchemaProperty) (map[string]string, error) {
+	textProperties := make(map[string]bool, len(properties))
+	for _, prop := range properties {
+		for _, dataType := range prop.DataType {
+			if dataType == "text" || dataType == "text[]" {
+				textProperties[prop.Name] = true
+			}
+		}
+	}
+
+	tokenization := make(map[string]string, len(raw))
+	for property, value := range raw {
+		if !textProperties[property] {
+			return nil, fmt.Errorf("tokenization property '%s' is not a text property of the collection", property)
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("tokenization.%s must be a string", property)
+		}
+		normalized, err := weaviate.ValidateTokenization(str)
+		if err != nil {
+			return nil, fmt.Errorf("tokenization.%s: %w", property, err)
+		}
+		tokenization[property] = normalized
+	}
+	return tokenization, nil
+}
+
+// requireWeaviateDatabase returns an error if the default database is not Weaviate
+func (s *Server) requireWeaviateDatabase(ctx context.Context, feature string) error {
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if dbConfig.Type != config.VectorDBTypeCloud && dbConfig.Type != config.VectorDBTypeLocal {
+		return fmt.Errorf("%s is only supported for Weaviate databases (current: %s)", feature, dbConfig.Type)
+	}
+	return nil
+}
+
+// newWeaviateClient creates a Weaviate REST client for the default database
+func (s *Server) newWeaviateClient(ctx context.Context) (*weaviate.Client, error) {
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig, err := weaviateClientConfig(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+	return weaviate.NewClient(clientConfig)
+}
+
+// weaviateClientConfig returns the Weaviate client configuration of a
+// database, including its authentication mode
+func weaviateClientConfig(dbConfig *config.VectorDBConfig) (*weaviate.Config, error) {
+	authMode, err := dbConfig.ResolvedAuthMode()
+	if err != nil {
+		return nil, err
+	}
+
+	searchFallback, err := dbConfig.ResolvedSearchFallback()
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig := &weaviate.Config{
+		URL:                dbConfig.URL,
+		APIKey:             dbConfig.APIKey,
+		OpenAIAPIKey:       dbConfig.OpenAIAPIKey,
+		AuthMode:           authMode,
+		SearchFallback:     searchFallback,
+		MaxFallbackDepth:   dbConfig.MaxFallbackDepth,
+		ScoreNormalization: dbConfig.ScoreNormalization,
+
+		MaxIdleConnsPerHost: dbConfig.MaxIdleConnsPerHost,
+		IdleConnTimeout:     time.Duration(dbConfig.IdleConnTimeout) * time.Second,
+
+		ContentFields: dbConfig.ContentFields,
+	}
+	for _, collection := range dbConfig.Collections {
+		if len(collection.ContentFields) > 0 {
+			if clientConfig.CollectionContentFields == nil {
+				clientConfig.CollectionContentFields = make(map[string][]string)
+			}
+			clientConfig.CollectionContentFields[collection.Name] = collection.ContentFields
+		}
+	}
+	if dbConfig.OIDC != nil {
+		clientConfig.OIDC = &weaviate.OIDCConfig{
+			TokenURL:     dbConfig.OIDC.TokenURL,
+			ClientID:     dbConfig.OIDC.ClientID,
+			ClientSecret: dbConfig.OIDC.ClientSecret,
+			Scopes:       dbConfig.OIDC.Scopes,
+		}
+	}
+	return clientConfig, nil
+}
+
+// createWeaviateCollection creates a collection with vector index and
+// property tokenization settings directly through the Weaviate REST API
+func (s *Server) createWeaviateCollection(ctx context.Context, schema *vectordb.CollectionSchema, indexConfig *weaviate.VectorIndexConfig, tokenization map[string]string) error {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.CreateCollectionFromSchema(ctx, weaviateCollectionSchema(schema, indexConfig, tokenization))
+}
+
+// weaviateCollectionSchema combines a collection schema with its index and
+// tokenization settings into the schema sent to Weaviate
+func weaviateCollectionSchema(schema *vectordb.CollectionSchema, indexConfig *weaviate.VectorIndexConfig, tokenization map[string]string) *weaviate.CollectionSchema {
+	weaviateSchema := &weaviate.CollectionSchema{
+		Class:             schema.Class,
+		Vectorizer:        schema.Vectorizer,
+		Properties:        make([]weaviate.SchemaProperty, len(schema.Properties)),
+		VectorIndexConfig: indexConfig,
+	}
+	for i, prop := range schema.Properties {
+		weaviateSchema.Properties[i] = weaviateSchemaProperty(prop)
+		weaviateSchema.Properties[i].Tokenization = tokenization[prop.Name]
+	}
+	return weaviateSchema
 }
 
 // handleDeleteCollection handles the delete_collection tool
@@ -253,13 +644,25 @@ func (s *Server) handleDeleteCollection(ctx context.Context, args map[string]int
 		return nil, fmt.Errorf("collection name is required")
 	}
 
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		return s.deleteCollectionDryRun(ctx, name)
+	}
+
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	// Create context with collection operation timeout
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
 	defer cancel()
 
-	err := s.dbClient.DeleteCollection(timeoutCtx, name)
+	err = s.db(ctx).DeleteCollection(timeoutCtx, name)
+	s.invalidateCollectionCaches(ctx, name)
 	if err != nil {
-		return nil, s.enhanceError("failed to delete collection", err)
+		return nil, s.enhanceError(ctx, "failed to delete collection", err)
 	}
 
 	return map[string]interface{}{
@@ -268,6 +671,33 @@ func (s *Server) handleDeleteCollection(ctx context.Context, args map[string]int
 	}, nil
 }
 
+// deleteCollectionDryRun reports whether a collection exists and how many
+// documents deleting it would remove, without deleting anything
+func (s *Server) deleteCollectionDryRun(ctx context.Context, name string) (interface{}, error) {
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
+	defer cancel()
+
+	exists, err := s.db(ctx).CollectionExists(timeoutCtx, name)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to check collection", err)
+	}
+
+	response := map[string]interface{}{
+		"name":    name,
+		"exists":  exists,
+		"dry_run": true,
+		"status":  "preview",
+	}
+	if exists {
+		count, err := s.getCollectionCount(timeoutCtx, name)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to count documents", err)
+		}
+		response["document_count"] = count
+	}
+	return response, nil
+}
+
 // handleListDocuments handles the list_documents tool
 func (s *Server) handleListDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	collection, ok := args["collection"].(string)
@@ -275,41 +705,126 @@ func (s *Server) handleListDocuments(ctx context.Context, args map[string]interf
 		return nil, fmt.Errorf("collection name is required")
 	}
 
-	limit := 10
-	if limitStr, ok := args["limit"].(string); ok {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil {
-			limit = parsedLimit
-		}
-	} else if limitInt, ok := args["limit"].(int); ok {
-		limit = limitInt
+	limit := getIntArg(args, "limit", 10)
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	offset := getIntArg(args, "offset", 0)
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative")
 	}
 
 	// Create context with query operation timeout (listing is a query)
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
 	defer cancel()
 
-	documents, err := s.dbClient.ListDocuments(timeoutCtx, collection, limit, 0)
+	// A limit of 0 means count only: aggregate the count without fetching documents
+	if limit == 0 {
+		count, err := s.getCollectionCount(timeoutCtx, collection)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to count documents", err)
+		}
+		return map[string]interface{}{
+			"documents":  []map[string]interface{}{},
+			"count":      count,
+			"count_only": true,
+			"collection": collection,
+		}, nil
+	}
+
+	// Fetch the total count concurrently with the page unless the caller opts out
+	includeTotal := true
+	if v, ok := args["include_total_count"].(bool); ok {
+		includeTotal = v
+	}
+
+	type countResult struct {
+		count int64
+		err   error
+	}
+	var totalCh chan countResult
+	if includeTotal {
+		totalCh = make(chan countResult, 1)
+		go func() {
+			count, err := s.getCollectionCount(timeoutCtx, collection)
+			totalCh <- countResult{count: count, err: err}
+		}()
+	}
+
+	// Fetch one extra document to tell whether another page follows
+	documents, err := s.db(ctx).ListDocuments(timeoutCtx, collection, limit+1, offset)
 	if err != nil {
-		return nil, s.enhanceError("failed to list documents", err)
+		return nil, s.enhanceError(ctx, "failed to list documents", err)
+	}
+	hasMore := len(documents) > limit
+	if hasMore {
+		documents = documents[:limit]
 	}
 
 	// Convert documents to a more MCP-friendly format
 	var result []map[string]interface{}
 	for _, doc := range documents {
+		contentSize, hasLargeFields := listedDocumentSizes(doc)
+		content, contentField := s.documentContent(ctx, collection, doc)
 		result = append(result, map[string]interface{}{
-			"id":       doc.ID,
-			"url":      doc.URL,
-			"text":     doc.Text,
-			"content":  doc.Content,
-			"metadata": doc.Metadata,
+			"id":                 doc.ID,
+			"url":                s.documentURL(ctx, collection, doc),
+			"text":               doc.Text,
+			"content":            content,
+			"content_field":      contentField,
+			"metadata":           doc.Metadata,
+			"content_size_bytes": contentSize,
+			"has_large_fields":   hasLargeFields,
 		})
 	}
 
-	return map[string]interface{}{
+	response := map[string]interface{}{
 		"documents":  result,
 		"count":      len(result),
 		"collection": collection,
-	}, nil
+		"offset":     offset,
+		"limit":      limit,
+		"has_more":   hasMore,
+	}
+	if hasMore {
+		response["next_offset"] = offset + len(result)
+	}
+
+	if totalCh != nil {
+		// A failed count should not fail the listing itself
+		total := <-totalCh
+		if total.err != nil {
+			s.logger.Warn(fmt.Sprintf("Failed to get total document count for %s: %v", collection, total.err))
+		} else {
+			response["total_count"] = total.count
+		}
+	}
+
+	return response, nil
+}
+
+// listedDocumentSizes returns the size of a listed document's content and
+// whether the listing left out large fields, which are marked with
+// placeholders in the metadata
+func listedDocumentSizes(doc *vectordb.Document) (int, bool) {
+	content := doc.Content
+	if content == "" {
+		content = doc.Text
+	}
+	// Listings substitute this summary when a document has no content
+	if content == fmt.Sprintf("Document ID: %s", doc.ID) {
+		content = ""
+	}
+
+	hasLargeFields := false
+	for _, value := range doc.Metadata {
+		if placeholder, ok := value.(string); ok &&
+			(placeholder == weaviate.ExcludedContentPlaceholder || placeholder == weaviate.ExcludedBase64Placeholder) {
+			hasLargeFields = true
+			break
+		}
+	}
+	return len(content), hasLargeFields
 }
 
 // handleCreateDocument handles the create_document tool
@@ -334,6 +849,34 @@ func (s *Server) handleCreateDocument(ctx context.Context, args map[string]inter
 		metadata = make(map[string]interface{})
 	}
 
+	// Optional property receiving the text instead of both text and content
+	textField, _ := args["text_field"].(string)
+	if textField != "" {
+		if err := s.requireWeaviateDatabase(ctx, "text_field"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Optional custom top-level properties, such as those added by create_collection
+	var properties map[string]interface{}
+	if rawProperties, exists := args["properties"]; exists && rawProperties != nil {
+		parsed, ok := rawProperties.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("properties must be an object")
+		}
+		for name := range parsed {
+			if reservedDocumentProperties[name] || name == textField {
+				return nil, fmt.Errorf("property '%s' is set by create_document itself; use its own argument instead", name)
+			}
+		}
+		if len(parsed) > 0 {
+			if err := s.requireWeaviateDatabase(ctx, "properties"); err != nil {
+				return nil, err
+			}
+			properties = parsed
+		}
+	}
+
 	// Create document using vectordb client
 	doc := &vectordb.Document{
 		URL:      url,
@@ -342,22 +885,112 @@ func (s *Server) handleCreateDocument(ctx context.Context, args map[string]inter
 		Metadata: metadata,
 	}
 
-	// Create context with document operation timeout
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
-	defer cancel()
-
-	err := s.dbClient.CreateDocument(timeoutCtx, collection, doc)
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Metadata must match the schema's metadata property type, which the
+	// adapter only infers from the collection name
+	schemaCtx, schemaCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+	metadataFormat := s.schemaMetadataFormat(schemaCtx, collection)
+	if textField != "" {
+		if err := s.checkTextField(schemaCtx, collection, textField); err != nil {
+			schemaCancel()
+			return nil, err
+		}
+	}
+	if properties != nil {
+		if err := s.checkDocumentProperties(schemaCtx, collection, properties); err != nil {
+			schemaCancel()
+			return nil, err
+		}
+	}
+	schemaCancel()
+
+	// Creating a document vectorizes it, so allow for the embedding provider
+	err = s.withEmbedding(ctx, vectordb.OperationTypeDocument, true, func(ctx context.Context) error {
+		// The weave-cli adapter always writes both text and content, and no
+		// other top-level properties
+		if metadataFormat != "" || textField != "" || properties != nil {
+			opts := documentWriteOptions{textField: textField, properties: properties}
+			return s.createDocumentsWithSchemaFormat(ctx, collection, []*vectordb.Document{doc}, opts)
+		}
+		return s.db(ctx).CreateDocument(ctx, collection, doc)
+	})
 	if err != nil {
-		return nil, s.enhanceError("failed to create document", err)
+		return nil, s.enhanceError(ctx, "failed to create document", err)
 	}
 
-	return map[string]interface{}{
+	response := map[string]interface{}{
 		"collection": collection,
 		"url":        url,
 		"text":       text,
 		"metadata":   metadata,
 		"status":     "created",
-	}, nil
+	}
+	if textField != "" {
+		response["text_field"] = textField
+	}
+	if properties != nil {
+		response["properties"] = properties
+	}
+	return response, nil
+}
+
+// reservedDocumentProperties are the properties create_document sets from
+// its own arguments
+var reservedDocumentProperties = map[string]bool{
+	"text":     true,
+	"content":  true,
+	"url":      true,
+	"image":    true,
+	"metadata": true,
+}
+
+// checkDocumentProperties validates create_document properties against the
+// collection schema, as validate_document does
+func (s *Server) checkDocumentProperties(ctx context.Context, collection string, properties map[string]interface{}) error {
+	schema, err := s.getSchema(ctx, collection)
+	if err != nil {
+		return s.enhanceError(ctx, "failed to get collection schema", err)
+	}
+	if schema == nil {
+		return fmt.Errorf("schema not available for collection '%s'", collection)
+	}
+
+	v := newDocumentValidator(schema)
+	v.checkProperties(collection, properties)
+	if len(v.errors) == 0 {
+		return nil
+	}
+	issues := make([]string, len(v.errors))
+	for i, issue := range v.errors {
+		issues[i] = issue.Field + ": " + issue.Message
+	}
+	return fmt.Errorf("invalid properties: %s", strings.Join(issues, "; "))
+}
+
+// checkTextField returns an error unless field is a text property of the
+// collection's schema
+func (s *Server) checkTextField(ctx context.Context, collection, field string) error {
+	schema, err := s.getSchema(ctx, collection)
+	if err != nil {
+		return s.enhanceError(ctx, "failed to get collection schema", err)
+	}
+	if schema != nil {
+		for _, prop := range schema.Properties {
+			if prop.Name == field {
+				if len(prop.DataType) > 0 && prop.DataType[0] == "text" {
+					return nil
+				}
+				return fmt.Errorf("text_field '%s' is not a text property of collection '%s'", field, collection)
+			}
+		}
+	}
+	return fmt.Errorf("text_field '%s' is not a property of collection '%s'", field, collection)
 }
 
 // handleBatchCreateDocuments handles the batch_create_documents tool
@@ -376,52 +1009,113 @@ func (s *Server) handleBatchCreateDocuments(ctx context.Context, args map[string
 		return nil, fmt.Errorf("documents array cannot be empty")
 	}
 
-	// Parse and validate all documents first
+	if maxBatch := s.config.BatchSizeLimit(); maxBatch >= 0 && len(documentsArg) > maxBatch {
+		return nil, fmt.Errorf("batch of %d documents exceeds max_batch_size (%d); split it into smaller batches", len(documentsArg), maxBatch)
+	}
+
+	// Parse all documents first; invalid ones are reported and skipped
 	documents := make([]*vectordb.Document, 0, len(documentsArg))
+	indices := make([]int, 0, len(documentsArg))
+	failed := make([]map[string]interface{}, 0)
 	for i, docArg := range documentsArg {
-		docMap, ok := docArg.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("document at index %d is not a valid object", i)
+		doc, err := parseBatchDocument(docArg)
+		if err != nil {
+			failed = append(failed, map[string]interface{}{"index": i, "error": err.Error()})
+			continue
 		}
+		documents = append(documents, doc)
+		indices = append(indices, i)
+	}
 
-		url, ok := docMap["url"].(string)
-		if !ok {
-			return nil, fmt.Errorf("document at index %d: URL is required", i)
-		}
+	response := map[string]interface{}{
+		"collection": collection,
+		"total":      len(documentsArg),
+	}
 
-		text, ok := docMap["text"].(string)
-		if !ok {
-			return nil, fmt.Errorf("document at index %d: text is required", i)
+	if len(documents) > 0 {
+		// Serialize writes to this collection (in-process advisory lock)
+		unlock, err := s.lockCollection(ctx, collection)
+		if err != nil {
+			return nil, err
 		}
+		defer unlock()
 
-		metadata, _ := docMap["metadata"].(map[string]interface{})
-		if metadata == nil {
-			metadata = make(map[string]interface{})
-		}
+		schemaCtx, schemaCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+		metadataFormat := s.schemaMetadataFormat(schemaCtx, collection)
+		schemaCancel()
 
-		doc := &vectordb.Document{
-			URL:      url,
-			Text:     text,
-			Content:  text, // Use text as content
-			Metadata: metadata,
+		// Create all documents in batch
+		err = s.createDocumentBatch(ctx, collection, documents, metadataFormat)
+		if err != nil {
+			// The batch reports a single error, so every submitted document is
+			// reported failed even though earlier ones may have been stored
+			batchErr := s.enhanceError(ctx, "failed to create documents in batch", err).Error()
+			for _, index := range indices {
+				failed = append(failed, map[string]interface{}{"index": index, "error": batchErr})
+			}
+			sort.Slice(failed, func(i, j int) bool {
+				return failed[i]["index"].(int) < failed[j]["index"].(int)
+			})
+			documents = nil
+			response["note"] = "the batch failed as a whole; documents before the failing one may already be stored"
 		}
-		documents = append(documents, doc)
 	}
 
-	// Create context with bulk operation timeout
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeBulk)
-	defer cancel()
-
-	// Create all documents in batch
-	err := s.dbClient.CreateDocuments(timeoutCtx, collection, documents)
-	if err != nil {
-		return nil, s.enhanceError("failed to create documents in batch", err)
+	status := "created"
+	switch {
+	case len(documents) == 0:
+		status = "failed"
+	case len(failed) > 0:
+		status = "partial"
 	}
 
-	return map[string]interface{}{
-		"collection": collection,
-		"count":      len(documents),
-		"status":     "created",
+	response["count"] = len(documents)
+	response["failed_count"] = len(failed)
+	response["failed"] = failed
+	response["status"] = status
+	return response, nil
+}
+
+// createDocumentBatch creates documents in one batch, formatting metadata for
+// the collection's schema when metadataFormat is set. Batches are not retried
+// since part of a failed batch may already be stored.
+func (s *Server) createDocumentBatch(ctx context.Context, collection string, documents []*vectordb.Document, metadataFormat string) error {
+	return s.withEmbedding(ctx, vectordb.OperationTypeBulk, false, func(ctx context.Context) error {
+		if metadataFormat != "" {
+			return s.createDocumentsWithSchemaFormat(ctx, collection, documents, documentWriteOptions{})
+		}
+		return s.db(ctx).CreateDocuments(ctx, collection, documents)
+	})
+}
+
+// parseBatchDocument converts one entry of the batch_create_documents
+// documents array into a document
+func parseBatchDocument(docArg interface{}) (*vectordb.Document, error) {
+	docMap, ok := docArg.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document is not a valid object")
+	}
+
+	url, ok := docMap["url"].(string)
+	if !ok {
+		return nil, fmt.Errorf("URL is required")
+	}
+
+	text, ok := docMap["text"].(string)
+	if !ok {
+		return nil, fmt.Errorf("text is required")
+	}
+
+	metadata, _ := docMap["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	return &vectordb.Document{
+		URL:      url,
+		Text:     text,
+		Content:  text, // Use text as content
+		Metadata: metadata,
 	}, nil
 }
 
@@ -437,28 +1131,40 @@ func (s *Server) handleGetDocument(ctx context.Context, args map[string]interfac
 		return nil, fmt.Errorf("document ID is required")
 	}
 
+	if includeVectorArg(args) {
+		return s.getDocumentWithVector(ctx, collection, documentID)
+	}
+
 	// Create context with document operation timeout
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
 	defer cancel()
 
 	// Get document using vectordb client
-	doc, err := s.dbClient.GetDocument(timeoutCtx, collection, documentID)
+	doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
 	if err != nil {
-		return nil, s.enhanceError("failed to get document", err)
+		return nil, s.enhanceError(ctx, "failed to get document", err)
 	}
 
+	content, contentField := s.documentContent(ctx, collection, doc)
 	return map[string]interface{}{
-		"id":         doc.ID,
-		"url":        doc.URL,
-		"text":       doc.Text,
-		"content":    doc.Content,
-		"metadata":   doc.Metadata,
-		"collection": collection,
+		"id":            doc.ID,
+		"url":           s.documentURL(ctx, collection, doc),
+		"text":          doc.Text,
+		"content":       content,
+		"content_field": contentField,
+		"metadata":      doc.Metadata,
+		"collection":    collection,
 	}, nil
 }
 
-// handleDeleteDocument handles the delete_document tool
-func (s *Server) handleDeleteDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+// defaultPreviewLength is the default number of content characters returned by preview_document
+const defaultPreviewLength = 200
+
+// previewMetadataKeys are the small metadata fields included in document previews
+var previewMetadataKeys = []string{"filename", "original_filename", "type", "content_type", "date", "created_at", "modified_at"}
+
+// handlePreviewDocument handles the preview_document tool
+func (s *Server) handlePreviewDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	collection, ok := args["collection"].(string)
 	if !ok {
 		return nil, fmt.Errorf("collection name is required")
@@ -469,369 +1175,2055 @@ func (s *Server) handleDeleteDocument(ctx context.Context, args map[string]inter
 		return nil, fmt.Errorf("document ID is required")
 	}
 
+	length := getIntArg(args, "length", defaultPreviewLength)
+	if length <= 0 {
+		return nil, fmt.Errorf("length must be a positive integer")
+	}
+
 	// Create context with document operation timeout
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
 	defer cancel()
 
-	// Delete document using vectordb client
-	err := s.dbClient.DeleteDocument(timeoutCtx, collection, documentID)
+	doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
 	if err != nil {
-		return nil, s.enhanceError("failed to delete document", err)
+		return nil, s.enhanceError(ctx, "failed to get document", err)
+	}
+
+	content := doc.Content
+	if content == "" {
+		content = doc.Text
+	}
+	excerpt, truncated := truncateRunes(content, length)
+
+	// Only include small, well-known metadata fields (never large content or image data)
+	metadata := make(map[string]interface{})
+	for _, key := range previewMetadataKeys {
+		if value, exists := doc.Metadata[key]; exists {
+			metadata[key] = value
+		}
 	}
 
 	return map[string]interface{}{
-		"document_id": documentID,
-		"collection":  collection,
-		"status":      "deleted",
+		"id":         doc.ID,
+		"url":        s.documentURL(ctx, collection, doc),
+		"excerpt":    excerpt,
+		"truncated":  truncated,
+		"metadata":   metadata,
+		"collection": collection,
 	}, nil
 }
 
-// handleCountDocuments handles the count_documents tool
-func (s *Server) handleCountDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+// truncateRunes shortens text to at most maxRunes characters without splitting
+// multi-byte characters, appending "…" when the text was truncated
+func truncateRunes(text string, maxRunes int) (string, bool) {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text, false
+	}
+	runes := []rune(text)
+	return string(runes[:maxRunes]) + "…", true
+}
+
+// handleDeleteDocument handles the delete_document tool
+func (s *Server) handleDeleteDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	collection, ok := args["collection"].(string)
 	if !ok {
 		return nil, fmt.Errorf("collection name is required")
 	}
 
-	// Create context with query operation timeout (counting is a query)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	documentID, ok := args["document_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	// Preview the delete by fetching the document instead
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+		defer cancel()
+
+		doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to get document", err)
+		}
+		return map[string]interface{}{
+			"document_id": doc.ID,
+			"collection":  collection,
+			"dry_run":     true,
+			"status":      "preview",
+		}, nil
+	}
+
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create context with document operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
 	defer cancel()
 
-	// Count documents using vectordb client
-	count, err := s.dbClient.GetCollectionCount(timeoutCtx, collection)
+	// Delete document using vectordb client
+	err = s.db(ctx).DeleteDocument(timeoutCtx, collection, documentID)
 	if err != nil {
-		return nil, s.enhanceError("failed to count documents", err)
+		return nil, s.enhanceError(ctx, "failed to delete document", err)
 	}
 
 	return map[string]interface{}{
-		"collection": collection,
-		"count":      count,
+		"document_id": documentID,
+		"collection":  collection,
+		"status":      "deleted",
 	}, nil
 }
 
-// handleQueryDocuments handles the query_documents tool
-func (s *Server) handleQueryDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+const (
+	// maxConcurrentDeletes limits concurrent per-document delete requests
+	maxConcurrentDeletes = 10
+	// maxDeleteDocumentsIDs caps the number of IDs delete_documents accepts per call
+	maxDeleteDocumentsIDs = 1000
+)
+
+// handleDeleteDocuments handles the delete_documents tool
+func (s *Server) handleDeleteDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	collection, ok := args["collection"].(string)
 	if !ok {
 		return nil, fmt.Errorf("collection name is required")
 	}
 
-	query, ok := args["query"].(string)
+	idsArg, ok := args["document_ids"].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("query is required")
+		return nil, fmt.Errorf("document_ids array is required")
+	}
+
+	if len(idsArg) == 0 {
+		return nil, fmt.Errorf("document_ids array cannot be empty")
+	}
+	if len(idsArg) > maxDeleteDocumentsIDs {
+		return nil, fmt.Errorf("document_ids has %d IDs, more than the maximum of %d per call", len(idsArg), maxDeleteDocumentsIDs)
 	}
 
-	limit := 5
-	if limitStr, ok := args["limit"].(string); ok {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil {
-			limit = parsedLimit
+	documentIDs := make([]string, 0, len(idsArg))
+	for i, idArg := range idsArg {
+		id, ok := idArg.(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("document ID at index %d must be a non-empty string", i)
 		}
-	} else if limitInt, ok := args["limit"].(int); ok {
-		limit = limitInt
+		documentIDs = append(documentIDs, id)
 	}
 
-	// Create context with query operation timeout
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
-	defer cancel()
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeBulk)
+		defer cancel()
 
-	// Query documents using vectordb client
-	queryOptions := &vectordb.QueryOptions{
-		TopK: limit,
+		results, foundCount := s.findDocumentsConcurrently(timeoutCtx, collection, documentIDs)
+		return map[string]interface{}{
+			"collection":      collection,
+			"results":         results,
+			"would_delete":    foundCount,
+			"not_found_count": len(documentIDs) - foundCount,
+			"deleted_count":   0,
+			"dry_run":         true,
+		}, nil
 	}
 
-	results, err := s.dbClient.SearchSemantic(timeoutCtx, collection, query, queryOptions)
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
 	if err != nil {
-		return nil, s.enhanceError("failed to query documents", err)
+		return nil, err
 	}
+	defer unlock()
 
-	// Convert results to a more MCP-friendly format
-	var result []map[string]interface{}
-	for _, res := range results {
-		result = append(result, map[string]interface{}{
-			"id":       res.Document.ID,
-			"content":  res.Document.Content,
-			"text":     res.Document.Text,
-			"url":      res.Document.URL,
-			"metadata": res.Document.Metadata,
-			"score":    res.Score,
-		})
+	// Create context with bulk operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeBulk)
+	defer cancel()
+
+	deleted, err := s.deleteDocumentsBulk(timeoutCtx, collection, documentIDs)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to delete documents", err)
 	}
 
 	return map[string]interface{}{
-		"results":    result,
-		"count":      len(result),
-		"collection": collection,
-		"query":      query,
+		"collection":    collection,
+		"results":       s.deleteResults(documentIDs, deleted),
+		"deleted_count": len(deleted.Deleted),
+		"failed_count":  len(deleted.Failed),
+		"failed":        failedDocuments(deleted),
 	}, nil
 }
 
-// handleUpdateDocument handles the update_document tool
-func (s *Server) handleUpdateDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	collection, ok := args["collection"].(string)
-	if !ok {
-		return nil, fmt.Errorf("collection name is required")
+// forEachDocument runs fn for every document ID, running at most limit calls
+// at once. It returns the error of each document in the order of documentIDs.
+func forEachDocument(ctx context.Context, documentIDs []string, limit int, fn func(id string) error) []error {
+	errs := make([]error, len(documentIDs))
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, id := range documentIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = fn(id)
+		}(i, id)
 	}
+	wg.Wait()
+	return errs
+}
 
-	documentID, ok := args["document_id"].(string)
-	if !ok {
-		return nil, fmt.Errorf("document ID is required")
+// deleteDocumentsBulk deletes documents by ID, using the Weaviate client's
+// bulk delete for Weaviate databases and concurrent adapter deletes otherwise
+func (s *Server) deleteDocumentsBulk(ctx context.Context, collection string, documentIDs []string) (*weaviate.BulkDeleteResult, error) {
+	if s.requireWeaviateDatabase(ctx, "bulk delete") == nil {
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return client.DeleteDocumentsBulkDetailed(ctx, collection, documentIDs)
 	}
 
-	content, _ := args["content"].(string)
-
-	var metadata map[string]interface{}
-	if metadataArg, ok := args["metadata"].(map[string]interface{}); ok {
-		metadata = metadataArg
+	errs := forEachDocument(ctx, documentIDs, maxConcurrentDeletes, func(id string) error {
+		return s.db(ctx).DeleteDocument(ctx, collection, id)
+	})
+	result := &weaviate.BulkDeleteResult{
+		Deleted: []string{},
+		Failed:  map[string]error{},
+	}
+	for i, id := range documentIDs {
+		if errs[i] != nil {
+			result.Failed[id] = errs[i]
+			continue
+		}
+		result.Deleted = append(result.Deleted, id)
 	}
+	return result, nil
+}
 
-	// Validate that at least one field is being updated
-	if content == "" && len(metadata) == 0 {
-		return nil, fmt.Errorf("must provide at least one of: content or metadata")
+// deleteResults lists the outcome of each document of a bulk delete in
+// request order, logging the failures
+func (s *Server) deleteResults(documentIDs []string, deleted *weaviate.BulkDeleteResult) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(documentIDs))
+	for _, id := range documentIDs {
+		if failure, failed := deleted.Failed[id]; failed {
+			s.logger.Warn(fmt.Sprintf("Failed to delete document %s: %v", id, failure))
+			results = append(results, map[string]interface{}{
+				"document_id": id,
+				"status":      "failed",
+				"error":       failure.Error(),
+			})
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"document_id": id,
+			"status":      "deleted",
+		})
 	}
 
-	// Create context with document operation timeout
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
-	defer cancel()
+	return results
+}
 
-	// Get the existing document first
-	doc, err := s.dbClient.GetDocument(timeoutCtx, collection, documentID)
-	if err != nil {
-		return nil, s.enhanceError("failed to get existing document", err)
+// failedDocuments maps each document a bulk delete left in place to its error message
+func failedDocuments(deleted *weaviate.BulkDeleteResult) map[string]string {
+	failed := make(map[string]string, len(deleted.Failed))
+	for id, err := range deleted.Failed {
+		failed[id] = err.Error()
 	}
+	return failed
+}
 
-	// Update the fields
-	if content != "" {
-		doc.Content = content
-		doc.Text = content
-	}
-	if len(metadata) > 0 {
-		if doc.Metadata == nil {
-			doc.Metadata = make(map[string]interface{})
-		}
-		for k, v := range metadata {
-			doc.Metadata[k] = v
+// findDocumentsConcurrently looks up documents by ID with bounded concurrency
+// for a delete dry run, returning per-document results in request order along
+// with the number found
+func (s *Server) findDocumentsConcurrently(ctx context.Context, collection string, documentIDs []string) ([]map[string]interface{}, int) {
+	errs := forEachDocument(ctx, documentIDs, maxConcurrentDeletes, func(id string) error {
+		_, err := s.db(ctx).GetDocument(ctx, collection, id)
+		return err
+	})
+
+	results := make([]map[string]interface{}, 0, len(documentIDs))
+	foundCount := 0
+	for i, id := range documentIDs {
+		if errs[i] != nil {
+			results = append(results, map[string]interface{}{
+				"document_id": id,
+				"status":      "not_found",
+				"error":       errs[i].Error(),
+			})
+			continue
 		}
+		foundCount++
+		results = append(results, map[string]interface{}{
+			"document_id": id,
+			"status":      "would_delete",
+		})
 	}
 
-	// Update document using vectordb client (reuse same timeout context)
-	err = s.dbClient.UpdateDocument(timeoutCtx, collection, doc)
-	if err != nil {
-		return nil, s.enhanceError("failed to update document", err)
-	}
-
-	return map[string]interface{}{
-		"document_id": documentID,
-		"collection":  collection,
-		"status":      "updated",
-	}, nil
+	return results, foundCount
 }
 
-// handleSuggestSchema handles the suggest_schema tool
-func (s *Server) handleSuggestSchema(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	sourcePath, ok := args["source_path"].(string)
+const (
+	// defaultDeleteByQueryLimit is the default number of matches considered by delete_documents_by_query
+	defaultDeleteByQueryLimit = 100
+	// maxDeleteByQueryLimit caps the number of documents delete_documents_by_query may delete per call
+	maxDeleteByQueryLimit = 1000
+)
+
+// handleDeleteDocumentsByQuery handles the delete_documents_by_query tool
+func (s *Server) handleDeleteDocumentsByQuery(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
 	if !ok {
-		return nil, fmt.Errorf("source_path is required")
+		return nil, fmt.Errorf("collection name is required")
 	}
 
-	collectionName, ok := args["collection_name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("collection_name is required")
+	query, ok := args["query"].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("query is required")
 	}
 
-	// Optional parameters
-	requirements := ""
-	if req, ok := args["requirements"].(string); ok {
-		requirements = req
+	searchMode := "semantic"
+	if mode, ok := args["search_mode"].(string); ok && mode != "" {
+		searchMode = mode
+	}
+	if searchMode != "semantic" && searchMode != "bm25" {
+		return nil, fmt.Errorf("invalid search_mode '%s': must be 'semantic' or 'bm25'", searchMode)
 	}
 
-	vdbType := "weaviate"
-	if vdb, ok := args["vdb_type"].(string); ok {
-		vdbType = vdb
+	// Dry run is the default so callers must opt in to deletion
+	dryRun := true
+	if v, ok := args["dry_run"].(bool); ok {
+		dryRun = v
 	}
 
-	maxSamples := "50"
-	if max, ok := args["max_samples"].(float64); ok {
-		maxSamples = fmt.Sprintf("%d", int(max))
+	threshold, err := deleteByQueryThreshold(args, searchMode, dryRun)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build CLI command
-	cmdParts := []string{"weave", "schema", "suggest", sourcePath, "--collection", collectionName, "--vdb", vdbType, "--max-samples", maxSamples, "--output", "json"}
-	if requirements != "" {
-		cmdParts = append(cmdParts, "--requirements", fmt.Sprintf("\"%s\"", requirements))
+	limit := getIntArg(args, "limit", defaultDeleteByQueryLimit)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+	if limit > maxDeleteByQueryLimit {
+		return nil, fmt.Errorf("limit %d exceeds maximum of %d documents per call", limit, maxDeleteByQueryLimit)
 	}
 
-	cmd := strings.Join(cmdParts, " ")
+	// Serialize writes to this collection (in-process advisory lock)
+	if !dryRun {
+		unlock, err := s.lockCollection(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 
-	// Create timeout context (60 seconds for AI operations)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 60)
+	// Create context with bulk operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeBulk)
 	defer cancel()
 
-	// Execute command
-	result, err := s.executeCommand(timeoutCtx, cmd)
-	if err != nil {
-		return nil, s.enhanceError("failed to suggest schema", err)
+	queryOptions := &vectordb.QueryOptions{
+		TopK: limit,
 	}
 
-	return result, nil
-}
-
-// handleSuggestChunking handles the suggest_chunking tool
-func (s *Server) handleSuggestChunking(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	sourcePath, ok := args["source_path"].(string)
-	if !ok {
-		return nil, fmt.Errorf("source_path is required")
+	var results []*vectordb.QueryResult
+	if searchMode == "bm25" {
+		results, err = s.db(ctx).SearchBM25(timeoutCtx, collection, query, queryOptions)
+	} else {
+		results, err = s.db(ctx).SearchSemantic(timeoutCtx, collection, query, queryOptions)
 	}
-
-	collectionName, ok := args["collection_name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("collection_name is required")
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to search documents", err)
 	}
 
-	// Optional parameters
-	requirements := ""
-	if req, ok := args["requirements"].(string); ok {
-		requirements = req
+	// Keep only matches at or above the score threshold
+	matchedIDs := make([]string, 0, len(results))
+	for _, res := range results {
+		if res.Score < threshold {
+			continue
+		}
+		matchedIDs = append(matchedIDs, res.Document.ID)
 	}
 
-	vdbType := "weaviate"
-	if vdb, ok := args["vdb_type"].(string); ok {
-		vdbType = vdb
+	response := map[string]interface{}{
+		"collection":    collection,
+		"query":         query,
+		"search_mode":   searchMode,
+		"threshold":     threshold,
+		"matched_ids":   matchedIDs,
+		"matched_count": len(matchedIDs),
+		"dry_run":       dryRun,
 	}
 
-	maxSamples := "50"
-	if max, ok := args["max_samples"].(float64); ok {
-		maxSamples = fmt.Sprintf("%d", int(max))
+	if dryRun {
+		response["deleted_count"] = 0
+		return response, nil
 	}
 
-	// Build CLI command
-	cmdParts := []string{"weave", "chunking", "suggest", sourcePath, "--collection", collectionName, "--vdb", vdbType, "--max-samples", maxSamples, "--output", "json"}
-	if requirements != "" {
-		cmdParts = append(cmdParts, "--requirements", fmt.Sprintf("\"%s\"", requirements))
+	deleted, err := s.deleteDocumentsBulk(timeoutCtx, collection, matchedIDs)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to delete documents", err)
 	}
+	response["results"] = s.deleteResults(matchedIDs, deleted)
+	response["deleted_count"] = len(deleted.Deleted)
+	response["failed_count"] = len(deleted.Failed)
+	response["failed"] = failedDocuments(deleted)
 
-	cmd := strings.Join(cmdParts, " ")
+	return response, nil
+}
 
-	// Create timeout context (60 seconds for AI operations)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 60)
-	defer cancel()
+// deleteByQueryThreshold returns the minimum score delete_documents_by_query
+// deletes. Deleting requires an explicit threshold, since without one every
+// hit up to limit would go however weak the match; semantic scores are
+// normalized to [0, 1], while BM25 scores are unbounded and must be positive.
+// A dry run without a threshold previews every hit.
+func deleteByQueryThreshold(args map[string]interface{}, searchMode string, dryRun bool) (float64, error) {
+	if _, present := args["threshold"]; !present || args["threshold"] == nil {
+		if dryRun {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("threshold is required when dry_run is false; run a dry run first to pick the minimum score to delete")
+	}
 
-	// Execute command
-	result, err := s.executeCommand(timeoutCtx, cmd)
+	threshold, err := floatArg(args, "threshold", 0)
 	if err != nil {
-		return nil, s.enhanceError("failed to suggest chunking", err)
+		return 0, err
 	}
-
-	return result, nil
+	if searchMode == "bm25" {
+		if !(threshold > 0) {
+			return 0, fmt.Errorf("threshold must be greater than 0 for bm25, got %g", threshold)
+		}
+		return threshold, nil
+	}
+	if !(threshold > 0 && threshold <= 1) {
+		return 0, fmt.Errorf("threshold must be greater than 0 and at most 1 for semantic search, got %g", threshold)
+	}
+	return threshold, nil
 }
 
-// executeCommand executes a shell command and returns the result
-func (s *Server) executeCommand(ctx context.Context, cmdStr string) (interface{}, error) {
-	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+const (
+	// defaultBulkUpdateLimit is the default number of matches considered by bulk_update_metadata
+	defaultBulkUpdateLimit = 100
+	// maxBulkUpdateLimit caps the number of documents bulk_update_metadata may update per call
+	maxBulkUpdateLimit = 1000
+	// maxConcurrentUpdates limits concurrent per-document update requests
+	maxConcurrentUpdates = 10
+)
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
+// bulkUpdateMatches returns the IDs of the documents bulk_update_metadata
+// updates. Key/value filters are exact matches; a nested And/Or/Not
+// expression is only supported on Weaviate.
+func (s *Server) bulkUpdateMatches(ctx context.Context, collection string, filters map[string]interface{}, limit int) ([]string, error) {
+	if !isFilterExpression(filters) {
+		results, err := s.db(ctx).SearchByMetadata(ctx, collection, filters, &vectordb.QueryOptions{TopK: limit})
+		if err != nil {
+			return nil, err
+		}
+		matchedIDs := make([]string, 0, len(results))
+		for _, res := range results {
+			matchedIDs = append(matchedIDs, res.Document.ID)
+		}
+		return matchedIDs, nil
+	}
+
+	expression, err := weaviate.ParseFilterExpression(filters)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireWeaviateDatabase(ctx, "nested filters"); err != nil {
+		return nil, err
+	}
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetDocumentIDsByFilter(ctx, collection, expression, limit)
+}
+
+// handleBulkUpdateMetadata handles the bulk_update_metadata tool
+func (s *Server) handleBulkUpdateMetadata(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	filters, ok := args["filters"].(map[string]interface{})
+	if !ok || len(filters) == 0 {
+		return nil, fmt.Errorf("filters are required")
+	}
+
+	metadata, ok := args["metadata"].(map[string]interface{})
+	if !ok || len(metadata) == 0 {
+		return nil, fmt.Errorf("metadata patch is required")
+	}
+
+	limit := getIntArg(args, "limit", defaultBulkUpdateLimit)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+	if limit > maxBulkUpdateLimit {
+		return nil, fmt.Errorf("limit %d exceeds maximum of %d documents per call", limit, maxBulkUpdateLimit)
+	}
+
+	dryRun, _ := args["dry_run"].(bool)
+
+	// Create context with bulk operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeBulk)
+	defer cancel()
+
+	matchedIDs, err := s.bulkUpdateMatches(timeoutCtx, collection, filters, limit)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to find matching documents", err)
+	}
+
+	response := map[string]interface{}{
+		"collection":    collection,
+		"filters":       filters,
+		"metadata":      metadata,
+		"matched_ids":   matchedIDs,
+		"matched_count": len(matchedIDs),
+		"dry_run":       dryRun,
+	}
+
+	if dryRun {
+		response["updated_count"] = 0
+		return response, nil
+	}
+
+	return s.runOperation(timeoutCtx, args, "bulk_update_metadata", int64(len(matchedIDs)), func(ctx context.Context, progress func(int64)) (interface{}, error) {
+		// Serialize writes to this collection (in-process advisory lock)
+		unlock, err := s.lockCollection(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+		var processed int64
+		updateResults, updatedCount := s.updateMetadataConcurrently(ctx, collection, matchedIDs, metadata, func() {
+			progress(atomic.AddInt64(&processed, 1))
+		})
+		response["results"] = updateResults
+		response["updated_count"] = updatedCount
+		response["failed_count"] = len(matchedIDs) - updatedCount
+
+		return response, nil
+	})
+}
+
+// updateMetadataConcurrently merges a metadata patch into documents by ID with
+// bounded concurrency and returns per-document results in request order along
+// with the number updated. onProcessed is called after each document.
+func (s *Server) updateMetadataConcurrently(ctx context.Context, collection string, documentIDs []string, metadata map[string]interface{}, onProcessed func()) ([]map[string]interface{}, int) {
+	errs := forEachDocument(ctx, documentIDs, maxConcurrentUpdates, func(id string) error {
+		defer onProcessed()
+		return s.mergeDocumentMetadata(ctx, collection, id, metadata)
+	})
+
+	results := make([]map[string]interface{}, 0, len(documentIDs))
+	updatedCount := 0
+	for i, id := range documentIDs {
+		if errs[i] != nil {
+			s.logger.Warn(fmt.Sprintf("Failed to update metadata for document %s: %v", id, errs[i]))
+			results = append(results, map[string]interface{}{
+				"document_id": id,
+				"status":      "failed",
+				"error":       errs[i].Error(),
+			})
+			continue
+		}
+		updatedCount++
+		results = append(results, map[string]interface{}{
+			"document_id": id,
+			"status":      "updated",
+		})
+	}
+
+	return results, updatedCount
+}
+
+// mergeDocumentMetadata fetches a document and writes it back with the
+// metadata patch applied, leaving other fields untouched
+func (s *Server) mergeDocumentMetadata(ctx context.Context, collection, documentID string, metadata map[string]interface{}) error {
+	doc, err := s.db(ctx).GetDocument(ctx, collection, documentID)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]interface{}, len(doc.Metadata)+len(metadata))
+	for k, v := range doc.Metadata {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+
+	updated := *doc
+	updated.Metadata = merged
+	return s.db(ctx).UpdateDocument(ctx, collection, &updated)
+}
+
+// handleCountDocuments handles the count_documents tool
+func (s *Server) handleCountDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	// Create context with query operation timeout (counting is a query)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	// Count documents using vectordb client
+	count, err := s.getCollectionCount(timeoutCtx, collection)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to count documents", err)
+	}
+
+	return map[string]interface{}{
+		"collection": collection,
+		"count":      count,
+	}, nil
+}
+
+// maxCountOnlyResults caps the number of results counted by query_documents
+// when limit is 0
+const maxCountOnlyResults = 1000
+
+// handleQueryDocuments handles the query_documents tool
+func (s *Server) handleQueryDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+
+	// A limit of 0 means count only: run a capped query and return just the count
+	countOnly := limit == 0
+	if countOnly {
+		limit = maxCountOnlyResults
+	}
+
+	// Reranking changes the order, not the count, so it is skipped for count only
+	rerank, _ := args["rerank"].(bool)
+	rerank = rerank && !countOnly
+
+	// Vectors are large, so they are only fetched on request and never for count only
+	includeVector := includeVectorArg(args) && !countOnly
+	if includeVector {
+		if err := s.requireWeaviateDatabase(ctx, "include_vector"); err != nil {
+			return nil, err
+		}
+		if rerank {
+			return nil, fmt.Errorf("include_vector cannot be combined with rerank")
+		}
+	}
+
+	// Weaviate embeds query text itself, so the vectorizer can only be
+	// switched to another of the collection's named vectors
+	queryVectorizer, _ := args["query_vectorizer"].(string)
+	if queryVectorizer != "" {
+		if err := s.requireWeaviateDatabase(ctx, "query_vectorizer"); err != nil {
+			return nil, err
+		}
+		if rerank {
+			return nil, fmt.Errorf("query_vectorizer cannot be combined with rerank")
+		}
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	var targetVector string
+	if queryVectorizer != "" {
+		resolved, err := s.resolveQueryVectorizer(timeoutCtx, collection, queryVectorizer)
+		if err != nil {
+			return nil, err
+		}
+		targetVector = resolved
+	}
+
+	var rerankNote string
+	if rerank {
+		reranked, note, err := s.queryReranked(timeoutCtx, collection, query, limit)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to rerank documents", err)
+		}
+		if reranked != nil {
+			response := map[string]interface{}{
+				"results":     reranked,
+				"count":       len(reranked),
+				"collection":  collection,
+				"query":       query,
+				"reranked":    true,
+				"search_mode": weaviate.SearchModeNearText,
+			}
+			if message := s.queryResultMessage(timeoutCtx, collection, weaviate.SearchModeNearText, len(reranked)); message != "" {
+				response["message"] = message
+			}
+			return response, nil
+		}
+		rerankNote = note
+	}
+
+	// Query documents using vectordb client
+	queryOptions := &vectordb.QueryOptions{
+		TopK: limit,
+	}
+
+	var results []*vectordb.QueryResult
+	var vectors [][]float32
+	var searchMode string
+	var err error
+	switch {
+	case includeVector || queryVectorizer != "" || s.weaviateQueryConfigured(ctx):
+		// The adapter returns no vectors, cannot target named vectors, and
+		// its fallback chain and scoring are fixed, so go through the
+		// Weaviate client for those
+		results, vectors, searchMode, err = s.querySemanticWithFallback(timeoutCtx, collection, query, weaviate.QueryOptions{
+			TopK:          limit,
+			IncludeVector: includeVector,
+			TargetVector:  targetVector,
+		})
+	case s.nearTextUnsupported(ctx, collection):
+		// Skip the semantic query that is known to fail and fall back to hybrid directly
+		results, err = s.db(ctx).SearchHybrid(timeoutCtx, collection, query, queryOptions)
+		searchMode = weaviate.SearchModeHybrid
+	default:
+		results, err = s.db(ctx).SearchSemantic(timeoutCtx, collection, query, queryOptions)
+		searchMode = searchModeSemantic
+	}
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to query documents", err)
+	}
+
+	if countOnly {
+		response := map[string]interface{}{
+			"results":     []map[string]interface{}{},
+			"count":       len(results),
+			"count_only":  true,
+			"capped":      len(results) >= maxCountOnlyResults,
+			"collection":  collection,
+			"query":       query,
+			"search_mode": searchMode,
+		}
+		if message := s.queryResultMessage(timeoutCtx, collection, searchMode, len(results)); message != "" {
+			response["message"] = message
+		}
+		return response, nil
+	}
+
+	// Convert results to a more MCP-friendly format
+	result := make([]map[string]interface{}, 0, len(results))
+	for i, res := range results {
+		entry := map[string]interface{}{
+			"id":       res.Document.ID,
+			"content":  res.Document.Content,
+			"text":     res.Document.Text,
+			"url":      s.documentURL(ctx, collection, &res.Document),
+			"metadata": res.Document.Metadata,
+			"score":    res.Score,
+		}
+		markFallbackResult(entry, searchMode)
+		if includeVector {
+			addVector(entry, vectors[i])
+		}
+		result = append(result, entry)
+	}
+
+	response := map[string]interface{}{
+		"results":     result,
+		"count":       len(result),
+		"collection":  collection,
+		"query":       query,
+		"search_mode": searchMode,
+	}
+	if message := s.queryResultMessage(timeoutCtx, collection, searchMode, len(result)); message != "" {
+		response["message"] = message
+	}
+	if queryVectorizer != "" {
+		response["query_vectorizer"] = queryVectorizer
+		if targetVector != "" {
+			response["target_vector"] = targetVector
+		}
+	}
+	if rerank {
+		response["reranked"] = false
+		response["rerank_note"] = rerankNote
+	}
+
+	return response, nil
+}
+
+// queryReranked runs a query reordered by the collection's reranker module.
+// It returns nil results and a note explaining why when reranking is not
+// available, so the caller can fall back to vector search order.
+func (s *Server) queryReranked(ctx context.Context, collection, query string, limit int) ([]map[string]interface{}, string, error) {
+	if err := s.requireWeaviateDatabase(ctx, "rerank"); err != nil {
+		return nil, "rerank is only supported for Weaviate databases; results are in vector search order", nil
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, err := client.QueryReranked(ctx, collection, query, limit)
+	if errors.Is(err, weaviate.ErrNoRerankerModule) {
+		return nil, fmt.Sprintf("collection '%s' has no reranker module; results are in vector search order", collection), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	formatted := make([]map[string]interface{}, len(results))
+	for i, res := range results {
+		doc := vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata}
+		formatted[i] = map[string]interface{}{
+			"id":             res.ID,
+			"content":        res.Content,
+			"text":           res.Content,
+			"url":            s.documentURL(ctx, collection, &doc),
+			"metadata":       res.Metadata,
+			"score":          res.RerankScore,
+			"rerank_score":   res.RerankScore,
+			"original_score": res.OriginalScore,
+			"original_rank":  res.OriginalRank,
+		}
+	}
+	return formatted, "", nil
+}
+
+// handleSearchHybrid handles the search_hybrid tool
+func (s *Server) handleSearchHybrid(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be a positive integer")
+	}
+
+	alpha, err := floatArg(args, "alpha", weaviate.DefaultHybridAlpha)
+	if err != nil {
+		return nil, err
+	}
+
+	fusionType, _ := args["fusion_type"].(string)
+	options := weaviate.HybridOptions{
+		TopK:       limit,
+		Alpha:      alpha,
+		FusionType: fusionType,
+	}
+	if err := weaviate.ValidateHybridOptions(options); err != nil {
+		return nil, err
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	response := map[string]interface{}{
+		"collection": collection,
+		"query":      query,
+		"alpha":      options.Alpha,
+	}
+	if fusionType != "" {
+		response["fusion_type"] = fusionType
+	}
+
+	var results []map[string]interface{}
+	if s.requireWeaviateDatabase(ctx, "search_hybrid") == nil {
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run hybrid search", err)
+		}
+		hybridResults, err := client.QueryHybrid(timeoutCtx, collection, query, options)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run hybrid search", err)
+		}
+		for _, res := range hybridResults {
+			doc := vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata}
+			results = append(results, map[string]interface{}{
+				"id":       res.ID,
+				"content":  res.Content,
+				"text":     res.Content,
+				"url":      s.documentURL(ctx, collection, &doc),
+				"metadata": res.Metadata,
+				"score":    res.Score,
+			})
+		}
+	} else {
+		// Other databases use their own hybrid weighting
+		hybridResults, err := s.db(ctx).SearchHybrid(timeoutCtx, collection, query, &vectordb.QueryOptions{TopK: limit})
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run hybrid search", err)
+		}
+		for _, res := range hybridResults {
+			results = append(results, map[string]interface{}{
+				"id":       res.Document.ID,
+				"content":  res.Document.Content,
+				"text":     res.Document.Text,
+				"url":      s.documentURL(ctx, collection, &res.Document),
+				"metadata": res.Document.Metadata,
+				"score":    res.Score,
+			})
+		}
+		response["note"] = "alpha and fusion_type are only applied on Weaviate databases"
+	}
+
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+	response["results"] = results
+	response["count"] = len(results)
+	return response, nil
+}
+
+// handleSearchBM25 handles the search_bm25 tool
+func (s *Server) handleSearchBM25(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be a positive integer")
+	}
+
+	var properties []string
+	if raw, ok := args["properties"]; ok {
+		parsed, err := stringList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("properties: %w", err)
+		}
+		properties = parsed
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	var results []map[string]interface{}
+	if len(properties) > 0 {
+		// The vectordb query options cannot name properties, so search through the Weaviate REST API
+		if err := s.requireWeaviateDatabase(ctx, "properties"); err != nil {
+			return nil, err
+		}
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run keyword search", err)
+		}
+		bm25Results, err := client.Query(timeoutCtx, collection, query, weaviate.QueryOptions{
+			TopK:       limit,
+			UseBM25:    true,
+			Properties: properties,
+		})
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run keyword search", err)
+		}
+		for _, res := range bm25Results {
+			doc := vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata}
+			entry := map[string]interface{}{
+				"id":       res.ID,
+				"content":  res.Content,
+				"text":     res.Content,
+				"url":      s.documentURL(ctx, collection, &doc),
+				"metadata": res.Metadata,
+				"score":    res.Score,
+			}
+			markFallbackResult(entry, res.SearchMode)
+			results = append(results, entry)
+		}
+	} else {
+		bm25Results, err := s.db(ctx).SearchBM25(timeoutCtx, collection, query, &vectordb.QueryOptions{TopK: limit})
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to run keyword search", err)
+		}
+		for _, res := range bm25Results {
+			results = append(results, map[string]interface{}{
+				"id":       res.Document.ID,
+				"content":  res.Document.Content,
+				"text":     res.Document.Text,
+				"url":      s.documentURL(ctx, collection, &res.Document),
+				"metadata": res.Document.Metadata,
+				"score":    res.Score,
+			})
+		}
+	}
+
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+	response := map[string]interface{}{
+		"results":    results,
+		"count":      len(results),
+		"collection": collection,
+		"query":      query,
+	}
+	if len(properties) > 0 {
+		response["properties"] = properties
+	}
+	return response, nil
+}
+
+// handleUpdateDocument handles the update_document tool
+func (s *Server) handleUpdateDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	documentID, ok := args["document_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	content, _ := args["content"].(string)
+
+	var metadata map[string]interface{}
+	if metadataArg, ok := args["metadata"].(map[string]interface{}); ok {
+		metadata = metadataArg
+	}
+
+	var removeKeys []string
+	if raw, ok := args["remove_metadata_keys"]; ok && raw != nil {
+		keys, err := stringList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("remove_metadata_keys: %w", err)
+		}
+		for _, key := range keys {
+			if key == "" {
+				return nil, fmt.Errorf("remove_metadata_keys must not contain empty keys")
+			}
+			if _, set := metadata[key]; set {
+				return nil, fmt.Errorf("metadata key '%s' is both set and removed", key)
+			}
+		}
+		removeKeys = keys
+	}
+
+	// Validate that at least one field is being updated
+	if content == "" && len(metadata) == 0 && len(removeKeys) == 0 {
+		return nil, fmt.Errorf("must provide at least one of: content, metadata, or remove_metadata_keys")
+	}
+
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create context with document operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+	defer cancel()
+
+	// Get the existing document first
+	doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get existing document", err)
+	}
+
+	// Remove keys first; only keys the document has count as a change
+	removed := []string{}
+	for _, key := range removeKeys {
+		if _, exists := doc.Metadata[key]; exists {
+			delete(doc.Metadata, key)
+			removed = append(removed, key)
+		}
+	}
+	if content == "" && len(metadata) == 0 && len(removed) == 0 {
+		return nil, fmt.Errorf("nothing to update: document %s has none of the metadata keys %v", documentID, removeKeys)
+	}
+
+	// Update the fields
+	if content != "" {
+		doc.Content = content
+		doc.Text = content
+	}
+	if len(metadata) > 0 {
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
+		}
+		for k, v := range metadata {
+			doc.Metadata[k] = v
+		}
+	}
+
+	// The Weaviate adapter merges the stored metadata back into every update,
+	// so removed keys are written separately through the REST client
+	removeViaREST := len(removed) > 0 && s.requireWeaviateDatabase(ctx, "metadata key removal") == nil
+
+	// Update document using vectordb client. New content is re-vectorized, so
+	// allow for the embedding provider.
+	if content != "" {
+		err = s.withEmbedding(ctx, vectordb.OperationTypeDocument, true, func(ctx context.Context) error {
+			return s.db(ctx).UpdateDocument(ctx, collection, doc)
+		})
+	} else if len(metadata) > 0 || !removeViaREST {
+		err = s.db(ctx).UpdateDocument(timeoutCtx, collection, doc)
+	}
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to update document", err)
+	}
+
+	if removeViaREST {
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		removed, err = client.RemoveMetadataKeys(timeoutCtx, collection, documentID, removed)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to remove metadata keys", err)
+		}
+	}
+
+	response := map[string]interface{}{
+		"document_id": documentID,
+		"collection":  collection,
+		"status":      "updated",
+	}
+	if len(removeKeys) > 0 {
+		response["removed_metadata_keys"] = removed
+	}
+	return response, nil
+}
+
+// handleUpsertDocument handles the upsert_document tool: it updates the
+// document with the given ID when it exists, merging metadata as
+// update_document does, and creates it with that ID otherwise
+func (s *Server) handleUpsertDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	documentID, ok := args["document_id"].(string)
+	if !ok || documentID == "" {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	url, _ := args["url"].(string)
+	text, _ := args["text"].(string)
+	metadata, _ := args["metadata"].(map[string]interface{})
+	if url == "" && text == "" && len(metadata) == 0 {
+		return nil, fmt.Errorf("must provide at least one of: url, text, or metadata")
+	}
+
+	// Serialize writes to this collection (in-process advisory lock), which
+	// also keeps the existence check and the write together
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create context with document operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+	defer cancel()
+
+	doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
+	if err != nil && categorizeError(err) != "not_found" {
+		return nil, s.enhanceError(ctx, "failed to get existing document", err)
+	}
+
+	status := "updated"
+	if err != nil {
+		// Not found: create it with the supplied ID
+		if text == "" {
+			return nil, fmt.Errorf("document '%s' does not exist; text is required to create it", documentID)
+		}
+		status = "created"
+		doc = &vectordb.Document{
+			ID:       documentID,
+			URL:      url,
+			Text:     text,
+			Content:  text,
+			Metadata: metadata,
+		}
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
+		}
+
+		// Metadata must match the schema's metadata property type, as in create_document
+		schemaCtx, schemaCancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+		metadataFormat := s.schemaMetadataFormat(schemaCtx, collection)
+		schemaCancel()
+
+		err = s.withEmbedding(ctx, vectordb.OperationTypeDocument, true, func(ctx context.Context) error {
+			if metadataFormat != "" {
+				return s.createDocumentsWithSchemaFormat(ctx, collection, []*vectordb.Document{doc}, documentWriteOptions{})
+			}
+			return s.db(ctx).CreateDocument(ctx, collection, doc)
+		})
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to create document", err)
+		}
+	} else {
+		if url != "" {
+			doc.URL = url
+		}
+		if text != "" {
+			doc.Text = text
+			doc.Content = text
+		}
+		if len(metadata) > 0 {
+			if doc.Metadata == nil {
+				doc.Metadata = make(map[string]interface{})
+			}
+			for k, v := range metadata {
+				doc.Metadata[k] = v
+			}
+		}
+
+		// New text is re-vectorized, so allow for the embedding provider
+		if text != "" {
+			err = s.withEmbedding(ctx, vectordb.OperationTypeDocument, true, func(ctx context.Context) error {
+				return s.db(ctx).UpdateDocument(ctx, collection, doc)
+			})
+		} else {
+			err = s.db(ctx).UpdateDocument(timeoutCtx, collection, doc)
+		}
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to update document", err)
+		}
+	}
+
+	return map[string]interface{}{
+		"document_id": documentID,
+		"collection":  collection,
+		"url":         doc.URL,
+		"metadata":    doc.Metadata,
+		"status":      status,
+	}, nil
+}
+
+// handleReembedDocument handles the reembed_document tool
+func (s *Server) handleReembedDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	documentID, ok := args["document_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	// Serialize writes to this collection (in-process advisory lock)
+	unlock, err := s.lockCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Create context with document operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+	defer cancel()
+
+	doc, err := s.db(ctx).GetDocument(timeoutCtx, collection, documentID)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get existing document", err)
+	}
+
+	content := doc.Content
+	if content == "" {
+		content = doc.Text
+	}
+	if content == "" {
+		return nil, fmt.Errorf("document '%s' has no text content to re-embed", documentID)
+	}
+
+	// Re-write the unchanged content so the collection's vectorizer recomputes the vector
+	doc.Content = content
+	doc.Text = content
+	err = s.withEmbedding(ctx, vectordb.OperationTypeDocument, true, func(ctx context.Context) error {
+		return s.db(ctx).UpdateDocument(ctx, collection, doc)
+	})
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to re-embed document", err)
+	}
+
+	return map[string]interface{}{
+		"document_id":    documentID,
+		"collection":     collection,
+		"content_length": len(content),
+		"status":         "reembedded",
+	}, nil
+}
+
+// handleSuggestSchema handles the suggest_schema tool
+func (s *Server) handleSuggestSchema(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	sourcePath, ok := args["source_path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("source_path is required")
+	}
+
+	collectionName, ok := args["collection_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection_name is required")
+	}
+
+	// Optional parameters
+	requirements := ""
+	if req, ok := args["requirements"].(string); ok {
+		requirements = req
+	}
+
+	vdbType := "weaviate"
+	if vdb, ok := args["vdb_type"].(string); ok {
+		vdbType = vdb
+	}
+
+	maxSamples := strconv.Itoa(getIntArg(args, "max_samples", 50))
+
+	// Build CLI command
+	cmdParts := []string{"weave", "schema", "suggest", sourcePath, "--collection", collectionName, "--vdb", vdbType, "--max-samples", maxSamples, "--output", "json"}
+	if requirements != "" {
+		cmdParts = append(cmdParts, "--requirements", fmt.Sprintf("\"%s\"", requirements))
+	}
+
+	cmd := strings.Join(cmdParts, " ")
+
+	// Create timeout context (60 seconds for AI operations)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 60)
+	defer cancel()
+
+	// Execute command
+	result, err := s.executeCommand(timeoutCtx, cmd)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to suggest schema", err)
+	}
+
+	return result, nil
+}
+
+// handleSuggestChunking handles the suggest_chunking tool
+func (s *Server) handleSuggestChunking(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	sourcePath, ok := args["source_path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("source_path is required")
+	}
+
+	collectionName, ok := args["collection_name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection_name is required")
+	}
+
+	// Optional parameters
+	requirements := ""
+	if req, ok := args["requirements"].(string); ok {
+		requirements = req
+	}
+
+	vdbType := "weaviate"
+	if vdb, ok := args["vdb_type"].(string); ok {
+		vdbType = vdb
+	}
+
+	maxSamples := strconv.Itoa(getIntArg(args, "max_samples", 50))
+
+	// Build CLI command
+	cmdParts := []string{"weave", "chunking", "suggest", sourcePath, "--collection", collectionName, "--vdb", vdbType, "--max-samples", maxSamples, "--output", "json"}
+	if requirements != "" {
+		cmdParts = append(cmdParts, "--requirements", fmt.Sprintf("\"%s\"", requirements))
+	}
+
+	cmd := strings.Join(cmdParts, " ")
+
+	// Create timeout context (60 seconds for AI operations)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 60)
+	defer cancel()
+
+	// Execute command
+	result, err := s.executeCommand(timeoutCtx, cmd)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to suggest chunking", err)
+	}
+
+	return result, nil
+}
+
+// executeCommand executes a shell command and returns the result
+func (s *Server) executeCommand(ctx context.Context, cmdStr string) (interface{}, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
 	if err != nil {
-		return nil, fmt.Errorf("command failed: %s - %s", err, stderr.String())
+		return nil, fmt.Errorf("command failed: %s - %s", err, stderr.String())
+	}
+
+	// Try to parse JSON output
+	var result interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		// If not JSON, return raw output
+		return map[string]interface{}{
+			"output": stdout.String(),
+		}, nil
+	}
+
+	return result, nil
+}
+
+// handleHealthCheck checks the health of the vector database
+func (s *Server) handleHealthCheck(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	// Create timeout context (10 seconds for health check)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 10)
+	defer cancel()
+
+	// Get database config
+	dbConfig, err := s.defaultDatabaseConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database config: %w", err)
+	}
+
+	// Check database health
+	err = s.db(ctx).Health(timeoutCtx)
+	if err != nil {
+		return map[string]interface{}{
+			"status":   "unhealthy",
+			"database": string(dbConfig.Type),
+			"error":    err.Error(),
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"status":   "healthy",
+		"database": string(dbConfig.Type),
+		"url":      dbConfig.URL,
+	}, nil
+}
+
+// handleHealthCheckAll checks every configured database concurrently, each
+// with its own health timeout, so the call takes as long as the slowest one
+func (s *Server) handleHealthCheckAll(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	enabledOnly, _ := args["enabled_only"].(bool)
+
+	defaultName := ""
+	if dbConfig, err := s.defaultDatabaseConfig(); err == nil {
+		defaultName = dbConfig.Name
+	}
+
+	var databases []*config.VectorDBConfig
+	for i := range s.config.Databases.VectorDatabases {
+		dbConfig := &s.config.Databases.VectorDatabases[i]
+		if enabledOnly && !dbConfig.Enabled {
+			continue
+		}
+		databases = append(databases, dbConfig)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[string]interface{}, len(databases))
+	healthy := 0
+	for _, dbConfig := range databases {
+		wg.Add(1)
+		go func(dbConfig *config.VectorDBConfig) {
+			defer wg.Done()
+			status := s.checkDatabaseHealth(ctx, dbConfig, dbConfig.Name == defaultName)
+
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[dbConfig.Name] = status
+			if status["status"] == "healthy" {
+				healthy++
+			}
+		}(dbConfig)
+	}
+	wg.Wait()
+
+	overall := "healthy"
+	switch {
+	case healthy == 0 && len(databases) > 0:
+		overall = "unhealthy"
+	case healthy < len(databases):
+		overall = "degraded"
+	}
+
+	return map[string]interface{}{
+		"status":    overall,
+		"databases": statuses,
+		"checked":   len(databases),
+		"healthy":   healthy,
+	}, nil
+}
+
+// checkDatabaseHealth runs a health check on one database with its health
+// operation timeout and reports its status, error, and latency
+func (s *Server) checkDatabaseHealth(ctx context.Context, dbConfig *config.VectorDBConfig, isDefault bool) map[string]interface{} {
+	status := map[string]interface{}{
+		"type":    string(dbConfig.Type),
+		"default": isDefault,
+		"enabled": dbConfig.Enabled,
+	}
+
+	var client vectordb.VectorDBClient
+	if isDefault {
+		client = s.db(ctx)
+	} else {
+		created, err := s.databaseClient(dbConfig)
+		if err != nil {
+			status["status"] = "unhealthy"
+			status["error"] = fmt.Sprintf("failed to create client: %v", err)
+			return status
+		}
+		client = created
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx,
+		vectordb.GetTimeoutForOperation(vectordb.OperationTypeHealth, isCloudDatabase(dbConfig), dbConfig.Timeout))
+	defer cancel()
+
+	start := time.Now()
+	err := client.Health(timeoutCtx)
+	status["latency_ms"] = durationMillis(time.Since(start))
+	if err != nil {
+		status["status"] = "unhealthy"
+		status["error"] = err.Error()
+		return status
+	}
+	status["status"] = "healthy"
+	return status
+}
+
+// maxPingSamples caps the number of round trips ping_database measures
+const maxPingSamples = 10
+
+// handlePingDatabase measures the round-trip latency of a trivial backend call
+func (s *Server) handlePingDatabase(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	samples := getIntArg(args, "samples", 1)
+	if samples < 1 {
+		samples = 1
+	}
+	if samples > maxPingSamples {
+		samples = maxPingSamples
+	}
+
+	dbConfig, err := s.defaultDatabaseConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database config: %w", err)
+	}
+
+	// Create context with health operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeHealth)
+	defer cancel()
+
+	latencies := make([]float64, 0, samples)
+	for i := 0; i < samples; i++ {
+		start := time.Now()
+		if err := s.db(ctx).Health(timeoutCtx); err != nil {
+			return nil, s.enhanceError(ctx, "failed to ping database", err)
+		}
+		latencies = append(latencies, durationMillis(time.Since(start)))
+	}
+
+	minLatency, maxLatency, total := latencies[0], latencies[0], 0.0
+	for _, latency := range latencies {
+		if latency < minLatency {
+			minLatency = latency
+		}
+		if latency > maxLatency {
+			maxLatency = latency
+		}
+		total += latency
+	}
+
+	return map[string]interface{}{
+		"database":       dbConfig.Name,
+		"type":           string(dbConfig.Type),
+		"url":            dbConfig.URL,
+		"latency_ms":     total / float64(len(latencies)),
+		"min_latency_ms": minLatency,
+		"max_latency_ms": maxLatency,
+		"samples":        len(latencies),
+	}, nil
+}
+
+// handleCountCollections counts the total number of collections
+func (s *Server) handleCountCollections(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	// Create timeout context (20 seconds for collection operation)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 20)
+	defer cancel()
+
+	// List all collections
+	collections, err := s.db(ctx).ListCollections(timeoutCtx)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to list collections", err)
+	}
+
+	// Extract collection names
+	names := make([]string, len(collections))
+	for i, col := range collections {
+		names[i] = col.Name
+	}
+
+	return map[string]interface{}{
+		"count":       len(collections),
+		"collections": names,
+	}, nil
+}
+
+// handleShowCollection shows detailed information about a collection
+func (s *Server) handleShowCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collectionName, ok := args["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	// Create timeout context (20 seconds for collection operation)
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 20)
+	defer cancel()
+
+	// Get collection schema
+	schema, err := s.getSchema(timeoutCtx, collectionName)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get collection schema", err)
+	}
+
+	// Get collection count
+	count, err := s.getCollectionCount(timeoutCtx, collectionName)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get collection count", err)
+	}
+
+	// Return the schema as plain JSON so callers see the same shape in-process
+	// and over the wire
+	schemaValue, err := toJSONValue(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collection schema: %w", err)
+	}
+	properties, err := toJSONValue(schema.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collection properties: %w", err)
+	}
+
+	return map[string]interface{}{
+		"name":       collectionName,
+		"schema":     schemaValue,
+		"count":      count,
+		"vectorizer": schema.Vectorizer,
+		"properties": properties,
+	}, nil
+}
+
+// handleCompareCollections compares the schemas of two collections
+func (s *Server) handleCompareCollections(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	source, ok := args["source"].(string)
+	if !ok || source == "" {
+		return nil, fmt.Errorf("source collection name is required")
+	}
+
+	target, ok := args["target"].(string)
+	if !ok || target == "" {
+		return nil, fmt.Errorf("target collection name is required")
+	}
+
+	// Create timeout context for collection operations
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+	defer cancel()
+
+	sourceSchema, err := s.getSchema(timeoutCtx, source)
+	if err != nil {
+		return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get schema for collection '%s'", source), err)
+	}
+
+	targetSchema, err := s.getSchema(timeoutCtx, target)
+	if err != nil {
+		return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get schema for collection '%s'", target), err)
+	}
+
+	if sourceSchema == nil || targetSchema == nil {
+		return nil, fmt.Errorf("schema not available for collection comparison")
+	}
+
+	result := diffSchemas(sourceSchema, targetSchema)
+	result["source"] = source
+	result["target"] = target
+
+	// The vectordb schema has no vector index settings; read them from Weaviate
+	if s.requireWeaviateDatabase(ctx, "vector index comparison") == nil {
+		client, err := s.newWeaviateClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sourceFull, err := client.GetFullCollectionSchema(timeoutCtx, source)
+		if err != nil {
+			return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get schema for collection '%s'", source), err)
+		}
+		targetFull, err := client.GetFullCollectionSchema(timeoutCtx, target)
+		if err != nil {
+			return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get schema for collection '%s'", target), err)
+		}
+
+		indexDiff := diffVectorIndexConfigs(sourceFull.VectorIndexConfig, targetFull.VectorIndexConfig)
+		result["vector_index_config"] = indexDiff
+		if indexDiff["changed"].(bool) {
+			result["identical"] = false
+		}
+	}
+
+	return result, nil
+}
+
+// handleReloadSchemas re-reads schema definitions from the schemas directory
+func (s *Server) handleReloadSchemas(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	names, err := s.config.ReloadSchemas()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload schemas: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Reloaded %d schemas from %s", len(names), s.config.SchemasDir))
+
+	return map[string]interface{}{
+		"schemas_dir": s.config.SchemasDir,
+		"schemas":     names,
+		"count":       len(names),
+	}, nil
+}
+
+// weaviateSchemaProperty converts a vectordb schema property, including
+// nested properties
+func weaviateSchemaProperty(prop vectordb.SchemaProperty) weaviate.SchemaProperty {
+	property := weaviate.SchemaProperty{
+		Name:        prop.Name,
+		DataType:    prop.DataType,
+		Description: prop.Description,
+		JSONSchema:  prop.JSONSchema,
+	}
+	for _, nested := range prop.NestedProperties {
+		property.NestedProperties = append(property.NestedProperties, weaviateSchemaProperty(nested))
+	}
+	return property
+}
+
+// handleGetCollectionConfig returns a collection's module configuration
+func (s *Server) handleGetCollectionConfig(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	// Module config is not exposed by the generic vector database client
+	if err := s.requireWeaviateDatabase(ctx, "get_collection_config"); err != nil {
+		return nil, err
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to create Weaviate client", err)
+	}
+
+	// Create timeout context for schema operations
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeSchema)
+	defer cancel()
+
+	moduleConfig, err := client.GetCollectionModuleConfig(timeoutCtx, name)
+	if err != nil {
+		return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get config for collection '%s'", name), err)
+	}
+
+	return map[string]interface{}{
+		"collection":         moduleConfig.Class,
+		"vectorizer":         moduleConfig.Vectorizer,
+		"module_config":      moduleConfig.ModuleConfig,
+		"generative_modules": moduleConfig.GenerativeModules,
+		"reranker_modules":   moduleConfig.RerankerModules,
+		"generative_enabled": moduleConfig.GenerativeEnabled(),
+		"reranker_enabled":   moduleConfig.RerankerEnabled(),
+	}, nil
+}
+
+// weaviateQueryConfigured reports whether the database is Weaviate with an
+// explicit search_fallback chain, max_fallback_depth, or score_normalization
+func (s *Server) weaviateQueryConfigured(ctx context.Context) bool {
+	if s.requireWeaviateDatabase(ctx, "search_fallback") != nil {
+		return false
+	}
+	dbConfig, err := s.databaseConfig(ctx)
+	return err == nil && (dbConfig.SearchFallback != nil || dbConfig.MaxFallbackDepth != 0 || dbConfig.ScoreNormalization != "")
+}
+
+// querySemanticWithFallback runs a semantic query through the Weaviate client,
+// which follows the configured search_fallback chain when nearText fails. It
+// also returns the search mode that answered, and when options.IncludeVector
+// is set the vector of each result, in result order.
+func (s *Server) querySemanticWithFallback(ctx context.Context, collection, query string, options weaviate.QueryOptions) ([]*vectordb.QueryResult, [][]float32, string, error) {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	weaviateResults, searchMode, err := client.QueryWithSearchMode(ctx, collection, query, options)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	results := make([]*vectordb.QueryResult, len(weaviateResults))
+	vectors := make([][]float32, len(weaviateResults))
+	for i, res := range weaviateResults {
+		results[i] = &vectordb.QueryResult{
+			Document: vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata},
+			Score:    res.Score,
+		}
+		vectors[i] = res.Vector
+	}
+	return results, vectors, searchMode, nil
+}
+
+// resolveQueryVectorizer returns the named vector of a Weaviate collection
+// that embeds queries with the query_vectorizer module, or "" for the
+// collection's default vector
+func (s *Server) resolveQueryVectorizer(ctx context.Context, collection, queryVectorizer string) (string, error) {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	targetVector, err := client.ResolveQueryVectorizer(ctx, collection, queryVectorizer)
+	if err != nil {
+		return "", fmt.Errorf("query_vectorizer: %w", err)
+	}
+	return targetVector, nil
+}
+
+// searchModeSemantic is the search_mode of query_documents results from the
+// vectordb adapter's semantic search, which does not report a finer mode
+const searchModeSemantic = "semantic"
+
+// queryResultMessage explains an empty or degraded query_documents result, so
+// agents can tell a query that matched nothing from a search that degraded to
+// a fallback or a collection with no documents. It is empty otherwise.
+func (s *Server) queryResultMessage(ctx context.Context, collection, searchMode string, count int) string {
+	degraded := searchMode == weaviate.SearchModeHybrid || searchMode == weaviate.SearchModeFallbackKeyword
+	if count > 0 {
+		if degraded {
+			return fmt.Sprintf("semantic search is not available on collection '%s'; results come from the %s fallback", collection, searchMode)
+		}
+		return ""
+	}
+
+	if total, err := s.getCollectionCount(ctx, collection); err == nil && total == 0 {
+		return fmt.Sprintf("collection '%s' is empty", collection)
+	}
+	if degraded {
+		return fmt.Sprintf("no matching documents: semantic search is not available on collection '%s' and the %s fallback found no matches; try other keywords", collection, searchMode)
+	}
+	return "no matching documents; try rephrasing the query or another search mode such as search_hybrid or search_bm25"
+}
+
+// markFallbackResult replaces the score of a result from the simple search
+// fallback, which is not ranked, with score_unavailable and its search_mode
+func markFallbackResult(result map[string]interface{}, searchMode string) {
+	if searchMode != weaviate.SearchModeFallbackKeyword {
+		return
+	}
+	delete(result, "score")
+	result["score_unavailable"] = true
+	result["search_mode"] = searchMode
+}
+
+// nearTextUnsupported reports whether semantic search is known to fail on a
+// Weaviate collection, from get_search_capabilities or an earlier query
+func (s *Server) nearTextUnsupported(ctx context.Context, collection string) bool {
+	if s.requireWeaviateDatabase(ctx, "search mode cache") != nil {
+		return false
+	}
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return false
+	}
+	supported, known := weaviate.CachedSearchMode(dbConfig.URL, collection, weaviate.SearchModeNearText)
+	return known && !supported
+}
+
+// invalidateCollectionCaches drops what Weaviate clients cached about a
+// collection after it is created or deleted through the database adapter,
+// which bypasses the client's own invalidation
+func (s *Server) invalidateCollectionCaches(ctx context.Context, collection string) {
+	if s.requireWeaviateDatabase(ctx, "collection caches") != nil {
+		return
+	}
+	dbConfig, err := s.databaseConfig(ctx)
+	if err != nil {
+		return
 	}
+	weaviate.InvalidateCollectionCaches(dbConfig.URL, collection)
+}
 
-	// Try to parse JSON output
-	var result interface{}
-	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
-		// If not JSON, return raw output
-		return map[string]interface{}{
-			"output": stdout.String(),
-		}, nil
+// handleGetSearchCapabilities handles the get_search_capabilities tool
+func (s *Server) handleGetSearchCapabilities(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("collection name is required")
 	}
 
-	return result, nil
-}
+	refresh, _ := args["refresh"].(bool)
 
-// handleHealthCheck checks the health of the vector database
-func (s *Server) handleHealthCheck(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	// Create timeout context (10 seconds for health check)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 10)
-	defer cancel()
+	// Search modes are probed with raw GraphQL queries
+	if err := s.requireWeaviateDatabase(ctx, "get_search_capabilities"); err != nil {
+		return nil, err
+	}
 
-	// Get database config
-	dbConfig, err := s.config.GetDefaultDatabase()
+	client, err := s.newWeaviateClient(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get database config: %w", err)
+		return nil, s.enhanceError(ctx, "failed to create Weaviate client", err)
 	}
 
-	// Check database health
-	err = s.dbClient.Health(timeoutCtx)
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	capabilities, err := client.GetSearchCapabilities(timeoutCtx, name, refresh)
 	if err != nil {
-		return map[string]interface{}{
-			"status":   "unhealthy",
-			"database": string(dbConfig.Type),
-			"error":    err.Error(),
-		}, nil
+		return nil, s.enhanceError(ctx, fmt.Sprintf("failed to get search capabilities for collection '%s'", name), err)
 	}
 
 	return map[string]interface{}{
-		"status":   "healthy",
-		"database": string(dbConfig.Type),
-		"url":      dbConfig.URL,
+		"collection": capabilities.Collection,
+		"modes":      capabilities.Modes,
+		"probed":     capabilities.Probed,
 	}, nil
 }
 
-// handleCountCollections counts the total number of collections
-func (s *Server) handleCountCollections(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	// Create timeout context (20 seconds for collection operation)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 20)
-	defer cancel()
+// diffSchemas returns a structured diff of two collection schemas.
+// Properties present only in target are reported as added, properties present
+// only in source as removed.
+func diffSchemas(source, target *vectordb.CollectionSchema) map[string]interface{} {
+	sourceProps := make(map[string]vectordb.SchemaProperty, len(source.Properties))
+	for _, prop := range source.Properties {
+		sourceProps[prop.Name] = prop
+	}
+	targetProps := make(map[string]vectordb.SchemaProperty, len(target.Properties))
+	for _, prop := range target.Properties {
+		targetProps[prop.Name] = prop
+	}
 
-	// List all collections
-	collections, err := s.dbClient.ListCollections(timeoutCtx)
-	if err != nil {
-		return nil, s.enhanceError("failed to list collections", err)
+	added := []map[string]interface{}{}
+	for _, prop := range target.Properties {
+		if _, exists := sourceProps[prop.Name]; !exists {
+			added = append(added, map[string]interface{}{
+				"name":      prop.Name,
+				"data_type": prop.DataType,
+			})
+		}
 	}
 
-	// Extract collection names
-	names := make([]string, len(collections))
-	for i, col := range collections {
-		names[i] = col.Name
+	removed := []map[string]interface{}{}
+	changed := []map[string]interface{}{}
+	for _, prop := range source.Properties {
+		targetProp, exists := targetProps[prop.Name]
+		if !exists {
+			removed = append(removed, map[string]interface{}{
+				"name":      prop.Name,
+				"data_type": prop.DataType,
+			})
+			continue
+		}
+
+		var changes []string
+		if strings.Join(prop.DataType, ",") != strings.Join(targetProp.DataType, ",") {
+			changes = append(changes, "data_type")
+		}
+		if prop.Description != targetProp.Description {
+			changes = append(changes, "description")
+		}
+		if nestedPropertyNames(prop) != nestedPropertyNames(targetProp) {
+			changes = append(changes, "nested_properties")
+		}
+		if len(changes) > 0 {
+			changed = append(changed, map[string]interface{}{
+				"name":             prop.Name,
+				"changes":          changes,
+				"source_data_type": prop.DataType,
+				"target_data_type": targetProp.DataType,
+			})
+		}
 	}
 
+	vectorizerChanged := source.Vectorizer != target.Vectorizer
+
 	return map[string]interface{}{
-		"count":       len(collections),
-		"collections": names,
-	}, nil
+		"identical": !vectorizerChanged && len(added) == 0 && len(removed) == 0 && len(changed) == 0,
+		"vectorizer": map[string]interface{}{
+			"source":  source.Vectorizer,
+			"target":  target.Vectorizer,
+			"changed": vectorizerChanged,
+		},
+		"properties_added":   added,
+		"properties_removed": removed,
+		"properties_changed": changed,
+	}
 }
 
-// handleShowCollection shows detailed information about a collection
-func (s *Server) handleShowCollection(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	collectionName, ok := args["name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("collection name is required")
+// diffVectorIndexConfigs compares the HNSW settings of two collections. A
+// missing config compares as all settings unset.
+func diffVectorIndexConfigs(source, target *weaviate.VectorIndexConfig) map[string]interface{} {
+	if source == nil {
+		source = &weaviate.VectorIndexConfig{}
 	}
-
-	// Create timeout context (20 seconds for collection operation)
-	timeoutCtx, cancel := s.createContextWithTimeout(ctx, 20)
-	defer cancel()
-
-	// Get collection schema
-	schema, err := s.dbClient.GetSchema(timeoutCtx, collectionName)
-	if err != nil {
-		return nil, s.enhanceError("failed to get collection schema", err)
+	if target == nil {
+		target = &weaviate.VectorIndexConfig{}
 	}
 
-	// Get collection count
-	count, err := s.dbClient.GetCollectionCount(timeoutCtx, collectionName)
-	if err != nil {
-		return nil, s.enhanceError("failed to get collection count", err)
+	changes := []string{}
+	if source.Distance != target.Distance {
+		changes = append(changes, "distance")
+	}
+	if source.EfConstruction != target.EfConstruction {
+		changes = append(changes, "efConstruction")
+	}
+	if source.MaxConnections != target.MaxConnections {
+		changes = append(changes, "maxConnections")
+	}
+	if source.Ef != target.Ef {
+		changes = append(changes, "ef")
 	}
 
 	return map[string]interface{}{
-		"name":       collectionName,
-		"schema":     schema,
-		"count":      count,
-		"vectorizer": schema.Vectorizer,
-		"properties": schema.Properties,
-	}, nil
+		"source":  *source,
+		"target":  *target,
+		"changed": len(changes) > 0,
+		"changes": changes,
+	}
+}
+
+// nestedPropertyNames returns a sorted, comma-separated list of nested property names and types
+func nestedPropertyNames(prop vectordb.SchemaProperty) string {
+	names := make([]string, 0, len(prop.NestedProperties))
+	for _, nested := range prop.NestedProperties {
+		names = append(names, nested.Name+":"+strings.Join(nested.DataType, "|"))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
 }
 
 // handleListEmbeddingModels lists all available embedding models
@@ -887,9 +3279,9 @@ func (s *Server) handleShowCollectionEmbeddings(ctx context.Context, args map[st
 	defer cancel()
 
 	// Get collection schema which contains vectorizer info
-	schema, err := s.dbClient.GetSchema(timeoutCtx, collectionName)
+	schema, err := s.getSchema(timeoutCtx, collectionName)
 	if err != nil {
-		return nil, s.enhanceError("failed to get collection schema", err)
+		return nil, s.enhanceError(ctx, "failed to get collection schema", err)
 	}
 
 	// Determine dimensions based on vectorizer
@@ -919,15 +3311,15 @@ func (s *Server) handleGetCollectionStats(ctx context.Context, args map[string]i
 	defer cancel()
 
 	// Get collection schema/info
-	schema, err := s.dbClient.GetSchema(timeoutCtx, collectionName)
+	schema, err := s.getSchema(timeoutCtx, collectionName)
 	if err != nil {
-		return nil, s.enhanceError("failed to get collection schema", err)
+		return nil, s.enhanceError(ctx, "failed to get collection schema", err)
 	}
 
 	// Get document count
-	count, err := s.dbClient.GetCollectionCount(timeoutCtx, collectionName)
+	count, err := s.getCollectionCount(timeoutCtx, collectionName)
 	if err != nil {
-		return nil, s.enhanceError("failed to count documents", err)
+		return nil, s.enhanceError(ctx, "failed to count documents", err)
 	}
 
 	// Build stats response
@@ -943,9 +3335,11 @@ func (s *Server) handleGetCollectionStats(ctx context.Context, args map[string]i
 	return stats, nil
 }
 
-// handleDeleteAllDocuments deletes all documents from a collection or all collections
+// handleDeleteAllDocuments deletes all documents from a collection or all collections.
+// Deletes above the configured cap require confirm_count to match the current count.
 func (s *Server) handleDeleteAllDocuments(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	collectionName, _ := args["collection"].(string)
+	dryRun, _ := args["dry_run"].(bool)
 
 	// Create timeout context for delete operations
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
@@ -953,57 +3347,200 @@ func (s *Server) handleDeleteAllDocuments(ctx context.Context, args map[string]i
 
 	if collectionName == "" {
 		// Delete all documents from all collections
-		collections, err := s.dbClient.ListCollections(timeoutCtx)
+		collections, err := s.db(ctx).ListCollections(timeoutCtx)
 		if err != nil {
-			return nil, s.enhanceError("failed to list collections", err)
+			return nil, s.enhanceError(ctx, "failed to list collections", err)
 		}
 
-		totalDeleted := 0
-		for _, coll := range collections {
-			// Get all documents in collection
-			docs, err := s.dbClient.ListDocuments(timeoutCtx, coll.Name, 10000, 0) // Large limit, offset 0
+		counts := make([]int64, len(collections))
+		countErrs := s.forEachCollection(timeoutCtx, collections, func(i int, collection string) error {
+			count, err := s.getCollectionCount(timeoutCtx, collection)
+			counts[i] = count
+			return err
+		})
+
+		// The total gates confirmation, so every collection must be counted
+		var documentCount int64
+		for i, err := range countErrs {
 			if err != nil {
-				s.logger.Warn(fmt.Sprintf("Failed to list documents in %s: %v", coll.Name, err))
-				continue
+				return nil, s.enhanceError(ctx, fmt.Sprintf("failed to count documents in collection '%s'", collections[i].Name), err)
 			}
+			documentCount += counts[i]
+		}
+
+		if dryRun {
+			return s.deleteAllDryRun(map[string]interface{}{
+				"collections": len(collections),
+			}, documentCount), nil
+		}
+		if err := s.checkDeleteAllConfirmation(args, "all collections", documentCount); err != nil {
+			return nil, err
+		}
 
-			// Delete each document
-			for _, doc := range docs {
-				err := s.dbClient.DeleteDocument(timeoutCtx, coll.Name, doc.ID)
+		return s.runOperation(timeoutCtx, args, "delete_all_documents", documentCount, func(ctx context.Context, progress func(int64)) (interface{}, error) {
+			var processed int64
+			deleted := make([]*weaviate.BulkDeleteResult, len(collections))
+			errs := s.forEachCollection(ctx, collections, func(i int, collection string) error {
+				result, err := s.deleteAllCollectionDocuments(ctx, collection, func(n int) {
+					progress(atomic.AddInt64(&processed, int64(n)))
+				})
+				deleted[i] = result
 				if err != nil {
-					s.logger.Warn(fmt.Sprintf("Failed to delete document %s: %v", doc.ID, err))
+					s.logger.Warn(fmt.Sprintf("Failed to delete documents in %s: %v", collection, err))
+				}
+				return err
+			})
+
+			totalDeleted := 0
+			failedDocs := map[string]map[string]string{}
+			for i, result := range deleted {
+				if result == nil {
 					continue
 				}
-				totalDeleted++
+				totalDeleted += len(result.Deleted)
+				if len(result.Failed) > 0 {
+					failedDocs[collections[i].Name] = failedDocuments(result)
+				}
+			}
+			failed := failedCollections(collections, errs)
+
+			response := map[string]interface{}{
+				"deleted_count":       totalDeleted,
+				"collections_cleaned": len(collections) - len(failed),
+				"failed":              failedDocs,
+			}
+			if len(failed) > 0 {
+				response["failed_collections"] = failed
 			}
+			return response, nil
+		})
+	}
+
+	documentCount, err := s.getCollectionCount(timeoutCtx, collectionName)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to count documents", err)
+	}
+
+	if dryRun {
+		return s.deleteAllDryRun(map[string]interface{}{
+			"collection": collectionName,
+		}, documentCount), nil
+	}
+	if err := s.checkDeleteAllConfirmation(args, fmt.Sprintf("collection '%s'", collectionName), documentCount); err != nil {
+		return nil, err
+	}
+
+	return s.runOperation(timeoutCtx, args, "delete_all_documents", documentCount, func(ctx context.Context, progress func(int64)) (interface{}, error) {
+		var processed int64
+		deleted, err := s.deleteAllCollectionDocuments(ctx, collectionName, func(n int) {
+			processed += int64(n)
+			progress(processed)
+		})
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to delete documents", err)
 		}
 
 		return map[string]interface{}{
-			"deleted_count":       totalDeleted,
-			"collections_cleaned": len(collections),
+			"collection":    collectionName,
+			"deleted_count": len(deleted.Deleted),
+			"failed_count":  len(deleted.Failed),
+			"failed":        failedDocuments(deleted),
 		}, nil
+	})
+}
+
+// runOperation runs a long operation inline, or in the background as a job
+// returning a job_id when async is set
+func (s *Server) runOperation(ctx context.Context, args map[string]interface{}, tool string, total int64, run jobFunc) (interface{}, error) {
+	if async, _ := args["async"].(bool); async {
+		return s.startJob(tool, total, func(jobCtx context.Context, progress func(int64)) (interface{}, error) {
+			// The request context ends with the response, so use a bulk timeout instead
+			jobCtx = withDatabaseOf(jobCtx, ctx)
+			timeoutCtx, cancel := s.createContextWithTimeout(jobCtx, vectordb.OperationTypeBulk)
+			defer cancel()
+			return run(timeoutCtx, progress)
+		})
+	}
+
+	return run(ctx, func(int64) {})
+}
+
+// deleteAllDryRun adds the document count and confirmation requirement to a
+// delete_all_documents dry run response
+func (s *Server) deleteAllDryRun(response map[string]interface{}, documentCount int64) map[string]interface{} {
+	deleteCap := s.config.DeleteAllDocumentsCap()
+	response["dry_run"] = true
+	response["document_count"] = documentCount
+	response["deleted_count"] = 0
+	response["max_delete_all_documents"] = deleteCap
+	response["confirmation_required"] = deleteCap >= 0 && documentCount > int64(deleteCap)
+	return response
+}
+
+// checkDeleteAllConfirmation refuses deletes above the configured cap unless
+// confirm_count matches the current document count. A confirm_count that no
+// longer matches is always refused, since the data changed after the dry run.
+func (s *Server) checkDeleteAllConfirmation(args map[string]interface{}, target string, documentCount int64) error {
+	if _, confirmed := args["confirm_count"]; confirmed {
+		confirmCount := int64(getIntArg(args, "confirm_count", -1))
+		if confirmCount != documentCount {
+			return fmt.Errorf("confirm_count %d does not match the %d documents in %s; run with dry_run to get the current count",
+				confirmCount, documentCount, target)
+		}
+		return nil
+	}
+
+	deleteCap := s.config.DeleteAllDocumentsCap()
+	if deleteCap >= 0 && documentCount > int64(deleteCap) {
+		return fmt.Errorf("%s has %d documents, more than the delete_all_documents limit of %d; run with dry_run and pass confirm_count=%d to proceed",
+			target, documentCount, deleteCap, documentCount)
 	}
+	return nil
+}
+
+// deleteAllBatchSize is the number of documents delete_all_documents deletes
+// per bulk delete, so progress is reported between batches
+const deleteAllBatchSize = 100
 
-	// Delete all documents from specific collection
-	docs, err := s.dbClient.ListDocuments(timeoutCtx, collectionName, 10000, 0) // Large limit, offset 0
+// deleteAllCollectionDocuments deletes every listed document in a collection
+// while holding its advisory lock and reports which documents were deleted and
+// which failed. onProcessed is called with the size of each batch processed.
+func (s *Server) deleteAllCollectionDocuments(ctx context.Context, collectionName string, onProcessed func(n int)) (*weaviate.BulkDeleteResult, error) {
+	unlock, err := s.lockCollection(ctx, collectionName)
 	if err != nil {
-		return nil, s.enhanceError("failed to list documents", err)
+		return nil, err
 	}
+	defer unlock()
 
-	deletedCount := 0
-	for _, doc := range docs {
-		err := s.dbClient.DeleteDocument(timeoutCtx, collectionName, doc.ID)
+	// Get all documents in collection
+	docs, err := s.db(ctx).ListDocuments(ctx, collectionName, 10000, 0) // Large limit, offset 0
+	if err != nil {
+		return nil, err
+	}
+
+	result := &weaviate.BulkDeleteResult{
+		Deleted: []string{},
+		Failed:  map[string]error{},
+	}
+	for start := 0; start < len(docs); start += deleteAllBatchSize {
+		batch := docs[start:min(start+deleteAllBatchSize, len(docs))]
+		ids := make([]string, len(batch))
+		for i, doc := range batch {
+			ids[i] = doc.ID
+		}
+
+		deleted, err := s.deleteDocumentsBulk(ctx, collectionName, ids)
 		if err != nil {
-			s.logger.Warn(fmt.Sprintf("Failed to delete document %s: %v", doc.ID, err))
-			continue
+			return result, err
 		}
-		deletedCount++
+		result.Deleted = append(result.Deleted, deleted.Deleted...)
+		for id, failure := range deleted.Failed {
+			s.logger.Warn(fmt.Sprintf("Failed to delete document %s: %v", id, failure))
+			result.Failed[id] = failure
+		}
+		onProcessed(len(ids))
 	}
-
-	return map[string]interface{}{
-		"collection":    collectionName,
-		"deleted_count": deletedCount,
-	}, nil
+	return result, nil
 }
 
 // handleShowDocumentByName shows a document by filename instead of ID
@@ -1024,39 +3561,156 @@ func (s *Server) handleShowDocumentByName(ctx context.Context, args map[string]i
 
 	// List documents and find by filename
 	// We'll use a reasonable limit and search through documents
-	docs, err := s.dbClient.ListDocuments(timeoutCtx, collectionName, 1000, 0)
+	docs, err := s.db(ctx).ListDocuments(timeoutCtx, collectionName, 1000, 0)
 	if err != nil {
-		return nil, s.enhanceError("failed to list documents", err)
+		return nil, s.enhanceError(ctx, "failed to list documents", err)
 	}
 
 	// Search for document with matching filename in metadata or URL
 	for _, doc := range docs {
-		// Check URL field
-		if doc.URL != "" && strings.Contains(doc.URL, filename) {
+		if s.documentMatchesFilename(ctx, collectionName, doc, filename) {
 			return map[string]interface{}{
 				"document_id": doc.ID,
 				"collection":  collectionName,
-				"url":         doc.URL,
+				"url":         s.documentURL(ctx, collectionName, doc),
 				"text":        doc.Text,
 				"metadata":    doc.Metadata,
 			}, nil
 		}
+	}
 
-		// Check metadata for filename field
-		if doc.Metadata != nil {
-			if filenameVal, ok := doc.Metadata["filename"].(string); ok && filenameVal == filename {
-				return map[string]interface{}{
-					"document_id": doc.ID,
-					"collection":  collectionName,
-					"url":         doc.URL,
-					"text":        doc.Text,
-					"metadata":    doc.Metadata,
-				}, nil
+	return nil, fmt.Errorf("document with filename '%s' not found in collection '%s'", filename, collectionName)
+}
+
+// Limits for find_document results
+const (
+	defaultFindDocumentLimit = 10
+	maxFindDocumentLimit     = 100
+	// findDocumentPageSize is the number of documents listed per page
+	findDocumentPageSize = 1000
+	// maxFindDocumentScan caps the documents scanned per call, matching
+	// Weaviate's default QUERY_MAXIMUM_RESULTS offset limit
+	maxFindDocumentScan = 10000
+)
+
+// findDocumentCriteria are the find_document arguments, in match_reason order
+var findDocumentCriteria = []string{"id", "url", "filename", "title", "text_contains"}
+
+// handleFindDocument searches a collection for documents matching any of
+// several partial identifiers
+func (s *Server) handleFindDocument(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collectionName, ok := args["collection"].(string)
+	if !ok || collectionName == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	criteria := make(map[string]string)
+	for _, key := range findDocumentCriteria {
+		if value, ok := args[key].(string); ok && value != "" {
+			criteria[key] = strings.ToLower(value)
+		}
+	}
+	if len(criteria) == 0 {
+		return nil, fmt.Errorf("at least one of id, url, filename, title, or text_contains is required")
+	}
+
+	limit := getIntArg(args, "limit", defaultFindDocumentLimit)
+	if limit <= 0 {
+		limit = defaultFindDocumentLimit
+	}
+	if limit > maxFindDocumentLimit {
+		limit = maxFindDocumentLimit
+	}
+
+	// Create timeout context
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+	defer cancel()
+
+	// Page through the collection and match client-side, like show_document_by_name
+	matches := make([]map[string]interface{}, 0)
+	truncated := false
+	scanned := 0
+	for !truncated {
+		if scanned == maxFindDocumentScan {
+			// Documents past the scan cap were not checked
+			truncated = true
+			break
+		}
+
+		docs, err := s.db(ctx).ListDocuments(timeoutCtx, collectionName, findDocumentPageSize, scanned)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to list documents", err)
+		}
+
+		for _, doc := range docs {
+			reasons := s.findDocumentMatchReasons(ctx, collectionName, doc, criteria)
+			if len(reasons) == 0 {
+				continue
 			}
+			if len(matches) == limit {
+				truncated = true
+				break
+			}
+			matches = append(matches, map[string]interface{}{
+				"document_id":  doc.ID,
+				"url":          s.documentURL(ctx, collectionName, doc),
+				"metadata":     doc.Metadata,
+				"match_reason": strings.Join(reasons, ", "),
+			})
+		}
+		scanned += len(docs)
+
+		if len(docs) < findDocumentPageSize {
+			break
 		}
 	}
 
-	return nil, fmt.Errorf("document with filename '%s' not found in collection '%s'", filename, collectionName)
+	return map[string]interface{}{
+		"collection": collectionName,
+		"matches":    matches,
+		"count":      len(matches),
+		"truncated":  truncated,
+		"scanned":    scanned,
+	}, nil
+}
+
+// findDocumentMatchReasons returns the find_document criteria a document
+// matches. Criteria values must already be lowercased; all comparisons are
+// case-insensitive substring matches.
+func (s *Server) findDocumentMatchReasons(ctx context.Context, collectionName string, doc *vectordb.Document, criteria map[string]string) []string {
+	contains := func(value interface{}, needle string) bool {
+		str, ok := value.(string)
+		return ok && str != "" && strings.Contains(strings.ToLower(str), needle)
+	}
+
+	var reasons []string
+	for _, key := range findDocumentCriteria {
+		needle, exists := criteria[key]
+		if !exists {
+			continue
+		}
+
+		matched := false
+		switch key {
+		case "id":
+			matched = contains(doc.ID, needle)
+		case "url":
+			matched = contains(s.documentURL(ctx, collectionName, doc), needle)
+		case "filename":
+			matched = contains(doc.Metadata["filename"], needle) ||
+				contains(doc.Metadata["original_filename"], needle) ||
+				contains(s.documentURL(ctx, collectionName, doc), needle)
+		case "title":
+			matched = contains(doc.Metadata["title"], needle)
+		case "text_contains":
+			matched = contains(doc.Text, needle) || contains(doc.Content, needle)
+		}
+
+		if matched {
+			reasons = append(reasons, key)
+		}
+	}
+	return reasons
 }
 
 // handleDeleteDocumentByName deletes a document by filename instead of ID
@@ -1071,23 +3725,42 @@ func (s *Server) handleDeleteDocumentByName(ctx context.Context, args map[string
 		return nil, fmt.Errorf("filename is required")
 	}
 
+	// Serialize writes to this collection (in-process advisory lock); a dry
+	// run only reads, so it does not wait for other writes
+	dryRun, _ := args["dry_run"].(bool)
+	if !dryRun {
+		unlock, err := s.lockCollection(ctx, collectionName)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	// Create timeout context
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
 	defer cancel()
 
 	// List documents and find by filename
-	docs, err := s.dbClient.ListDocuments(timeoutCtx, collectionName, 1000, 0)
+	docs, err := s.db(ctx).ListDocuments(timeoutCtx, collectionName, 1000, 0)
 	if err != nil {
-		return nil, s.enhanceError("failed to list documents", err)
+		return nil, s.enhanceError(ctx, "failed to list documents", err)
 	}
 
-	// Search for document with matching filename
+	// Search for document with matching filename in metadata or URL
 	for _, doc := range docs {
-		// Check URL field
-		if doc.URL != "" && strings.Contains(doc.URL, filename) {
-			err := s.dbClient.DeleteDocument(timeoutCtx, collectionName, doc.ID)
+		if s.documentMatchesFilename(ctx, collectionName, doc, filename) {
+			if dryRun {
+				return map[string]interface{}{
+					"document_id": doc.ID,
+					"collection":  collectionName,
+					"filename":    filename,
+					"dry_run":     true,
+					"status":      "preview",
+				}, nil
+			}
+			err := s.db(ctx).DeleteDocument(timeoutCtx, collectionName, doc.ID)
 			if err != nil {
-				return nil, s.enhanceError("failed to delete document", err)
+				return nil, s.enhanceError(ctx, "failed to delete document", err)
 			}
 			return map[string]interface{}{
 				"document_id": doc.ID,
@@ -1096,22 +3769,6 @@ func (s *Server) handleDeleteDocumentByName(ctx context.Context, args map[string
 				"status":      "deleted",
 			}, nil
 		}
-
-		// Check metadata for filename field
-		if doc.Metadata != nil {
-			if filenameVal, ok := doc.Metadata["filename"].(string); ok && filenameVal == filename {
-				err := s.dbClient.DeleteDocument(timeoutCtx, collectionName, doc.ID)
-				if err != nil {
-					return nil, s.enhanceError("failed to delete document", err)
-				}
-				return map[string]interface{}{
-					"document_id": doc.ID,
-					"collection":  collectionName,
-					"filename":    filename,
-					"status":      "deleted",
-				}, nil
-			}
-		}
 	}
 
 	return nil, fmt.Errorf("document with filename '%s' not found in collection '%s'", filename, collectionName)
@@ -1125,10 +3782,7 @@ func (s *Server) handleExecuteQuery(ctx context.Context, args map[string]interfa
 	}
 
 	collectionName, _ := args["collection"].(string)
-	limit := 5 // default limit
-	if limitArg, ok := args["limit"].(float64); ok {
-		limit = int(limitArg)
-	}
+	limit := getIntArg(args, "limit", 5)
 
 	// Create timeout context for query operations
 	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
@@ -1136,56 +3790,32 @@ func (s *Server) handleExecuteQuery(ctx context.Context, args map[string]interfa
 
 	// If no collection specified, search across all collections
 	if collectionName == "" {
-		collections, err := s.dbClient.ListCollections(timeoutCtx)
+		collections, err := s.db(ctx).ListCollections(timeoutCtx)
 		if err != nil {
-			return nil, s.enhanceError("failed to list collections", err)
+			return nil, s.enhanceError(ctx, "failed to list collections", err)
 		}
 
-		// Query each collection and aggregate results
-		allResults := []interface{}{}
-		for _, coll := range collections {
-			queryOptions := &vectordb.QueryOptions{
-				TopK: limit,
-			}
-			results, err := s.dbClient.SearchSemantic(timeoutCtx, coll.Name, query, queryOptions)
-			if err != nil {
-				s.logger.Warn(fmt.Sprintf("Failed to query collection %s: %v", coll.Name, err))
-				continue
-			}
+		allResults, errs := s.searchCollections(timeoutCtx, collections, query, limit)
 
-			// Add collection name to each result
-			for _, result := range results {
-				resultMap := map[string]interface{}{
-					"collection":  coll.Name,
-					"document_id": result.Document.ID,
-					"text":        result.Document.Text,
-					"url":         result.Document.URL,
-					"metadata":    result.Document.Metadata,
-					"score":       result.Score,
-				}
-				allResults = append(allResults, resultMap)
-			}
+		response := map[string]interface{}{
+			"query":               query,
+			"results":             allResults,
+			"count":               len(allResults),
+			"collections_queried": len(collections),
 		}
-
-		// Sort by score and limit
-		if len(allResults) > limit {
-			allResults = allResults[:limit]
+		if failed := failedCollections(collections, errs); len(failed) > 0 {
+			response["failed_collections"] = failed
 		}
-
-		return map[string]interface{}{
-			"query":   query,
-			"results": allResults,
-			"count":   len(allResults),
-		}, nil
+		return response, nil
 	}
 
 	// Query specific collection
 	queryOptions := &vectordb.QueryOptions{
 		TopK: limit,
 	}
-	results, err := s.dbClient.SearchSemantic(timeoutCtx, collectionName, query, queryOptions)
+	results, err := s.db(ctx).SearchSemantic(timeoutCtx, collectionName, query, queryOptions)
 	if err != nil {
-		return nil, s.enhanceError("failed to execute query", err)
+		return nil, s.enhanceError(ctx, "failed to execute query", err)
 	}
 
 	// Format results
@@ -1194,7 +3824,7 @@ func (s *Server) handleExecuteQuery(ctx context.Context, args map[string]interfa
 		formattedResults[i] = map[string]interface{}{
 			"document_id": result.Document.ID,
 			"text":        result.Document.Text,
-			"url":         result.Document.URL,
+			"url":         s.documentURL(ctx, collectionName, &result.Document),
 			"metadata":    result.Document.Metadata,
 			"score":       result.Score,
 		}
@@ -1208,6 +3838,72 @@ func (s *Server) handleExecuteQuery(ctx context.Context, args map[string]interfa
 	}, nil
 }
 
+// defaultGenerativeSearchLimit is the number of source documents used for
+// generative search when no limit is given
+const defaultGenerativeSearchLimit = 5
+
+// handleGenerativeSearch handles the generative_search tool
+func (s *Server) handleGenerativeSearch(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	query, ok := args["query"].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	prompt, ok := args["prompt"].(string)
+	if !ok || prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+
+	limit := getIntArg(args, "limit", defaultGenerativeSearchLimit)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	// Generative modules are only reachable through the Weaviate client
+	if err := s.requireWeaviateDatabase(ctx, "generative_search"); err != nil {
+		return nil, err
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to create Weaviate client", err)
+	}
+
+	// Create timeout context for query operations
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	result, err := client.GenerativeSearch(timeoutCtx, collection, query, prompt, limit)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to run generative search", err)
+	}
+
+	sources := make([]interface{}, len(result.Sources))
+	for i, source := range result.Sources {
+		sources[i] = map[string]interface{}{
+			"document_id": source.ID,
+			"text":        source.Content,
+			"metadata":    source.Metadata,
+			"score":       source.Score,
+		}
+	}
+
+	return map[string]interface{}{
+		"collection":         collection,
+		"query":              query,
+		"prompt":             prompt,
+		"answer":             result.Answer,
+		"sources":            sources,
+		"count":              len(sources),
+		"generative_modules": result.Modules,
+	}, nil
+}
+
 // Phase 1: Observability & Monitoring tool handlers
 
 // handleConfigureLogging configures structured logging
@@ -1269,12 +3965,18 @@ func (s *Server) handleGetMetrics(ctx context.Context, args map[string]interface
 				"weave_documents_total",
 				"weave_errors_total",
 				"weave_active_connections",
+				"weave_mcp_tool_calls_total",
+				"weave_mcp_tool_errors_total",
+				"weave_mcp_tool_call_duration_seconds",
 			},
 			"labels": map[string]interface{}{
-				"weave_request_duration_seconds": []string{"vdb_type", "operation", "status"},
-				"weave_documents_total":          []string{"vdb_type", "operation"},
-				"weave_errors_total":             []string{"vdb_type", "operation", "error_type"},
-				"weave_active_connections":       []string{"vdb_type"},
+				"weave_request_duration_seconds":       []string{"vdb_type", "operation", "status"},
+				"weave_documents_total":                []string{"vdb_type", "operation"},
+				"weave_errors_total":                   []string{"vdb_type", "operation", "error_type"},
+				"weave_active_connections":             []string{"vdb_type"},
+				"weave_mcp_tool_calls_total":           []string{"tool"},
+				"weave_mcp_tool_errors_total":          []string{"tool"},
+				"weave_mcp_tool_call_duration_seconds": []string{"tool"},
 			},
 		}, nil
 	}
@@ -1296,13 +3998,13 @@ func (s *Server) handleCheckHealth(ctx context.Context, args map[string]interfac
 	defer cancel()
 
 	// Get database config
-	dbConfig, err := s.config.GetDefaultDatabase()
+	dbConfig, err := s.defaultDatabaseConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database config: %w", err)
 	}
 
 	// Perform health check
-	dbErr := s.dbClient.Health(timeoutCtx)
+	dbErr := s.db(ctx).Health(timeoutCtx)
 
 	result := map[string]interface{}{
 		"status":    "healthy",
@@ -1332,7 +4034,7 @@ func (s *Server) handleCheckHealth(ctx context.Context, args map[string]interfac
 
 		// Try to get collection count as a connectivity test
 		if dbErr == nil {
-			collections, err := s.dbClient.ListCollections(timeoutCtx)
+			collections, err := s.db(ctx).ListCollections(timeoutCtx)
 			if err == nil {
 				dbStatus["collections_count"] = len(collections)
 			}
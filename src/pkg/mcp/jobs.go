@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Job statuses reported by get_job_status and the job events stream
+const (
+	jobStatusRunning   = "running"
+	jobStatusCompleted = "completed"
+	jobStatusFailed    = "failed"
+	jobStatusCancelled = "cancelled"
+)
+
+const (
+	// jobRetention is how long finished jobs stay queryable
+	jobRetention = time.Hour
+	// jobProgressInterval is how often the events stream emits progress
+	jobProgressInterval = time.Second
+	// maxJobs bounds the job store; the oldest finished jobs are evicted first
+	maxJobs = 100
+)
+
+// jobFunc runs an async operation, calling progress with the number of items
+// processed so far
+type jobFunc func(ctx context.Context, progress func(processed int64)) (interface{}, error)
+
+// job is an async operation tracked in memory
+type job struct {
+	mu         sync.Mutex
+	id         string
+	tool       string
+	status     string
+	processed  int64
+	total      int64
+	result     interface{}
+	err        string
+	startedAt  time.Time
+	finishedAt time.Time
+	cancel     context.CancelFunc
+	cancelled  bool
+	finished   chan struct{} // closed when the job completes, fails, or is cancelled
+}
+
+// setProcessed records progress
+func (j *job) setProcessed(processed int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.processed = processed
+}
+
+// finish records the outcome of the job
+func (j *job) finish(result interface{}, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.finishedAt = time.Now()
+	switch {
+	case j.cancelled:
+		j.status = jobStatusCancelled
+		j.result = result
+	case err != nil:
+		j.status = jobStatusFailed
+		j.err = err.Error()
+	default:
+		j.status = jobStatusCompleted
+		j.result = result
+	}
+	close(j.finished)
+}
+
+// requestCancel cancels a running job's context. The job reports cancelled
+// once its operation returns.
+func (j *job) requestCancel() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != jobStatusRunning {
+		return fmt.Errorf("job '%s' is already %s", j.id, j.status)
+	}
+	j.cancelled = true
+	j.cancel()
+	return nil
+}
+
+// finishedTime returns when the job finished, and false while it is running
+func (j *job) finishedTime() (time.Time, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.finishedAt, j.status != jobStatusRunning
+}
+
+// snapshot returns the job state as a JSON-friendly map
+func (j *job) snapshot() map[string]interface{} {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	snapshot := map[string]interface{}{
+		"job_id":     j.id,
+		"tool":       j.tool,
+		"status":     j.status,
+		"processed":  j.processed,
+		"total":      j.total,
+		"started_at": j.startedAt.UTC(),
+	}
+	if j.total > 0 {
+		snapshot["percent"] = float64(j.processed) * 100 / float64(j.total)
+	}
+	if j.status == jobStatusRunning && j.cancelled {
+		snapshot["cancel_requested"] = true
+	}
+	if j.status != jobStatusRunning {
+		snapshot["finished_at"] = j.finishedAt.UTC()
+		snapshot["duration_ms"] = j.finishedAt.Sub(j.startedAt).Milliseconds()
+	}
+	if j.result != nil {
+		snapshot["result"] = j.result
+	}
+	if j.err != "" {
+		snapshot["error"] = j.err
+	}
+	return snapshot
+}
+
+// jobRegistry keeps up to maxJobs async jobs in memory. Jobs are not
+// persisted and are lost when the server restarts; finished jobs are pruned
+// after jobRetention or when room is needed for a new job.
+type jobRegistry struct {
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// start runs fn in the background and returns its job. It fails when the
+// store is full of running jobs.
+func (r *jobRegistry) start(tool string, total int64, fn jobFunc) (*job, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		id:        uuid.New().String(),
+		tool:      tool,
+		status:    jobStatusRunning,
+		total:     total,
+		startedAt: time.Now(),
+		cancel:    cancel,
+		finished:  make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	if r.jobs == nil {
+		r.jobs = make(map[string]*job)
+	}
+	r.pruneLocked(time.Now().Add(-jobRetention))
+	if len(r.jobs) >= maxJobs {
+		r.evictOldestFinishedLocked()
+	}
+	if len(r.jobs) >= maxJobs {
+		r.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("too many jobs in progress (max %d); wait for running jobs to finish", maxJobs)
+	}
+	r.jobs[j.id] = j
+	r.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		result, err := fn(ctx, j.setProcessed)
+		j.finish(result, err)
+	}()
+
+	return j, nil
+}
+
+// get returns a job by ID
+func (r *jobRegistry) get(id string) (*job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j, exists := r.jobs[id]
+	return j, exists
+}
+
+// pruneLocked removes jobs that finished before cutoff. r.mu must be held.
+func (r *jobRegistry) pruneLocked(cutoff time.Time) {
+	for id, j := range r.jobs {
+		if finishedAt, finished := j.finishedTime(); finished && finishedAt.Before(cutoff) {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// evictOldestFinishedLocked removes the job that finished first, if any.
+// r.mu must be held.
+func (r *jobRegistry) evictOldestFinishedLocked() {
+	var (
+		oldest   *job
+		oldestAt time.Time
+	)
+	for _, j := range r.jobs {
+		finishedAt, finished := j.finishedTime()
+		if !finished {
+			continue
+		}
+		if oldest == nil || finishedAt.Before(oldestAt) {
+			oldest, oldestAt = j, finishedAt
+		}
+	}
+	if oldest != nil {
+		delete(r.jobs, oldest.id)
+	}
+}
+
+// startJob starts an async job for a tool and returns its initial status
+func (s *Server) startJob(tool string, total int64, fn jobFunc) (interface{}, error) {
+	// The job outlives the tool call that started it, so Drain tracks it too
+	end := s.calls.begin()
+	j, err := s.jobs.start(tool, total, func(ctx context.Context, progress func(int64)) (interface{}, error) {
+		defer end()
+		return fn(ctx, progress)
+	})
+	if err != nil {
+		end()
+		return nil, err
+	}
+
+	s.logger.Info("Started async job", zap.String("job_id", j.id), zap.String("tool", tool))
+
+	response := j.snapshot()
+	response["events_url"] = "/mcp/jobs/events?job_id=" + j.id
+	return response, nil
+}
+
+// handleGetJobStatus handles the get_job_status tool
+func (s *Server) handleGetJobStatus(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	jobID, ok := args["job_id"].(string)
+	if !ok || jobID == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+
+	j, exists := s.jobs.get(jobID)
+	if !exists {
+		return nil, fmt.Errorf("job '%s' not found", jobID)
+	}
+	return j.snapshot(), nil
+}
+
+// handleCancelJob handles the cancel_job tool
+func (s *Server) handleCancelJob(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	jobID, ok := args["job_id"].(string)
+	if !ok || jobID == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+
+	j, exists := s.jobs.get(jobID)
+	if !exists {
+		return nil, fmt.Errorf("job '%s' not found", jobID)
+	}
+	if err := j.requestCancel(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cancelled async job", zap.String("job_id", jobID))
+	return j.snapshot(), nil
+}
+
+// handleJobEvents streams job progress as server-sent events: a "progress"
+// event every jobProgressInterval and a final "done" event when the job ends
+func (s *Server) handleJobEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobID := r.URL.Query().Get("job_id")
+	if jobID == "" {
+		http.Error(w, "job_id is required", http.StatusBadRequest)
+		return
+	}
+
+	j, exists := s.jobs.get(jobID)
+	if !exists {
+		http.Error(w, fmt.Sprintf("Job '%s' not found", jobID), http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	release, ok := s.acquireStream(w, r, streamJobEvents)
+	if !ok {
+		return
+	}
+	defer release()
+
+	// Jobs outlast the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	writeEvent := func(event string) bool {
+		data, err := json.Marshal(j.snapshot())
+		if err != nil {
+			s.logger.Error("Failed to encode job event", zap.String("job_id", jobID), zap.Error(err))
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	ticker := time.NewTicker(jobProgressInterval)
+	defer ticker.Stop()
+
+	if !writeEvent("progress") {
+		return
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-j.finished:
+			writeEvent("done")
+			return
+		case <-ticker.C:
+			if !writeEvent("progress") {
+				return
+			}
+		}
+	}
+}
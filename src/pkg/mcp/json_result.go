@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// APIVersion is the version of the tool response format. It is reported as
+// api_version in every object a tool returns and in the HTTP envelope of
+// each call. It is bumped when a response shape changes incompatibly: a
+// field is removed or renamed, changes type, or changes meaning. Adding a
+// field, or a tool, does not bump it, so clients should ignore fields they
+// don't know.
+const APIVersion = "1"
+
+// apiVersionField is the response field carrying APIVersion
+const apiVersionField = "api_version"
+
+// toJSONValue converts a value into plain JSON structures (maps, slices,
+// strings, bools, json.Number and nil) by round-tripping it through JSON.
+// Numbers are kept as json.Number so large integers don't lose precision.
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var normalized interface{}
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// withJSONResult wraps a tool handler so its result is normalized into plain
+// JSON structures, giving in-process, stdio and HTTP callers the same shape.
+// Object results are stamped with api_version.
+func withJSONResult(toolName string, handler func(ctx context.Context, args map[string]interface{}) (interface{}, error)) func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		result, err := handler(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized, err := toJSONValue(result)
+		if err != nil {
+			return nil, fmt.Errorf("tool '%s' returned a result that is not JSON-serializable: %w", toolName, err)
+		}
+		if object, ok := normalized.(map[string]interface{}); ok {
+			if _, exists := object[apiVersionField]; !exists {
+				object[apiVersionField] = APIVersion
+			}
+		}
+		return normalized, nil
+	}
+}
+
+// DecodeToolArguments decodes the JSON arguments of a tool call. Whole
+// numbers that fit become int64 instead of float64, so IDs and counts above
+// 2^53 arrive intact; other numbers become float64.
+func DecodeToolArguments(data []byte) (map[string]interface{}, error) {
+	var args map[string]interface{}
+	if err := decodeWithNumbers(data, &args); err != nil {
+		return nil, err
+	}
+	normalizeArgumentNumbers(args)
+	return args, nil
+}
+
+// decodeWithNumbers unmarshals JSON, keeping numbers as json.Number
+func decodeWithNumbers(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// normalizeArgumentNumbers replaces the json.Number values of decoded
+// arguments, recursively, with int64 for whole numbers and float64 otherwise
+func normalizeArgumentNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeArgumentNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeArgumentNumbers(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// imageCollectionKeywords mirror the weave-cli Weaviate adapter, which picks
+// the metadata shape from the collection name rather than from its schema
+var imageCollectionKeywords = []string{"image", "img", "photo", "picture", "visual"}
+
+// adapterUsesObjectMetadata reports whether the weave-cli adapter writes
+// metadata of a collection as an object (true) or a JSON string (false)
+func adapterUsesObjectMetadata(collectionName string) bool {
+	name := strings.ToLower(collectionName)
+	for _, keyword := range imageCollectionKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaMetadataFormat returns the metadata format of a Weaviate collection
+// when it differs from the shape the weave-cli adapter would write, and ""
+// when the adapter can be used. Writing the wrong shape stores documents that
+// later fail to read with "must not have a sub selection".
+func (s *Server) schemaMetadataFormat(ctx context.Context, collectionName string) string {
+	if s.requireWeaviateDatabase(ctx, "metadata formatting") != nil {
+		return ""
+	}
+
+	schema, err := s.getSchema(ctx, collectionName)
+	if err != nil || schema == nil {
+		return ""
+	}
+
+	format := metadataFormatOf(schema)
+	if (format == weaviate.MetadataFormatObject) == adapterUsesObjectMetadata(collectionName) {
+		return ""
+	}
+	return format
+}
+
+// metadataFormatOf returns how a schema stores document metadata: as an
+// object when its metadata property is an object, otherwise as JSON text
+func metadataFormatOf(schema *vectordb.CollectionSchema) string {
+	for _, prop := range schema.Properties {
+		if prop.Name == "metadata" && len(prop.DataType) > 0 && prop.DataType[0] == "object" {
+			return weaviate.MetadataFormatObject
+		}
+	}
+	return weaviate.MetadataFormatText
+}
+
+// documentWriteOptions are create_document options that only the Weaviate
+// REST client supports
+type documentWriteOptions struct {
+	textField  string                 // Property receiving the text; both text and content when empty
+	properties map[string]interface{} // Additional top-level properties
+}
+
+// createDocumentsWithSchemaFormat creates documents through the Weaviate REST
+// client, which formats metadata to match the collection schema
+func (s *Server) createDocumentsWithSchemaFormat(ctx context.Context, collectionName string, docs []*vectordb.Document, opts documentWriteOptions) error {
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		err := client.CreateDocument(ctx, collectionName, weaviate.Document{
+			ID:           doc.ID,
+			URL:          doc.URL,
+			Image:        doc.Image,
+			ImageData:    doc.ImageData,
+			Text:         doc.Text,
+			Content:      doc.Content,
+			ContentField: opts.textField,
+			Metadata:     doc.Metadata,
+			Properties:   opts.properties,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -393,6 +393,11 @@ func (s *MockServer) registerTools() {
 
 // registerTool registers a tool with the mock server
 func (s *MockServer) registerTool(tool Tool) {
+	tool.Handler = withJSONResult(tool.Name, tool.Handler)
+	if tool.Examples == nil {
+		tool.Examples = toolExamples[tool.Name]
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Tools[tool.Name] = tool
@@ -963,10 +968,7 @@ func (s *MockServer) handleExecuteQuery(ctx context.Context, args map[string]int
 	}
 
 	collectionName, _ := args["collection"].(string)
-	limit := 5
-	if limitArg, ok := args["limit"].(float64); ok {
-		limit = int(limitArg)
-	}
+	limit := getIntArg(args, "limit", 5)
 
 	// If no collection specified, search all collections
 	if collectionName == "" {
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// forEachCollection runs fn for every collection, processing at most
+// multi_collection_concurrency collections at once. It returns the error of
+// each collection in the order of collections.
+func (s *Server) forEachCollection(ctx context.Context, collections []vectordb.CollectionInfo, fn func(i int, collection string) error) []error {
+	errs := make([]error, len(collections))
+	semaphore := make(chan struct{}, s.config.CollectionConcurrency())
+	var wg sync.WaitGroup
+	for i, coll := range collections {
+		wg.Add(1)
+		go func(i int, collection string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = fn(i, collection)
+		}(i, coll.Name)
+	}
+	wg.Wait()
+	return errs
+}
+
+// failedCollections lists the collections whose part of a multi-collection
+// operation failed, with their errors, sorted by collection name
+func failedCollections(collections []vectordb.CollectionInfo, errs []error) []map[string]interface{} {
+	failed := []map[string]interface{}{}
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, map[string]interface{}{
+				"collection": collections[i].Name,
+				"error":      err.Error(),
+			})
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i]["collection"].(string) < failed[j]["collection"].(string)
+	})
+	return failed
+}
+
+// sortCollectionsByName returns the collections sorted by name, so results
+// of a fan-out don't depend on the order the database lists them in
+func sortCollectionsByName(collections []vectordb.CollectionInfo) []vectordb.CollectionInfo {
+	sorted := make([]vectordb.CollectionInfo, len(collections))
+	copy(sorted, collections)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// searchCollections runs a semantic search on each collection concurrently
+// and returns the best limit results across them, tagged with their
+// collection, along with the error of each collection. Results are ordered
+// by score, then collection name, then document ID, so the same results
+// always come back in the same order.
+func (s *Server) searchCollections(ctx context.Context, collections []vectordb.CollectionInfo, query string, limit int) ([]interface{}, []error) {
+	collectionResults := make([][]*vectordb.QueryResult, len(collections))
+	errs := s.forEachCollection(ctx, collections, func(i int, collection string) error {
+		results, err := s.db(ctx).SearchSemantic(ctx, collection, query, &vectordb.QueryOptions{TopK: limit})
+		if err != nil {
+			s.logger.Warn(fmt.Sprintf("Failed to query collection %s: %v", collection, err))
+			return err
+		}
+		collectionResults[i] = results
+		return nil
+	})
+
+	// Add collection name to each result
+	allResults := []interface{}{}
+	for i, results := range collectionResults {
+		for _, result := range results {
+			allResults = append(allResults, map[string]interface{}{
+				"collection":  collections[i].Name,
+				"document_id": result.Document.ID,
+				"text":        result.Document.Text,
+				"url":         s.documentURL(ctx, collections[i].Name, &result.Document),
+				"metadata":    result.Document.Metadata,
+				"score":       result.Score,
+			})
+		}
+	}
+
+	// Sort by score and limit, breaking ties by collection and document
+	sort.Slice(allResults, func(i, j int) bool {
+		a, b := allResults[i].(map[string]interface{}), allResults[j].(map[string]interface{})
+		if scoreA, scoreB := a["score"].(float64), b["score"].(float64); scoreA != scoreB {
+			return scoreA > scoreB
+		}
+		if collA, collB := a["collection"].(string), b["collection"].(string); collA != collB {
+			return collA < collB
+		}
+		return a["document_id"].(string) < b["document_id"].(string)
+	})
+	if len(allResults) > limit {
+		allResults = allResults[:limit]
+	}
+	return allResults, errs
+}
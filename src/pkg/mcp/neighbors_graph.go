@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+const (
+	// defaultGraphDepth is the default number of hops from the starting document
+	defaultGraphDepth = 2
+	// maxGraphDepth caps the hops nearest_neighbors_graph explores
+	maxGraphDepth = 5
+	// defaultGraphBreadth is the default number of neighbors fetched per node
+	defaultGraphBreadth = 5
+	// maxGraphBreadth caps the neighbors fetched per node
+	maxGraphBreadth = 20
+	// defaultGraphMaxNodes is the default bound on the nodes in the graph
+	defaultGraphMaxNodes = 50
+	// maxGraphNodes caps max_nodes, which bounds the nearObject queries run
+	maxGraphNodes = 500
+)
+
+// graphNode is a document queued for neighbor expansion
+type graphNode struct {
+	id    string
+	depth int
+}
+
+// handleNearestNeighborsGraph handles the nearest_neighbors_graph tool
+func (s *Server) handleNearestNeighborsGraph(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	documentID, ok := args["document_id"].(string)
+	if !ok || documentID == "" {
+		return nil, fmt.Errorf("document ID is required")
+	}
+
+	depth := getIntArg(args, "depth", defaultGraphDepth)
+	if depth < 1 || depth > maxGraphDepth {
+		return nil, fmt.Errorf("depth must be between 1 and %d", maxGraphDepth)
+	}
+	breadth := getIntArg(args, "breadth", defaultGraphBreadth)
+	if breadth < 1 || breadth > maxGraphBreadth {
+		return nil, fmt.Errorf("breadth must be between 1 and %d", maxGraphBreadth)
+	}
+	maxNodes := getIntArg(args, "max_nodes", defaultGraphMaxNodes)
+	if maxNodes < 1 || maxNodes > maxGraphNodes {
+		return nil, fmt.Errorf("max_nodes must be between 1 and %d", maxGraphNodes)
+	}
+
+	if err := s.requireWeaviateDatabase(ctx, "nearest_neighbors_graph"); err != nil {
+		return nil, err
+	}
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	root, err := client.GetDocument(timeoutCtx, collection, documentID)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get document", err)
+	}
+
+	nodes := []map[string]interface{}{graphNodeEntry(root.ID, root.Content, root.Metadata, 0)}
+	edges := []map[string]interface{}{}
+	visited := map[string]bool{root.ID: true}
+	linked := map[[2]string]bool{}
+	truncated := false
+
+	// Expand breadth-first so the nodes closest to the start are kept when
+	// max_nodes is reached
+	queue := []graphNode{{id: root.ID, depth: 0}}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.depth >= depth {
+			continue
+		}
+
+		neighbors, err := client.QueryNearObject(timeoutCtx, collection, node.id, breadth)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to find nearest neighbors", err)
+		}
+
+		for _, neighbor := range neighbors {
+			if !visited[neighbor.ID] {
+				if len(nodes) >= maxNodes {
+					truncated = true
+					continue
+				}
+				visited[neighbor.ID] = true
+				nodes = append(nodes, graphNodeEntry(neighbor.ID, neighbor.Content, neighbor.Metadata, node.depth+1))
+				queue = append(queue, graphNode{id: neighbor.ID, depth: node.depth + 1})
+			}
+
+			// Similarity is symmetric, so keep one edge per pair of documents
+			pair := [2]string{node.id, neighbor.ID}
+			if neighbor.ID < node.id {
+				pair = [2]string{neighbor.ID, node.id}
+			}
+			if linked[pair] {
+				continue
+			}
+			linked[pair] = true
+			edges = append(edges, map[string]interface{}{
+				"source": node.id,
+				"target": neighbor.ID,
+				"score":  neighbor.Score,
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"collection":  collection,
+		"document_id": documentID,
+		"depth":       depth,
+		"breadth":     breadth,
+		"nodes":       nodes,
+		"edges":       edges,
+		"node_count":  len(nodes),
+		"edge_count":  len(edges),
+		"truncated":   truncated,
+	}, nil
+}
+
+// graphNodeEntry describes a graph node with a content excerpt rather than
+// the whole document, keeping large graphs readable
+func graphNodeEntry(id, content string, metadata map[string]interface{}, depth int) map[string]interface{} {
+	excerpt, _ := truncateRunes(content, defaultPreviewLength)
+	entry := map[string]interface{}{
+		"id":      id,
+		"depth":   depth,
+		"excerpt": excerpt,
+	}
+	for _, key := range previewMetadataKeys {
+		if value, exists := metadata[key]; exists {
+			entry[key] = value
+		}
+	}
+	return entry
+}
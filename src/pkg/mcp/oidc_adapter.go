@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"sync"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+	"go.uber.org/zap"
+)
+
+// oidcAdapter is a vector database adapter authenticated with an OIDC access
+// token. The weave-cli adapter only accepts a static bearer key, so the
+// adapter is rebuilt once the shared token manager has refreshed the token.
+type oidcAdapter struct {
+	vectordb.VectorDBClient
+	config *config.VectorDBConfig
+	token  string                 // access token the adapter was created with
+	tokens func() (string, error) // current access token
+
+	mu          sync.Mutex
+	replacement *oidcAdapter // adapter rebuilt with a newer token, guarded by mu
+}
+
+// adapterCredential returns the bearer credential for the vector database
+// adapter and, in oidc mode, the source of current access tokens
+func (s *Server) adapterCredential(dbConfig *config.VectorDBConfig) (string, func() (string, error), error) {
+	if dbConfig.Type != config.VectorDBTypeCloud && dbConfig.Type != config.VectorDBTypeLocal {
+		return dbConfig.APIKey, nil, nil
+	}
+
+	clientConfig, err := weaviateClientConfig(dbConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch clientConfig.AuthMode {
+	case config.AuthModeNone:
+		return "", nil, nil
+	case config.AuthModeOIDC:
+		client, err := weaviate.NewClient(clientConfig)
+		if err != nil {
+			return "", nil, err
+		}
+		token, err := client.AccessToken()
+		if err != nil {
+			return "", nil, err
+		}
+		return token, client.AccessToken, nil
+	}
+	return dbConfig.APIKey, nil, nil
+}
+
+// currentAdapter returns an adapter holding the current access token,
+// rebuilding it when the token was refreshed and replacing the cached
+// client. When the token or the rebuild fails, the existing adapter is kept
+// so the call reports the database's own error.
+func (s *Server) currentAdapter(adapter *oidcAdapter) vectordb.VectorDBClient {
+	token, err := adapter.tokens()
+	if err != nil || token == adapter.token {
+		return adapter
+	}
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if adapter.replacement == nil || adapter.replacement.token != token {
+		client, err := newAdapter(adapter.config, token)
+		if err != nil {
+			s.logger.Warn("Failed to rebuild the vector database adapter with a refreshed OIDC token",
+				zap.String("name", adapter.config.Name),
+				zap.Error(err))
+			return adapter
+		}
+		adapter.replacement = &oidcAdapter{VectorDBClient: client, config: adapter.config, token: token, tokens: adapter.tokens}
+	}
+	replacement := adapter.replacement
+
+	s.mu.Lock()
+	if s.dbClient == vectordb.VectorDBClient(adapter) {
+		s.dbClient = replacement
+	}
+	if s.dbClients[adapter.config.Name] == vectordb.VectorDBClient(adapter) {
+		s.dbClients[adapter.config.Name] = replacement
+	}
+	s.mu.Unlock()
+	return replacement
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/config"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// definitionSchema is a collection schema translated from a named schema
+// definition, with the Weaviate-only settings kept separately
+type definitionSchema struct {
+	schema       *vectordb.CollectionSchema
+	indexConfig  *weaviate.VectorIndexConfig
+	tokenization map[string]string
+}
+
+// schemaFromDefinition validates a schema definition and translates it into
+// a collection schema. className overrides the class in the definition.
+func schemaFromDefinition(def *config.SchemaDefinition, className string) (*definitionSchema, error) {
+	if len(def.Schema) == 0 {
+		return nil, fmt.Errorf("schema '%s' has no schema section", def.Name)
+	}
+
+	if className == "" {
+		class, ok := def.Schema["class"].(string)
+		if !ok || class == "" {
+			return nil, fmt.Errorf("schema '%s' has no class; pass collection_name", def.Name)
+		}
+		className = class
+	}
+
+	vectorizer := defaultVectorizer
+	if raw, exists := def.Schema["vectorizer"]; exists {
+		v, ok := raw.(string)
+		if !ok || v == "" {
+			return nil, fmt.Errorf("schema '%s': vectorizer must be a non-empty string", def.Name)
+		}
+		vectorizer = v
+	}
+
+	rawProperties, ok := def.Schema["properties"].([]interface{})
+	if !ok || len(rawProperties) == 0 {
+		return nil, fmt.Errorf("schema '%s' must define at least one property", def.Name)
+	}
+
+	result := &definitionSchema{
+		schema: &vectordb.CollectionSchema{
+			Class:      className,
+			Vectorizer: vectorizer,
+		},
+	}
+
+	properties, err := propertiesFromDefinition(def.Name, rawProperties, result)
+	if err != nil {
+		return nil, err
+	}
+	result.schema.Properties = properties
+
+	if raw, exists := def.Schema["vectorIndexConfig"]; exists {
+		rawIndexConfig, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema '%s': vectorIndexConfig must be an object", def.Name)
+		}
+		indexConfig, err := weaviate.ParseVectorIndexConfig(rawIndexConfig)
+		if err != nil {
+			return nil, fmt.Errorf("schema '%s': %w", def.Name, err)
+		}
+		result.indexConfig = indexConfig
+	}
+
+	return result, nil
+}
+
+// propertiesFromDefinition validates and converts schema definition
+// properties. Tokenization of top-level properties is collected in result.
+func propertiesFromDefinition(schemaName string, rawProperties []interface{}, result *definitionSchema) ([]vectordb.SchemaProperty, error) {
+	properties := make([]vectordb.SchemaProperty, 0, len(rawProperties))
+	seen := make(map[string]bool, len(rawProperties))
+
+	for i, raw := range rawProperties {
+		values, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema '%s': property %d must be an object", schemaName, i)
+		}
+
+		name, _ := values["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("schema '%s': property %d has no name", schemaName, i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("schema '%s': duplicate property '%s'", schemaName, name)
+		}
+		seen[name] = true
+
+		// Schema files use lowercase keys (datatype); accept Weaviate's dataType too
+		rawDataType, exists := values["datatype"]
+		if !exists {
+			rawDataType = values["dataType"]
+		}
+		dataType, err := stringList(rawDataType)
+		if err != nil || len(dataType) == 0 {
+			return nil, fmt.Errorf("schema '%s': property '%s' must have a datatype list", schemaName, name)
+		}
+
+		property := vectordb.SchemaProperty{Name: name, DataType: dataType}
+		if description, ok := values["description"].(string); ok {
+			property.Description = description
+		}
+		if jsonSchema, ok := values["json_schema"].(map[string]interface{}); ok {
+			property.JSONSchema = jsonSchema
+		}
+
+		rawNested, exists := values["nestedproperties"]
+		if !exists {
+			rawNested, exists = values["nestedProperties"]
+		}
+		if exists {
+			nested, ok := rawNested.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("schema '%s': property '%s' nested properties must be a list", schemaName, name)
+			}
+			property.NestedProperties, err = propertiesFromDefinition(schemaName, nested, &definitionSchema{})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if rawTokenization, exists := values["tokenization"]; exists {
+			tokenization, ok := rawTokenization.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema '%s': property '%s' tokenization must be a string", schemaName, name)
+			}
+			normalized, err := weaviate.ValidateTokenization(tokenization)
+			if err != nil {
+				return nil, fmt.Errorf("schema '%s': property '%s': %w", schemaName, name, err)
+			}
+			if result.tokenization == nil {
+				result.tokenization = make(map[string]string)
+			}
+			result.tokenization[name] = normalized
+		}
+
+		properties = append(properties, property)
+	}
+
+	return properties, nil
+}
+
+// stringList converts a YAML/JSON list of strings
+func stringList(raw interface{}) ([]string, error) {
+	switch v := raw.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings")
+			}
+			values[i] = str
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("expected a list of strings")
+}
+
+// handleCreateCollectionFromSchemaFile creates a collection from a named
+// schema definition in config.yaml or the schemas directory
+func (s *Server) handleCreateCollectionFromSchemaFile(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	schemaName, ok := args["schema_name"].(string)
+	if !ok || schemaName == "" {
+		return nil, fmt.Errorf("schema_name is required")
+	}
+
+	collectionName, _ := args["collection_name"].(string)
+
+	def, err := s.config.GetSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	translated, err := schemaFromDefinition(def, collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	useWeaviate := translated.indexConfig != nil || translated.tokenization != nil
+	if useWeaviate {
+		if err := s.requireWeaviateDatabase(ctx, "vectorIndexConfig and tokenization in schema definitions"); err != nil {
+			return nil, err
+		}
+	}
+
+	schema := translated.schema
+
+	// Return the resolved schema without creating anything
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		return map[string]interface{}{
+			"name":              schema.Class,
+			"schema":            schemaName,
+			"vectorizer":        schema.Vectorizer,
+			"collection_schema": weaviateCollectionSchema(schema, translated.indexConfig, translated.tokenization),
+			"dry_run":           true,
+			"status":            "preview",
+		}, nil
+	}
+
+	// Create context with collection operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeCollection)
+	defer cancel()
+
+	if useWeaviate {
+		err = s.createWeaviateCollection(timeoutCtx, schema, translated.indexConfig, translated.tokenization)
+	} else {
+		err = s.db(ctx).CreateCollection(timeoutCtx, schema.Class, schema)
+	}
+	s.invalidateCollectionCaches(ctx, schema.Class)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to create collection", err)
+	}
+
+	properties := make([]string, len(schema.Properties))
+	for i, prop := range schema.Properties {
+		properties[i] = prop.Name
+	}
+
+	return map[string]interface{}{
+		"name":       schema.Class,
+		"schema":     schemaName,
+		"vectorizer": schema.Vectorizer,
+		"properties": properties,
+		"status":     "created",
+	}, nil
+}
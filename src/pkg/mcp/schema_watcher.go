@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// schemaWatchDebounce coalesces bursts of file events (editors often write
+// a file several times on save) into a single reload
+const schemaWatchDebounce = 250 * time.Millisecond
+
+// schemaWatcher reloads schema definitions when files in the schemas
+// directory change
+type schemaWatcher struct {
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	once    sync.Once
+}
+
+// isSchemaFile reports whether a path is a schema YAML file
+func isSchemaFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// startSchemaWatcher watches the schemas directory and reloads schemas on
+// change. Config precedence is preserved by Config.ReloadSchemas.
+func (s *Server) startSchemaWatcher() (*schemaWatcher, error) {
+	dir := s.config.SchemasDir
+	if dir == "" {
+		return nil, fmt.Errorf("watch_schemas requires schemas_dir to be set")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema watcher: %w", err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch schemas directory %s: %w", dir, err)
+	}
+
+	sw := &schemaWatcher{watcher: watcher, done: make(chan struct{})}
+	go sw.run(s)
+
+	s.logger.Info("Watching schemas directory for changes", zap.String("dir", dir))
+	return sw, nil
+}
+
+// run processes file events until the watcher is closed
+func (sw *schemaWatcher) run(s *Server) {
+	var (
+		timer   *time.Timer
+		mu      sync.Mutex
+		changed []string
+	)
+
+	reload := func() {
+		mu.Lock()
+		files := changed
+		changed = nil
+		mu.Unlock()
+
+		names, err := s.config.ReloadSchemas()
+		if err != nil {
+			s.logger.Warn("Failed to reload schemas after change",
+				zap.Strings("files", files),
+				zap.Error(err))
+			return
+		}
+		s.logger.Info("Reloaded schemas after change",
+			zap.Strings("files", files),
+			zap.Strings("schemas", names))
+	}
+
+	for {
+		select {
+		case event, ok := <-sw.watcher.Events:
+			if !ok {
+				return
+			}
+			if !isSchemaFile(event.Name) || event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			mu.Lock()
+			changed = append(changed, filepath.Base(event.Name))
+			mu.Unlock()
+
+			if timer == nil {
+				timer = time.AfterFunc(schemaWatchDebounce, reload)
+			} else {
+				timer.Reset(schemaWatchDebounce)
+			}
+		case err, ok := <-sw.watcher.Errors:
+			if !ok {
+				return
+			}
+			s.logger.Warn("Schema watcher error", zap.Error(err))
+		case <-sw.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// Close stops watching the schemas directory
+func (sw *schemaWatcher) Close() error {
+	var err error
+	sw.once.Do(func() {
+		close(sw.done)
+		err = sw.watcher.Close()
+	})
+	return err
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	// corsRejectionsTotal counts requests whose Origin is not allowed
+	corsRejectionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "weave_mcp_cors_rejections_total",
+			Help: "Total number of requests from origins not in allowed_origins",
+		},
+	)
+
+	// authFailuresTotal counts tool calls the database rejected for credentials
+	authFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "weave_mcp_auth_failures_total",
+			Help: "Total number of tool calls rejected by the database for invalid credentials",
+		},
+		[]string{"database"},
+	)
+)
+
+// securityEvents counts rejected origins and failed authentications for
+// /stats; the Prometheus counters above carry the same events
+type securityEvents struct {
+	corsRejections atomic.Int64
+	authFailures   atomic.Int64
+}
+
+// snapshot returns a JSON-friendly view of the counters. Streaming
+// connections refused by max_sse_connections are counted by the stream
+// tracker and passed in.
+func (e *securityEvents) snapshot(streamRejections int64) map[string]interface{} {
+	return map[string]interface{}{
+		"cors_rejections":   e.corsRejections.Load(),
+		"auth_failures":     e.authFailures.Load(),
+		"stream_rejections": streamRejections,
+	}
+}
+
+// clientIP returns the host part of the request's remote address
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// recordCORSRejection counts and logs a request from a disallowed origin
+func (s *Server) recordCORSRejection(r *http.Request, origin string) {
+	s.security.corsRejections.Add(1)
+	corsRejectionsTotal.Inc()
+	s.logger.Warn("Rejected CORS origin",
+		zap.String("origin", origin),
+		zap.String("remote_ip", clientIP(r)),
+		zap.String("path", r.URL.Path))
+}
+
+// recordAuthFailure counts and logs a tool call the database rejected for
+// its credentials. The error is not logged, as some databases echo the
+// offending API key back in it.
+func (s *Server) recordAuthFailure(ctx context.Context, tool string) {
+	database := "unknown"
+	if dbConfig, err := s.databaseConfig(ctx); err == nil && dbConfig != nil {
+		database = dbConfig.Name
+	}
+	s.security.authFailures.Add(1)
+	authFailuresTotal.WithLabelValues(database).Inc()
+	s.logger.Warn("Database rejected credentials",
+		zap.String("tool", tool),
+		zap.String("database", database))
+}
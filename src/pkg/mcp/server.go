@@ -4,10 +4,15 @@
 package mcp
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +21,7 @@ import (
 	"github.com/maximilien/weave-mcp/src/pkg/config"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 // Server represents the MCP server implementation
@@ -26,6 +32,34 @@ type Server struct {
 	corsConfig *CORSConfig
 	mu         sync.RWMutex
 	Tools      map[string]Tool
+
+	// dbClients caches clients of non-default databases selected with the
+	// database tool argument, guarded by mu
+	dbClients map[string]vectordb.VectorDBClient
+
+	// collectionLocks serializes destructive operations per collection (in-process only)
+	collectionLocks collectionLocks
+
+	// inflight coalesces concurrent identical count and schema requests
+	inflight singleflight.Group
+
+	// toolStats tracks in-memory tool usage counters exposed via GET /stats
+	toolStats toolStats
+
+	// jobs tracks async operations queried via get_job_status and GET /mcp/jobs/events
+	jobs jobRegistry
+
+	// streams counts open job event streams and exports, capped by max_sse_connections
+	streams streamTracker
+
+	// calls counts in-flight tool calls and async jobs, awaited by Drain
+	calls callTracker
+
+	// security counts rejected CORS origins and database auth failures
+	security securityEvents
+
+	// schemaWatcher reloads schemas on file changes when watch_schemas is enabled
+	schemaWatcher *schemaWatcher
 }
 
 // Tool represents an MCP tool
@@ -33,6 +67,7 @@ type Tool struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
+	Examples    []ToolExample          `json:"examples,omitempty"` // Defaults to toolExamples[Name]
 	Handler     func(ctx context.Context, args map[string]interface{}) (interface{}, error)
 }
 
@@ -41,8 +76,9 @@ func NewServer(cfg *config.Config, logger *zap.Logger) (*Server, error) {
 	server := &Server{
 		config:     cfg,
 		logger:     logger,
-		corsConfig: DefaultCORSConfig(),
+		corsConfig: CORSConfigFromConfig(cfg.CORS),
 		Tools:      make(map[string]Tool),
+		toolStats:  toolStats{startedAt: time.Now()},
 	}
 
 	// Initialize vector database client
@@ -53,6 +89,17 @@ func NewServer(cfg *config.Config, logger *zap.Logger) (*Server, error) {
 	// Register tools
 	server.registerTools()
 
+	// Optionally reload schemas when files in the schemas directory change
+	if cfg.WatchSchemas {
+		watcher, err := server.startSchemaWatcher()
+		if err != nil {
+			// Don't fail - schemas can still be reloaded with reload_schemas
+			logger.Warn("Failed to start schema watcher", zap.Error(err))
+		} else {
+			server.schemaWatcher = watcher
+		}
+	}
+
 	return server, nil
 }
 
@@ -71,24 +118,9 @@ func (s *Server) initializeVectorDB() error {
 		return fmt.Errorf("failed to get default database: %w", err)
 	}
 
-	// Convert to vectordb.Config
-	vdbConfig := &vectordb.Config{
-		Type:               vectordb.VectorDBType(dbConfig.Type),
-		URL:                dbConfig.URL,
-		APIKey:             dbConfig.APIKey,
-		OpenAIAPIKey:       dbConfig.OpenAIAPIKey,
-		DatabaseURL:        dbConfig.DatabaseURL,
-		DatabaseKey:        dbConfig.DatabaseKey,
-		Timeout:            dbConfig.Timeout,
-		Enabled:            dbConfig.Enabled,
-		SimulateEmbeddings: dbConfig.SimulateEmbeddings,
-		EmbeddingDimension: dbConfig.EmbeddingDimension,
-	}
-
-	// Create vector database client using factory pattern
-	client, err := vectordb.CreateClient(vdbConfig)
+	client, err := s.createVectorDBClient(dbConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create vector database client: %w", err)
+		return err
 	}
 
 	// Test the connection with a health check
@@ -108,6 +140,49 @@ func (s *Server) initializeVectorDB() error {
 	return nil
 }
 
+// createVectorDBClient creates a vector database client for a database
+// configuration. In oidc mode the adapter is wrapped so db can rebuild it
+// when the access token is refreshed.
+func (s *Server) createVectorDBClient(dbConfig *config.VectorDBConfig) (vectordb.VectorDBClient, error) {
+	apiKey, tokens, err := s.adapterCredential(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newAdapter(dbConfig, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if tokens != nil {
+		return &oidcAdapter{VectorDBClient: client, config: dbConfig, token: apiKey, tokens: tokens}, nil
+	}
+	return client, nil
+}
+
+// newAdapter creates the weave-cli adapter of a database with a bearer credential
+func newAdapter(dbConfig *config.VectorDBConfig, apiKey string) (vectordb.VectorDBClient, error) {
+	// Convert to vectordb.Config
+	vdbConfig := &vectordb.Config{
+		Type:               vectordb.VectorDBType(dbConfig.Type),
+		URL:                dbConfig.URL,
+		APIKey:             apiKey,
+		OpenAIAPIKey:       dbConfig.OpenAIAPIKey,
+		DatabaseURL:        dbConfig.DatabaseURL,
+		DatabaseKey:        dbConfig.DatabaseKey,
+		Timeout:            dbConfig.Timeout,
+		Enabled:            dbConfig.Enabled,
+		SimulateEmbeddings: dbConfig.SimulateEmbeddings,
+		EmbeddingDimension: dbConfig.EmbeddingDimension,
+	}
+
+	// Create vector database client using factory pattern
+	client, err := vectordb.CreateClient(vdbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vector database client: %w", err)
+	}
+	return client, nil
+}
+
 // CORSConfig represents CORS configuration
 type CORSConfig struct {
 	AllowedOrigins []string
@@ -126,6 +201,25 @@ func DefaultCORSConfig() *CORSConfig {
 	}
 }
 
+// CORSConfigFromConfig returns the default CORS configuration with the
+// fields set in the config file's cors section applied
+func CORSConfigFromConfig(cfg config.CORSConfig) *CORSConfig {
+	cors := DefaultCORSConfig()
+	if len(cfg.AllowedOrigins) > 0 {
+		cors.AllowedOrigins = cfg.AllowedOrigins
+	}
+	if len(cfg.AllowedMethods) > 0 {
+		cors.AllowedMethods = cfg.AllowedMethods
+	}
+	if len(cfg.AllowedHeaders) > 0 {
+		cors.AllowedHeaders = cfg.AllowedHeaders
+	}
+	if cfg.MaxAge > 0 {
+		cors.MaxAge = cfg.MaxAge
+	}
+	return cors
+}
+
 // corsMiddleware creates a CORS middleware
 func (s *Server) corsMiddleware(config *CORSConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -136,6 +230,8 @@ func (s *Server) corsMiddleware(config *CORSConfig) func(http.Handler) http.Hand
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 			} else if len(config.AllowedOrigins) > 0 && config.AllowedOrigins[0] == "*" {
 				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else if origin != "" {
+				s.recordCORSRejection(r, origin)
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
@@ -165,16 +261,49 @@ func (s *Server) isOriginAllowed(origin string, allowedOrigins []string) bool {
 			return true
 		}
 		// Support wildcard subdomains like *.example.com
-		if strings.HasPrefix(allowed, "*.") {
-			domain := strings.TrimPrefix(allowed, "*.")
-			if strings.HasSuffix(origin, domain) {
-				return true
-			}
+		if strings.Contains(allowed, "*.") && originMatchesWildcard(origin, allowed) {
+			return true
 		}
 	}
 	return false
 }
 
+// originMatchesWildcard matches an origin against a wildcard subdomain
+// pattern such as *.example.com, https://*.example.com, or
+// *.example.com:3000. The origin's host must be a subdomain of the
+// pattern's domain, so evilexample.com and example.com itself do not match.
+// A scheme or port in the pattern must match the origin's; without one any
+// scheme or port is allowed.
+func originMatchesWildcard(origin, pattern string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return false
+	}
+
+	scheme, hostPattern, hasScheme := strings.Cut(pattern, "://")
+	if !hasScheme {
+		scheme, hostPattern = "", pattern
+	}
+	if scheme != "" && !strings.EqualFold(scheme, parsed.Scheme) {
+		return false
+	}
+
+	domain, ok := strings.CutPrefix(hostPattern, "*.")
+	if !ok || domain == "" {
+		return false
+	}
+	if host, port, err := net.SplitHostPort(domain); err == nil {
+		if port != parsed.Port() {
+			return false
+		}
+		domain = host
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	suffix := "." + strings.ToLower(domain)
+	return len(host) > len(suffix) && strings.HasSuffix(host, suffix)
+}
+
 // Handler returns the HTTP handler for the MCP server
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
@@ -182,12 +311,21 @@ func (s *Server) Handler() http.Handler {
 	// Health check endpoint
 	mux.HandleFunc("/health", s.handleHealth)
 
-	// Metrics endpoint (Prometheus format)
-	mux.Handle("/metrics", promhttp.Handler())
+	// Metrics endpoint (Prometheus format), opt-in via metrics.enabled
+	if s.metricsEnabled() {
+		mux.Handle("/metrics", promhttp.Handler())
+	}
+
+	// Tool usage stats endpoint (in-memory, reset on restart)
+	mux.HandleFunc("/stats", s.handleStats)
 
 	// MCP endpoints
 	mux.HandleFunc("/mcp/tools/list", s.handleToolsList)
 	mux.HandleFunc("/mcp/tools/call", s.handleToolCall)
+	mux.HandleFunc("/mcp/tools/describe", s.handleToolDescribe)
+	mux.HandleFunc("/mcp/jobs/events", s.handleJobEvents)
+	mux.HandleFunc("/mcp/import", s.handleImport)
+	mux.HandleFunc("/mcp/export", s.handleExport)
 
 	// Apply CORS middleware with configured settings
 	s.mu.RLock()
@@ -197,6 +335,11 @@ func (s *Server) Handler() http.Handler {
 	return s.corsMiddleware(corsConfig)(mux)
 }
 
+// metricsEnabled reports whether the Prometheus /metrics endpoint is served
+func (s *Server) metricsEnabled() bool {
+	return s.config != nil && s.config.Metrics.Enabled
+}
+
 // MetricsHandler returns a standalone metrics HTTP handler for :9091
 func (s *Server) MetricsHandler() http.Handler {
 	mux := http.NewServeMux()
@@ -228,8 +371,14 @@ func (s *Server) registerTools() {
 		Name:        "list_collections",
 		Description: "List all collections in the vector database",
 		InputSchema: map[string]interface{}{
-			"type":       "object",
-			"properties": map[string]interface{}{},
+			"type": "object",
+			"properties": map[string]interface{}{
+				"include_details": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return each collection's document count and vectorizer instead of just its name (default: false)",
+					"default":     false,
+				},
+			},
 		},
 		Handler: s.withMetrics("list_collections", s.handleListCollections),
 	})
@@ -246,9 +395,13 @@ func (s *Server) registerTools() {
 				},
 				"type": map[string]interface{}{
 					"type":        "string",
-					"description": "Type of collection (text or image)",
+					"description": "Type of collection (text or image); required unless the template sets it",
 					"enum":        []string{"text", "image"},
 				},
+				"template": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional collection template (see list_collection_templates) supplying the parameters this call does not set",
+				},
 				"description": map[string]interface{}{
 					"type":        "string",
 					"description": "Description of the collection",
@@ -258,12 +411,150 @@ func (s *Server) registerTools() {
 					"description": "Embedding model/vectorizer to use (e.g., text2vec-openai, text-embedding-3-small, text-embedding-ada-002)",
 					"default":     "text2vec-openai",
 				},
+				"vector_index_config": map[string]interface{}{
+					"type":        "object",
+					"description": "Optional HNSW vector index settings (Weaviate only)",
+					"properties": map[string]interface{}{
+						"distance": map[string]interface{}{
+							"type":        "string",
+							"description": "Distance metric",
+							"enum":        []string{"cosine", "dot", "l2-squared", "hamming", "manhattan"},
+						},
+						"efConstruction": map[string]interface{}{
+							"type":        "integer",
+							"description": "Size of the dynamic list used while building the index",
+						},
+						"maxConnections": map[string]interface{}{
+							"type":        "integer",
+							"description": "Maximum number of connections per node",
+						},
+						"ef": map[string]interface{}{
+							"type":        "integer",
+							"description": "Size of the dynamic list used during search (-1 for dynamic)",
+						},
+					},
+				},
+				"tokenization": map[string]interface{}{
+					"type":        "object",
+					"description": "Optional tokenization per text property, e.g. {\"url\": \"field\"} (Weaviate only). Values: word, lowercase, whitespace, field",
+					"additionalProperties": map[string]interface{}{
+						"type": "string",
+						"enum": []string{"word", "lowercase", "whitespace", "field"},
+					},
+				},
+				"properties": map[string]interface{}{
+					"type":        "array",
+					"description": "Optional custom properties added to the default text, url, and metadata properties",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"name": map[string]interface{}{
+								"type":        "string",
+								"description": "Property name",
+							},
+							"data_type": map[string]interface{}{
+								"type":        "string",
+								"description": "Property data type",
+								"enum":        []string{"text", "string", "int", "number", "boolean", "date", "object"},
+							},
+							"description": map[string]interface{}{
+								"type":        "string",
+								"description": "Property description",
+							},
+						},
+						"required": []string{"name", "data_type"},
+					},
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return the resolved schema without creating the collection",
+					"default":     false,
+				},
 			},
-			"required": []string{"name", "type"},
+			"required": []string{"name"},
 		},
 		Handler: s.withMetrics("create_collection", s.handleCreateCollection),
 	})
 
+	s.registerTool(Tool{
+		Name:        "save_collection_template",
+		Description: "Save a named preset of create_collection parameters (type, vectorizer, vector index config, properties, tokenization) for use with create_collection's template argument. Saved templates last until the server restarts",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the template; saving an existing name replaces it",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Type of collection (text or image)",
+					"enum":        []string{"text", "image"},
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "Description of the collections created from the template",
+				},
+				"vectorizer": map[string]interface{}{
+					"type":        "string",
+					"description": "Embedding model/vectorizer to use",
+				},
+				"vector_index_config": map[string]interface{}{
+					"type":        "object",
+					"description": "HNSW vector index settings, as for create_collection (Weaviate only)",
+				},
+				"properties": map[string]interface{}{
+					"type":        "array",
+					"description": "Custom {name, data_type, description} properties, as for create_collection",
+					"items": map[string]interface{}{
+						"type": "object",
+					},
+				},
+				"tokenization": map[string]interface{}{
+					"type":        "object",
+					"description": "Tokenization per text property, as for create_collection (Weaviate only)",
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: s.handleSaveCollectionTemplate,
+	})
+
+	s.registerTool(Tool{
+		Name:        "list_collection_templates",
+		Description: "List the collection templates from config.yaml and save_collection_template with their parameters",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleListCollectionTemplates,
+	})
+
+	s.registerTool(Tool{
+		Name:        "create_collection_from_schema_file",
+		Description: "Create a collection from a named schema definition in config.yaml or the schemas directory",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"schema_name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the schema definition (see reload_schemas for available names)",
+				},
+				"collection_name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection to create (default: the schema's class)",
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return the resolved schema without creating the collection",
+					"default":     false,
+				},
+			},
+			"required": []string{"schema_name"},
+		},
+		Handler: s.handleCreateCollectionFromSchemaFile,
+	})
+
 	s.registerTool(Tool{
 		Name:        "delete_collection",
 		Description: "Delete a collection from the vector database",
@@ -274,6 +565,11 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "Name of the collection to delete",
 				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report whether the collection exists and its document count without deleting it",
+					"default":     false,
+				},
 			},
 			"required": []string{"name"},
 		},
@@ -293,9 +589,19 @@ func (s *Server) registerTools() {
 				},
 				"limit": map[string]interface{}{
 					"type":        "integer",
-					"description": "Maximum number of documents to return",
+					"description": "Maximum number of documents to return (0 returns only the document count)",
 					"default":     10,
 				},
+				"offset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of documents to skip; pass next_offset from the previous page to continue",
+					"default":     0,
+				},
+				"include_total_count": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include the collection's total document count as total_count (default: true)",
+					"default":     true,
+				},
 			},
 			"required": []string{"collection"},
 		},
@@ -325,12 +631,52 @@ func (s *Server) registerTools() {
 					"description": "Additional metadata for the document",
 					"default":     map[string]interface{}{},
 				},
+				"text_field": map[string]interface{}{
+					"type":        "string",
+					"description": "Text property that receives the text, such as the one the vectorizer indexes (Weaviate only; default: both text and content)",
+				},
+				"properties": map[string]interface{}{
+					"type":        "object",
+					"description": "Custom top-level properties to set, validated against the collection schema (Weaviate only)",
+				},
 			},
 			"required": []string{"collection", "url", "text"},
 		},
 		Handler: s.handleCreateDocument,
 	})
 
+	s.registerTool(Tool{
+		Name:        "validate_document",
+		Description: "Check a document against the collection schema without creating it",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "URL of the document",
+				},
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": "Text content of the document",
+				},
+				"metadata": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata as passed to create_document",
+				},
+				"properties": map[string]interface{}{
+					"type":        "object",
+					"description": "Property values to check directly against the schema (e.g. {\"year\": 2024})",
+				},
+			},
+			"required": []string{"collection"},
+		},
+		Handler: s.handleValidateDocument,
+	})
+
 	s.registerTool(Tool{
 		Name:        "batch_create_documents",
 		Description: "Create multiple documents in a collection in a single batch operation",
@@ -343,7 +689,7 @@ func (s *Server) registerTools() {
 				},
 				"documents": map[string]interface{}{
 					"type":        "array",
-					"description": "Array of documents to create",
+					"description": "Array of documents to create (at most max_batch_size, default 1000)",
 					"items": map[string]interface{}{
 						"type": "object",
 						"properties": map[string]interface{}{
@@ -384,6 +730,16 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "ID of the document to retrieve",
 				},
+				"include_vector": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also return the document's embedding vector, which is large (Weaviate only, default: false)",
+					"default":     false,
+				},
+				"include_vectors": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Alias of include_vector",
+					"default":     false,
+				},
 			},
 			"required": []string{"collection", "document_id"},
 		},
@@ -391,8 +747,8 @@ func (s *Server) registerTools() {
 	})
 
 	s.registerTool(Tool{
-		Name:        "delete_document",
-		Description: "Delete a document from a collection",
+		Name:        "get_full_document",
+		Description: "Reassemble a chunked document from any of its chunk IDs or its source, ordered by chunk_index",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -402,17 +758,21 @@ func (s *Server) registerTools() {
 				},
 				"document_id": map[string]interface{}{
 					"type":        "string",
-					"description": "ID of the document to delete",
+					"description": "ID of any chunk of the document",
+				},
+				"source": map[string]interface{}{
+					"type":        "string",
+					"description": "source_document metadata value shared by the chunks (instead of document_id)",
 				},
 			},
-			"required": []string{"collection", "document_id"},
+			"required": []string{"collection"},
 		},
-		Handler: s.handleDeleteDocument,
+		Handler: s.handleGetFullDocument,
 	})
 
 	s.registerTool(Tool{
-		Name:        "count_documents",
-		Description: "Count documents in a collection",
+		Name:        "preview_document",
+		Description: "Get a short content excerpt and key metadata for a document, excluding large fields",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -420,15 +780,23 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "Name of the collection",
 				},
+				"document_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the document to preview",
+				},
+				"length": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of content characters to return (default: 200)",
+				},
 			},
-			"required": []string{"collection"},
+			"required": []string{"collection", "document_id"},
 		},
-		Handler: s.handleCountDocuments,
+		Handler: s.handlePreviewDocument,
 	})
 
 	s.registerTool(Tool{
-		Name:        "update_document",
-		Description: "Update a document's content or metadata",
+		Name:        "delete_document",
+		Description: "Delete a document from a collection",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -438,27 +806,22 @@ func (s *Server) registerTools() {
 				},
 				"document_id": map[string]interface{}{
 					"type":        "string",
-					"description": "ID of the document to update",
-				},
-				"content": map[string]interface{}{
-					"type":        "string",
-					"description": "New content for the document (optional)",
+					"description": "ID of the document to delete",
 				},
-				"metadata": map[string]interface{}{
-					"type":        "object",
-					"description": "Metadata fields to update (optional)",
-					"default":     map[string]interface{}{},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Check that the document exists without deleting it",
+					"default":     false,
 				},
 			},
 			"required": []string{"collection", "document_id"},
 		},
-		Handler: s.handleUpdateDocument,
+		Handler: s.handleDeleteDocument,
 	})
 
-	// Query tools
 	s.registerTool(Tool{
-		Name:        "query_documents",
-		Description: "Query documents using semantic search",
+		Name:        "delete_documents",
+		Description: "Delete multiple documents from a collection by ID, reporting per-document success or failure",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -466,21 +829,467 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "Name of the collection",
 				},
-				"query": map[string]interface{}{
-					"type":        "string",
-					"description": "Search query",
+				"document_ids": map[string]interface{}{
+					"type":        "array",
+					"description": "IDs of the documents to delete (max 1000)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
 				},
-				"limit": map[string]interface{}{
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report which documents exist and would be deleted without deleting them",
+					"default":     false,
+				},
+			},
+			"required": []string{"collection", "document_ids"},
+		},
+		Handler: s.handleDeleteDocuments,
+	})
+
+	s.registerTool(Tool{
+		Name:        "delete_documents_by_query",
+		Description: "Delete documents matching a search query above a score threshold (dry run by default)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query used to find documents to delete",
+				},
+				"search_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Search mode used to match documents",
+					"enum":        []string{"semantic", "bm25"},
+					"default":     "semantic",
+				},
+				"threshold": map[string]interface{}{
+					"type":        "number",
+					"description": "Minimum score a match must have to be deleted: in (0, 1] for semantic, above 0 for bm25. Required when dry_run is false; dry runs without it list every match",
+				},
+				"limit": map[string]interface{}{
 					"type":        "integer",
-					"description": "Maximum number of results to return",
+					"description": "Maximum number of documents to match (max 1000)",
+					"default":     100,
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only return the documents that would be deleted",
+					"default":     true,
+				},
+			},
+			"required": []string{"collection", "query"},
+		},
+		Handler: s.handleDeleteDocumentsByQuery,
+	})
+
+	s.registerTool(Tool{
+		Name:        "bulk_update_metadata",
+		Description: "Apply a metadata patch to all documents matching a metadata filter",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"filters": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata key/value pairs documents must match (e.g. {\"type\": \"pdf\"}), or on Weaviate a nested {\"operator\": \"And|Or|Not\", \"operands\": [...]} expression of filter strings (e.g. {\"operator\": \"Or\", \"operands\": [\"type=pdf\", \"type=doc\"]})",
+				},
+				"metadata": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata fields to set on every matching document",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of documents to update (max 1000)",
+					"default":     100,
+				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only return the documents that would be updated",
+					"default":     false,
+				},
+				"async": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Run in the background and return a job_id for get_job_status",
+					"default":     false,
+				},
+			},
+			"required": []string{"collection", "filters", "metadata"},
+		},
+		Handler: s.handleBulkUpdateMetadata,
+	})
+
+	s.registerTool(Tool{
+		Name:        "count_documents",
+		Description: "Count documents in a collection",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+			},
+			"required": []string{"collection"},
+		},
+		Handler: s.handleCountDocuments,
+	})
+
+	s.registerTool(Tool{
+		Name:        "update_document",
+		Description: "Update a document's content or metadata, or remove metadata keys",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"document_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the document to update",
+				},
+				"content": map[string]interface{}{
+					"type":        "string",
+					"description": "New content for the document (optional)",
+				},
+				"metadata": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata fields to update (optional)",
+					"default":     map[string]interface{}{},
+				},
+				"remove_metadata_keys": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Metadata keys to delete from the document (optional)",
+				},
+			},
+			"required": []string{"collection", "document_id"},
+		},
+		Handler: s.handleUpdateDocument,
+	})
+
+	s.registerTool(Tool{
+		Name:        "upsert_document",
+		Description: "Create or update a document by ID: updates it when it exists (merging metadata) and creates it with that ID otherwise",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"document_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the document (a UUID for Weaviate)",
+				},
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "URL of the document (optional)",
+				},
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": "Text content of the document; required when the document does not exist yet",
+				},
+				"metadata": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata fields to set; merged into existing metadata on update",
+				},
+			},
+			"required": []string{"collection", "document_id"},
+		},
+		Handler: s.handleUpsertDocument,
+	})
+
+	s.registerTool(Tool{
+		Name:        "reembed_document",
+		Description: "Force re-vectorization of a single document by re-writing its content with the collection's vectorizer",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"document_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the document to re-embed",
+				},
+			},
+			"required": []string{"collection", "document_id"},
+		},
+		Handler: s.handleReembedDocument,
+	})
+
+	// Query tools
+	s.registerTool(Tool{
+		Name:        "query_documents",
+		Description: "Query documents using semantic search",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return (0 returns only the result count)",
 					"default":     5,
 				},
+				"rerank": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Reorder results with the collection's Weaviate reranker module, returning original and reranked scores (default: false)",
+					"default":     false,
+				},
+				"include_vector": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also return each result's embedding vector; vectors are large, so keep limit small (Weaviate only, default: false)",
+					"default":     false,
+				},
+				"include_vectors": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Alias of include_vector",
+					"default":     false,
+				},
+				"query_vectorizer": map[string]interface{}{
+					"type":        "string",
+					"description": "Vectorizer module, or named vector, to embed the query with instead of the collection's default (Weaviate only; must be one of the collection's vectorizers)",
+				},
 			},
 			"required": []string{"collection", "query"},
 		},
 		Handler: s.handleQueryDocuments,
 	})
 
+	s.registerTool(Tool{
+		Name:        "query_documents_filtered",
+		Description: "Query documents using semantic search restricted by metadata filters",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return",
+					"default":     5,
+				},
+				"filters": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata filters keyed by property. A plain value is an exact match (e.g. {\"type\": \"pdf\"}); an {\"operator\", \"value\"} object selects Equal, NotEqual, Like, GreaterThan, GreaterThanEqual, LessThan, LessThanEqual, ContainsAny or ContainsAll (e.g. {\"year\": {\"operator\": \"GreaterThan\", \"value\": 2020}}). On Weaviate, a nested {\"operator\": \"And|Or|Not\", \"operands\": [...]} expression of filter strings combines filters (e.g. {\"operator\": \"Or\", \"operands\": [\"type=pdf\", \"year>2020\"]})",
+				},
+			},
+			"required": []string{"collection", "query", "filters"},
+		},
+		Handler: s.handleQueryDocumentsFiltered,
+	})
+
+	s.registerTool(Tool{
+		Name:        "query_documents_advanced",
+		Description: "Semantic search over several concepts, optionally steered toward or away from topics with move_to/move_away (Weaviate only)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query; optional when concepts are given",
+				},
+				"concepts": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Extra concepts searched together with the query (e.g. [\"pasta\", \"risotto\"])",
+				},
+				"move_to": map[string]interface{}{
+					"type":        "object",
+					"description": "Bias results toward these concepts; force (0-1) sets how strongly",
+					"properties": map[string]interface{}{
+						"concepts": map[string]interface{}{
+							"type":  "array",
+							"items": map[string]interface{}{"type": "string"},
+						},
+						"force": map[string]interface{}{
+							"type":    "number",
+							"minimum": 0,
+							"maximum": 1,
+							"default": 0.5,
+						},
+					},
+					"required": []string{"concepts"},
+				},
+				"move_away": map[string]interface{}{
+					"type":        "object",
+					"description": "Bias results away from these concepts; force (0-1) sets how strongly",
+					"properties": map[string]interface{}{
+						"concepts": map[string]interface{}{
+							"type":  "array",
+							"items": map[string]interface{}{"type": "string"},
+						},
+						"force": map[string]interface{}{
+							"type":    "number",
+							"minimum": 0,
+							"maximum": 1,
+							"default": 0.5,
+						},
+					},
+					"required": []string{"concepts"},
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return",
+					"default":     5,
+				},
+			},
+			"required": []string{"collection"},
+		},
+		Handler: s.handleQueryDocumentsAdvanced,
+	})
+
+	s.registerTool(Tool{
+		Name:        "search_by_vector",
+		Description: "Search a collection with a precomputed embedding (nearVector) instead of query text; the vector must match the collection's dimensions (Weaviate only)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"vector": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "number"},
+					"description": "Query embedding, with the same dimensions as the collection's vectors",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return",
+					"default":     5,
+				},
+			},
+			"required": []string{"collection", "vector"},
+		},
+		Handler: s.handleSearchByVector,
+	})
+
+	s.registerTool(Tool{
+		Name:        "get_documents_by_metadata",
+		Description: "Get all documents whose metadata matches filters, without a search query; set ids_only to cheaply check which documents exist",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"filters": map[string]interface{}{
+					"type":        "object",
+					"description": "Metadata filters keyed by property, or a nested operator/operands expression, as in query_documents_filtered (e.g. {\"filename\": \"report.pdf\"}); databases other than Weaviate support exact matches only",
+				},
+				"ids_only": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return only the IDs of matching documents (default: false)",
+					"default":     false,
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": "Maximum number of documents to return (default: 100, max: 1000)",
+				},
+			},
+			"required": []string{"collection", "filters"},
+		},
+		Handler: s.handleGetDocumentsByMetadata,
+	})
+
+	s.registerTool(Tool{
+		Name:        "search_hybrid",
+		Description: "Hybrid search combining vector and keyword (BM25) search, with alpha controlling the weighting per query",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return",
+					"default":     5,
+				},
+				"alpha": map[string]interface{}{
+					"type":        "number",
+					"description": "Weight of vector search between 0.0 (pure keyword) and 1.0 (pure vector)",
+					"minimum":     0,
+					"maximum":     1,
+					"default":     0.75,
+				},
+				"fusion_type": map[string]interface{}{
+					"type":        "string",
+					"description": "How keyword and vector results are combined (default: the database default)",
+					"enum":        []string{"rankedFusion", "relativeScoreFusion"},
+				},
+			},
+			"required": []string{"collection", "query"},
+		},
+		Handler: s.handleSearchHybrid,
+	})
+
+	s.registerTool(Tool{
+		Name:        "search_bm25",
+		Description: "Keyword (BM25) search over document text, without vector similarity",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Keywords to search for",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return",
+					"default":     5,
+				},
+				"properties": map[string]interface{}{
+					"type":        "array",
+					"description": "Properties to search (default: the content/text fields; Weaviate only when set)",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			"required": []string{"collection", "query"},
+		},
+		Handler: s.handleSearchBM25,
+	})
+
 	// AI tools
 	s.registerTool(Tool{
 		Name:        "suggest_schema",
@@ -552,41 +1361,238 @@ func (s *Server) registerTools() {
 		Handler: s.handleSuggestChunking,
 	})
 
-	// Health and monitoring tools
+	// Health and monitoring tools
+	s.registerTool(Tool{
+		Name:        "health_check",
+		Description: "Check the health and connectivity of the vector database",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleHealthCheck,
+	})
+
+	s.registerTool(Tool{
+		Name:        "health_check_all",
+		Description: "Check the health of every configured vector database concurrently, returning each one's status, error, and latency",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"enabled_only": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only check databases configured with enabled: true (default: false)",
+					"default":     false,
+				},
+			},
+		},
+		Handler: s.handleHealthCheckAll,
+	})
+
+	s.registerTool(Tool{
+		Name:        "deep_health_check",
+		Description: "Verify the default vector database accepts writes by creating, reading, and deleting a document in a throwaway collection, reporting each step's status and latency. Slower than health_check, which only checks connectivity",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleDeepHealthCheck,
+	})
+
+	s.registerTool(Tool{
+		Name:        "ping_database",
+		Description: "Measure the round-trip latency to the vector database in milliseconds",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"samples": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of pings to average (default: 1, max: 10)",
+				},
+			},
+		},
+		Handler: s.handlePingDatabase,
+	})
+
+	s.registerTool(Tool{
+		Name:        "config_info",
+		Description: "Show the effective server configuration (databases, vectorizer default, timeouts, CORS, TLS, environment) with API keys and passwords masked",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleConfigInfo,
+	})
+
+	s.registerTool(Tool{
+		Name:        "list_databases",
+		Description: "List the configured vector databases with their types, URLs (secrets redacted), and which is the default; their names are valid values of the database argument",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleListDatabases,
+	})
+
+	s.registerTool(Tool{
+		Name:        "switch_default_database",
+		Description: "Make another configured vector database the default without restarting; the switch only happens if the database passes a health check",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the configured database to make the default (see list_databases)",
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: s.handleSwitchDefaultDatabase,
+	})
+
+	s.registerTool(Tool{
+		Name:        "set_server_default_collection",
+		Description: "Set the server-wide collection that document and query tools use when collection is omitted; the change applies to every client of this server, and an empty name clears it",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection to use by default, or an empty string to clear the default",
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: s.handleSetServerDefaultCollection,
+	})
+
+	s.registerTool(Tool{
+		Name:        "describe_tool",
+		Description: "Describe a tool: its description, input schema, and example arguments with the results they return",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the tool to describe",
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: s.handleDescribeTool,
+	})
+
+	s.registerTool(Tool{
+		Name:        "count_collections",
+		Description: "Count the total number of collections in the database",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleCountCollections,
+	})
+
+	s.registerTool(Tool{
+		Name:        "show_collection",
+		Description: "Show detailed information about a collection including schema, count, and properties",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection to show",
+				},
+			},
+			"required": []string{"name"},
+		},
+		Handler: s.handleShowCollection,
+	})
+
 	s.registerTool(Tool{
-		Name:        "health_check",
-		Description: "Check the health and connectivity of the vector database",
+		Name:        "compare_collections",
+		Description: "Compare the schemas of two collections and return the differences",
 		InputSchema: map[string]interface{}{
-			"type":       "object",
-			"properties": map[string]interface{}{},
+			"type": "object",
+			"properties": map[string]interface{}{
+				"source": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the first (source) collection",
+				},
+				"target": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the second (target) collection",
+				},
+			},
+			"required": []string{"source", "target"},
 		},
-		Handler: s.handleHealthCheck,
+		Handler: s.handleCompareCollections,
 	})
 
 	s.registerTool(Tool{
-		Name:        "count_collections",
-		Description: "Count the total number of collections in the database",
+		Name:        "get_collection_config",
+		Description: "Get a collection's module configuration (vectorizer, generative and reranker modules) to check whether generative queries are available (Weaviate only)",
 		InputSchema: map[string]interface{}{
-			"type":       "object",
-			"properties": map[string]interface{}{},
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+			},
+			"required": []string{"name"},
 		},
-		Handler: s.handleCountCollections,
+		Handler: s.handleGetCollectionConfig,
 	})
 
 	s.registerTool(Tool{
-		Name:        "show_collection",
-		Description: "Show detailed information about a collection including schema, count, and properties",
+		Name:        "get_search_capabilities",
+		Description: "Report which search modes (nearText, bm25, hybrid) a collection supports; results are cached so queries skip unsupported modes (Weaviate only)",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
 				"name": map[string]interface{}{
 					"type":        "string",
-					"description": "Name of the collection to show",
+					"description": "Name of the collection",
+				},
+				"refresh": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Probe every mode again instead of using cached results",
+					"default":     false,
 				},
 			},
 			"required": []string{"name"},
 		},
-		Handler: s.handleShowCollection,
+		Handler: s.handleGetSearchCapabilities,
+	})
+
+	s.registerTool(Tool{
+		Name:        "warm_cache",
+		Description: "Prefetch schemas and counts of collections and probe their search modes into the search mode cache (Weaviate), so a burst of queries starts warm",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collections": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Collections to warm (default: all collections)",
+				},
+				"refresh": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Probe every search mode again instead of keeping cached results",
+					"default":     false,
+				},
+			},
+		},
+		Handler: s.handleWarmCache,
+	})
+
+	s.registerTool(Tool{
+		Name:        "reload_schemas",
+		Description: "Reload schema definitions from the schemas directory without restarting; schemas in config.yaml keep precedence",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+		Handler: s.handleReloadSchemas,
 	})
 
 	// Embedding tools
@@ -643,11 +1649,57 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "Name of the collection (optional - if not provided, deletes from all collections)",
 				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only return the number of documents that would be deleted",
+					"default":     false,
+				},
+				"confirm_count": map[string]interface{}{
+					"type":        "integer",
+					"description": "Document count from a dry run; required when it exceeds max_delete_all_documents",
+				},
+				"async": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Run in the background and return a job_id for get_job_status",
+					"default":     false,
+				},
 			},
 		},
 		Handler: s.handleDeleteAllDocuments,
 	})
 
+	s.registerTool(Tool{
+		Name:        "get_job_status",
+		Description: "Get the status and progress of an async operation",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID returned by an async tool call",
+				},
+			},
+			"required": []string{"job_id"},
+		},
+		Handler: s.handleGetJobStatus,
+	})
+
+	s.registerTool(Tool{
+		Name:        "cancel_job",
+		Description: "Cancel a running async operation",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID returned by an async tool call",
+				},
+			},
+			"required": []string{"job_id"},
+		},
+		Handler: s.handleCancelJob,
+	})
+
 	s.registerTool(Tool{
 		Name:        "show_document_by_name",
 		Description: "Show a document by filename instead of ID",
@@ -668,6 +1720,46 @@ func (s *Server) registerTools() {
 		Handler: s.handleShowDocumentByName,
 	})
 
+	s.registerTool(Tool{
+		Name:        "find_document",
+		Description: "Find documents by partial ID, URL, filename, title, or text, returning each match with a match_reason",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Document ID or ID fragment",
+				},
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "Substring of the document URL",
+				},
+				"filename": map[string]interface{}{
+					"type":        "string",
+					"description": "Substring of the filename in metadata or URL",
+				},
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Substring of the title in metadata",
+				},
+				"text_contains": map[string]interface{}{
+					"type":        "string",
+					"description": "Substring of the document text or content",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of matches to return (default: 10, max: 100)",
+				},
+			},
+			"required": []string{"collection"},
+		},
+		Handler: s.handleFindDocument,
+	})
+
 	s.registerTool(Tool{
 		Name:        "delete_document_by_name",
 		Description: "Delete a document by filename instead of ID",
@@ -682,6 +1774,11 @@ func (s *Server) registerTools() {
 					"type":        "string",
 					"description": "Filename to search for in URL or metadata",
 				},
+				"dry_run": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return the matching document ID without deleting it",
+					"default":     false,
+				},
 			},
 			"required": []string{"collection", "filename"},
 		},
@@ -712,6 +1809,154 @@ func (s *Server) registerTools() {
 		Handler: s.handleExecuteQuery,
 	})
 
+	s.registerTool(Tool{
+		Name:        "smart_search",
+		Description: "Search the collections a query is most likely about, chosen from collection names and content types (e.g. image collections for image queries), falling back to all collections; results are tagged by collection with the routing rationale",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Natural language query to search for",
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": "Maximum number of results to return across collections (default: 5)",
+				},
+			},
+			"required": []string{"query"},
+		},
+		Handler: s.handleSmartSearch,
+	})
+
+	s.registerTool(Tool{
+		Name:        "generative_search",
+		Description: "Search a collection and have Weaviate's generative module synthesize an answer from the top results (Weaviate only, requires a generative module on the collection)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Semantic search query used to retrieve source documents",
+				},
+				"prompt": map[string]interface{}{
+					"type":        "string",
+					"description": "Task for the generative module, applied to the retrieved documents as a group",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Number of source documents to retrieve (default: 5)",
+				},
+			},
+			"required": []string{"collection", "query", "prompt"},
+		},
+		Handler: s.handleGenerativeSearch,
+	})
+
+	s.registerTool(Tool{
+		Name:        "nearest_neighbors_graph",
+		Description: "Build a similarity graph around a document by following nearest neighbors (nearObject) outward, returning documents as nodes and similarity scores as edges",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"document_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the starting document",
+				},
+				"depth": map[string]interface{}{
+					"type":        "integer",
+					"description": "Hops to follow from the starting document (1-5)",
+					"default":     defaultGraphDepth,
+				},
+				"breadth": map[string]interface{}{
+					"type":        "integer",
+					"description": "Nearest neighbors fetched per document (1-20)",
+					"default":     defaultGraphBreadth,
+				},
+				"max_nodes": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum documents in the graph (1-500)",
+					"default":     defaultGraphMaxNodes,
+				},
+			},
+			"required": []string{"collection", "document_id"},
+		},
+		Handler: s.handleNearestNeighborsGraph,
+	})
+
+	s.registerTool(Tool{
+		Name:        "copy_collection",
+		Description: "Copy a collection's schema (including vectorizer) and documents, with their IDs and metadata, into a new collection",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"source": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection to copy",
+				},
+				"destination": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection to create",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of documents to copy (default: all)",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Delete and recreate the destination if it already exists (default: false)",
+					"default":     false,
+				},
+			},
+			"required": []string{"source", "destination"},
+		},
+		Handler: s.handleCopyCollection,
+	})
+
+	s.registerTool(Tool{
+		Name:        "compact_collection",
+		Description: "Run available maintenance on a collection's shards and report their status; compaction and flushing are reported as not supported when the backend exposes no trigger (Weaviate only)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the collection",
+				},
+				"reset_readonly": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return READONLY shards to READY (default: false)",
+					"default":     false,
+				},
+			},
+			"required": []string{"collection"},
+		},
+		Handler: s.handleCompactCollection,
+	})
+
+	s.registerTool(Tool{
+		Name:        "cluster_status",
+		Description: "Report the nodes of a Weaviate cluster with their status, shards, and object counts (Weaviate only)",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"collection": map[string]interface{}{
+					"type":        "string",
+					"description": "Only report the shards of this collection (default: all collections)",
+				},
+			},
+		},
+		Handler: s.handleClusterStatus,
+	})
+
 	// Phase 1: Observability & Monitoring tools
 	s.registerTool(Tool{
 		Name:        "configure_logging",
@@ -837,6 +2082,17 @@ func (s *Server) registerTools() {
 
 // registerTool registers a tool with the server
 func (s *Server) registerTool(tool Tool) {
+	if databaseTools[tool.Name] {
+		tool = s.withDatabaseArgument(tool)
+	}
+	if defaultCollectionTools[tool.Name] {
+		tool = s.withDefaultCollection(tool)
+	}
+	tool.Handler = withJSONResult(tool.Name, tool.Handler)
+	if tool.Examples == nil {
+		tool.Examples = toolExamples[tool.Name]
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.Tools[tool.Name] = tool
@@ -856,14 +2112,14 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := s.dbClient.Health(ctx); err != nil {
+	if err := s.db(ctx).Health(ctx); err != nil {
 		dbStatus = "unhealthy"
 		dbError = err.Error()
 		s.logger.Warn("Database health check failed", zap.Error(err))
 	}
 
 	// Get database type from config
-	dbConfig, _ := s.config.GetDefaultDatabase()
+	dbConfig, _ := s.defaultDatabaseConfig()
 	dbType := "unknown"
 	dbName := "unknown"
 	if dbConfig != nil {
@@ -929,6 +2185,31 @@ func (s *Server) handleToolsList(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleToolDescribe handles GET /mcp/tools/describe?name=<tool>
+func (s *Server) handleToolDescribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	description, err := s.describeTool(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(description); err != nil {
+		s.logger.Error("Failed to encode tool description", zap.String("tool", name), zap.Error(err))
+	}
+}
+
 // handleToolCall handles tool execution requests
 func (s *Server) handleToolCall(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -936,15 +2217,36 @@ func (s *Server) handleToolCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var request struct {
-		Name      string                 `json:"name"`
-		Arguments map[string]interface{} `json:"arguments"`
+	// A JSON array is a batch of independent tool calls; only batches are
+	// size-limited, so single calls are unchanged
+	reader := bufio.NewReader(r.Body)
+	if isJSONArray(reader) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, io.NopCloser(reader), maxToolCallBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, fmt.Sprintf("Batch request body exceeds the limit of %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		s.handleToolCallBatch(w, r, body)
+		return
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+	var request toolCallRequest
+	if err := decodeWithNumbers(body, &request); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
+	normalizeArgumentNumbers(request.Arguments)
 
 	s.mu.RLock()
 	tool, exists := s.Tools[request.Name]
@@ -955,18 +2257,11 @@ func (s *Server) handleToolCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Execute tool with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-	defer cancel()
-
-	result, err := tool.Handler(ctx, request.Arguments)
+	result, err := s.callTool(r.Context(), tool, request.Name, request.Arguments)
 	if err != nil {
-		s.logger.Error("Tool execution failed",
-			zap.String("tool", request.Name),
-			zap.Error(err))
-
 		response := map[string]interface{}{
-			"error": err.Error(),
+			apiVersionField: APIVersion,
+			"error":         err.Error(),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -977,7 +2272,8 @@ func (s *Server) handleToolCall(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]interface{}{
-		"result": result,
+		apiVersionField: APIVersion,
+		"result":        result,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -986,8 +2282,50 @@ func (s *Server) handleToolCall(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// callTool runs a tool handler with the tool's configured timeout, recording
+// its stats and logging failures
+func (s *Server) callTool(ctx context.Context, tool Tool, name string, args map[string]interface{}) (interface{}, error) {
+	defer s.calls.begin()()
+
+	ctx, cancel := context.WithTimeout(ctx, s.toolTimeout(name))
+	defer cancel()
+
+	start := time.Now()
+	result, err := tool.Handler(ctx, args)
+	duration := time.Since(start)
+	s.toolStats.record(name, duration, err != nil)
+	recordToolMetrics(name, duration, err != nil)
+	if err != nil {
+		if categorizeError(err) == "auth" {
+			// Logged without the error, which may echo the API key
+			s.recordAuthFailure(ctx, name)
+		} else {
+			s.logger.Error("Tool execution failed",
+				zap.String("tool", name),
+				zap.Error(err))
+		}
+	}
+	return result, err
+}
+
+// toolTimeout returns the operation_timeouts entry for a tool, falling back
+// to the default entry
+func (s *Server) toolTimeout(name string) time.Duration {
+	if s.config == nil {
+		return config.DefaultToolTimeout
+	}
+	return s.config.OperationTimeouts.For(name)
+}
+
 // Cleanup cleans up resources
 func (s *Server) Cleanup() error {
+	// Stop the schema watcher if running
+	if s.schemaWatcher != nil {
+		if err := s.schemaWatcher.Close(); err != nil {
+			return fmt.Errorf("failed to stop schema watcher: %w", err)
+		}
+	}
+
 	// Close Weaviate client if needed
 	// (Weaviate client doesn't have a Close method, so nothing to do here)
 	return nil
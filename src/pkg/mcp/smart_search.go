@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// Routing strategies reported by smart_search
+const (
+	routingCollectionName = "collection_name" // the query names collections
+	routingContentType    = "content_type"    // the query asks for images
+	routingAll            = "all"             // no signal: every collection
+)
+
+// Collection content types inferred by smart_search
+const (
+	contentTypeText  = "text"
+	contentTypeImage = "image"
+)
+
+// imageQueryTerms are query words that ask for images rather than text
+var imageQueryTerms = map[string]bool{
+	"image": true, "images": true, "photo": true, "photos": true,
+	"picture": true, "pictures": true, "pic": true, "pics": true,
+	"screenshot": true, "screenshots": true, "diagram": true, "diagrams": true,
+	"illustration": true, "illustrations": true, "logo": true, "logos": true,
+	"figure": true, "figures": true, "png": true, "jpg": true, "jpeg": true,
+	"gif": true, "svg": true,
+}
+
+// imageVectorizerMarkers are substrings of vectorizer modules that embed images
+var imageVectorizerMarkers = []string{"img2vec", "multi2vec", "clip"}
+
+// handleSmartSearch handles the smart_search tool: it picks the collections a
+// query is most likely about and searches only those, falling back to every
+// collection when nothing points at a subset
+func (s *Server) handleSmartSearch(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	query, ok := args["query"].(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	collections, err := s.db(ctx).ListCollections(timeoutCtx)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to list collections", err)
+	}
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("no collections to search")
+	}
+	collections = sortCollectionsByName(collections)
+
+	selected, routing := s.routeQuery(ctx, query, collections)
+	results, errs := s.searchCollections(timeoutCtx, selected, query, limit)
+
+	response := map[string]interface{}{
+		"query":               query,
+		"results":             results,
+		"count":               len(results),
+		"routing":             routing,
+		"collections_queried": len(selected),
+	}
+	if failed := failedCollections(selected, errs); len(failed) > 0 {
+		response["failed_collections"] = failed
+	}
+	return response, nil
+}
+
+// routeQuery selects the collections to search for a query and describes why.
+// Collections named in the query win, then image collections for queries
+// asking for images; otherwise the routing is ambiguous and all are searched.
+func (s *Server) routeQuery(ctx context.Context, query string, collections []vectordb.CollectionInfo) ([]vectordb.CollectionInfo, map[string]interface{}) {
+	lowerQuery := strings.ToLower(query)
+	terms := queryTerms(lowerQuery)
+
+	var named []vectordb.CollectionInfo
+	for _, coll := range collections {
+		if queryNamesCollection(lowerQuery, terms, coll.Name) {
+			named = append(named, coll)
+		}
+	}
+	if len(named) > 0 {
+		return named, routingInfo(routingCollectionName, named,
+			"the query mentions the collection by name")
+	}
+
+	var imageTerm string
+	for _, term := range terms {
+		if imageQueryTerms[term] {
+			imageTerm = term
+			break
+		}
+	}
+	if imageTerm != "" {
+		var images []vectordb.CollectionInfo
+		for _, coll := range collections {
+			if s.collectionContentType(ctx, coll) == contentTypeImage {
+				images = append(images, coll)
+			}
+		}
+		if len(images) > 0 && len(images) < len(collections) {
+			routing := routingInfo(routingContentType, images,
+				fmt.Sprintf("the query asks for images ('%s'), so only image collections are searched", imageTerm))
+			routing["content_type"] = contentTypeImage
+			return images, routing
+		}
+	}
+
+	return collections, routingInfo(routingAll, collections,
+		"no collection names or content type in the query narrowed the search, so all collections are searched")
+}
+
+// routingInfo describes a routing decision for the smart_search response
+func routingInfo(strategy string, collections []vectordb.CollectionInfo, rationale string) map[string]interface{} {
+	names := make([]string, len(collections))
+	for i, coll := range collections {
+		names[i] = coll.Name
+	}
+	return map[string]interface{}{
+		"strategy":    strategy,
+		"collections": names,
+		"rationale":   rationale,
+	}
+}
+
+// collectionContentType infers whether a collection holds images or text from
+// its configured type, then its vectorizer, then its name
+func (s *Server) collectionContentType(ctx context.Context, coll vectordb.CollectionInfo) string {
+	if configured := s.collectionConfig(ctx, coll.Name); configured != nil && configured.Type != "" {
+		if strings.EqualFold(configured.Type, contentTypeImage) {
+			return contentTypeImage
+		}
+		return contentTypeText
+	}
+
+	vectorizer := strings.ToLower(coll.Vectorizer)
+	for _, marker := range imageVectorizerMarkers {
+		if strings.Contains(vectorizer, marker) {
+			return contentTypeImage
+		}
+	}
+
+	for _, term := range queryTerms(strings.ToLower(coll.Name)) {
+		if imageQueryTerms[term] {
+			return contentTypeImage
+		}
+	}
+	if strings.Contains(strings.ToLower(coll.Name), contentTypeImage) {
+		return contentTypeImage
+	}
+	return contentTypeText
+}
+
+// queryNamesCollection reports whether a lowercased query mentions a
+// collection name, either as a word or, for multi-word names, verbatim
+func queryNamesCollection(lowerQuery string, terms []string, name string) bool {
+	lowerName := strings.ToLower(name)
+	for _, term := range terms {
+		if term == lowerName {
+			return true
+		}
+	}
+	return len(queryTerms(lowerName)) > 1 && strings.Contains(lowerQuery, lowerName)
+}
+
+// queryTerms splits text into its letter and digit runs
+func queryTerms(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
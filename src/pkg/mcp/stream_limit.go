@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// Streaming endpoints counted against the connection cap
+const (
+	streamJobEvents = "job_events"
+	streamExport    = "export"
+)
+
+// streamRetryAfterSeconds is the Retry-After hint sent when the cap is reached
+const streamRetryAfterSeconds = 5
+
+var (
+	// activeStreams tracks open streaming connections per endpoint
+	activeStreams = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "weave_mcp_active_streams",
+			Help: "Number of open streaming connections",
+		},
+		[]string{"endpoint"},
+	)
+
+	// streamRejectionsTotal counts streaming requests refused by the cap
+	streamRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "weave_mcp_stream_rejections_total",
+			Help: "Total number of streaming connections rejected by max_sse_connections",
+		},
+		[]string{"endpoint"},
+	)
+)
+
+// streamTracker counts the open long-lived streaming connections so they
+// can be capped and reported in /stats
+type streamTracker struct {
+	mu       sync.Mutex
+	active   map[string]int
+	total    int
+	rejected int64
+}
+
+// acquire reserves a connection slot for endpoint, failing when limit
+// connections are already open (a negative limit disables the cap)
+func (t *streamTracker) acquire(endpoint string, limit int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limit >= 0 && t.total >= limit {
+		t.rejected++
+		streamRejectionsTotal.WithLabelValues(endpoint).Inc()
+		return false
+	}
+
+	if t.active == nil {
+		t.active = make(map[string]int)
+	}
+	t.active[endpoint]++
+	t.total++
+	activeStreams.WithLabelValues(endpoint).Inc()
+	return true
+}
+
+// release frees a slot reserved by acquire
+func (t *streamTracker) release(endpoint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.active[endpoint]--
+	t.total--
+	activeStreams.WithLabelValues(endpoint).Dec()
+}
+
+// snapshot returns a JSON-friendly view of the open streams
+func (t *streamTracker) snapshot(limit int) map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	byEndpoint := map[string]interface{}{
+		streamJobEvents: t.active[streamJobEvents],
+		streamExport:    t.active[streamExport],
+	}
+	return map[string]interface{}{
+		"active":      t.total,
+		"max":         limit,
+		"rejected":    t.rejected,
+		"by_endpoint": byEndpoint,
+	}
+}
+
+// rejections returns how many connections the cap has refused
+func (t *streamTracker) rejections() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rejected
+}
+
+// acquireStream reserves a streaming connection slot for the request, or
+// writes a 503 and returns false when max_sse_connections is reached. The
+// returned release frees the slot; it also runs when the request context is
+// cancelled, so a client disconnect frees the slot even while the handler is
+// still blocked on the database.
+func (s *Server) acquireStream(w http.ResponseWriter, r *http.Request, endpoint string) (func(), bool) {
+	limit := s.config.SSEConnectionLimit()
+	if !s.streams.acquire(endpoint, limit) {
+		s.logger.Warn("Rejected streaming connection",
+			zap.String("endpoint", endpoint),
+			zap.String("remote_ip", clientIP(r)),
+			zap.Int("max_sse_connections", limit))
+		w.Header().Set("Retry-After", strconv.Itoa(streamRetryAfterSeconds))
+		http.Error(w, fmt.Sprintf("Too many streaming connections (limit %d)", limit), http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	release := sync.OnceFunc(func() { s.streams.release(endpoint) })
+	stop := context.AfterFunc(r.Context(), release)
+	return func() {
+		stop()
+		release()
+	}, true
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxToolCallBatch limits the calls in one batch request
+	maxToolCallBatch = 50
+	// maxConcurrentBatchCalls limits the calls of a batch running at once
+	maxConcurrentBatchCalls = 5
+	// maxToolCallBody limits the size of a batch request body, leaving room
+	// for documents with inline images; single calls are not limited
+	maxToolCallBody = 32 << 20
+)
+
+// toolCallRequest is the body of a tool call, or one entry of a batch
+type toolCallRequest struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// isJSONArray reports whether the first non-whitespace byte of body opens an
+// array, consuming only the leading whitespace
+func isJSONArray(body *bufio.Reader) bool {
+	for {
+		b, err := body.ReadByte()
+		if err != nil {
+			return false
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		_ = body.UnreadByte()
+		return b == '['
+	}
+}
+
+// handleToolCallBatch runs a batch of independent tool calls concurrently and
+// responds with a {result} or {error} object per call, in request order
+func (s *Server) handleToolCallBatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	var requests []toolCallRequest
+	if err := decodeWithNumbers(body, &requests); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	for _, request := range requests {
+		normalizeArgumentNumbers(request.Arguments)
+	}
+	if len(requests) == 0 {
+		http.Error(w, "Batch must contain at least one tool call", http.StatusBadRequest)
+		return
+	}
+	if len(requests) > maxToolCallBatch {
+		http.Error(w, fmt.Sprintf("Batch has %d tool calls, more than the limit of %d", len(requests), maxToolCallBatch), http.StatusBadRequest)
+		return
+	}
+
+	// Each call is bounded by its tool timeout, but calls queued behind the
+	// concurrency limit can together outlast the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	responses := make([]map[string]interface{}, len(requests))
+	semaphore := make(chan struct{}, maxConcurrentBatchCalls)
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request toolCallRequest) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			responses[i] = s.batchToolCall(r, request)
+		}(i, request)
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		s.logger.Error("Failed to encode batch tool call response", zap.Error(err))
+	}
+}
+
+// batchToolCall runs one call of a batch
+func (s *Server) batchToolCall(r *http.Request, request toolCallRequest) map[string]interface{} {
+	s.mu.RLock()
+	tool, exists := s.Tools[request.Name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return map[string]interface{}{apiVersionField: APIVersion, "error": fmt.Sprintf("Tool '%s' not found", request.Name)}
+	}
+
+	result, err := s.callTool(r.Context(), tool, request.Name, request.Arguments)
+	if err != nil {
+		return map[string]interface{}{apiVersionField: APIVersion, "error": err.Error()}
+	}
+	return map[string]interface{}{apiVersionField: APIVersion, "result": result}
+}
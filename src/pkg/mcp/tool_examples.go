@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToolExample is a realistic invocation of a tool and the result it returns
+type ToolExample struct {
+	Description string                 `json:"description"`
+	Arguments   map[string]interface{} `json:"arguments"`
+	Result      interface{}            `json:"result,omitempty"`
+}
+
+// toolExamples are the examples attached to core tools at registration
+var toolExamples = map[string][]ToolExample{
+	"list_collections": {
+		{
+			Description: "List all collections",
+			Arguments:   map[string]interface{}{},
+			Result: map[string]interface{}{
+				"collections": []string{"WeaveDocs", "WeaveImages"},
+				"count":       2,
+			},
+		},
+	},
+	"create_collection": {
+		{
+			Description: "Create a text collection vectorized with OpenAI",
+			Arguments: map[string]interface{}{
+				"name":        "Articles",
+				"type":        "text",
+				"description": "Blog articles",
+				"vectorizer":  "text2vec-openai",
+			},
+			Result: map[string]interface{}{
+				"name":        "Articles",
+				"type":        "text",
+				"description": "Blog articles",
+				"vectorizer":  "text2vec-openai",
+				"status":      "created",
+			},
+		},
+		{
+			Description: "Create a collection from a template, overriding its description",
+			Arguments: map[string]interface{}{
+				"name":        "NewsArticles",
+				"template":    "articles",
+				"description": "News articles",
+			},
+			Result: map[string]interface{}{
+				"name":        "NewsArticles",
+				"type":        "text",
+				"description": "News articles",
+				"vectorizer":  "text2vec-openai",
+				"template":    "articles",
+				"status":      "created",
+			},
+		},
+	},
+	"list_documents": {
+		{
+			Description: "List the first 2 documents of a collection",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "limit": 2},
+			Result: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"count":      2,
+				"documents": []map[string]interface{}{
+					{"id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60", "url": "https://example.com/intro", "text": "Weaviate is a vector database...", "metadata": map[string]interface{}{"filename": "intro.md"}},
+					{"id": "0b7e2d41-3c5f-4a6b-8d9e-2f1a3b4c5d6e", "url": "https://example.com/setup", "text": "To install the server...", "metadata": map[string]interface{}{"filename": "setup.md"}},
+				},
+				"total_count": 120,
+				"has_more":    true,
+			},
+		},
+		{
+			Description: "Count documents without fetching any",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "limit": 0},
+			Result: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"count":      120,
+				"count_only": true,
+				"documents":  []interface{}{},
+			},
+		},
+	},
+	"create_document": {
+		{
+			Description: "Add a document with metadata",
+			Arguments: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"url":        "https://example.com/guide",
+				"text":       "This guide explains how to configure collections.",
+				"metadata":   map[string]interface{}{"filename": "guide.md", "author": "docs-team"},
+			},
+			Result: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"url":        "https://example.com/guide",
+				"text":       "This guide explains how to configure collections.",
+				"metadata":   map[string]interface{}{"filename": "guide.md", "author": "docs-team"},
+				"status":     "created",
+			},
+		},
+	},
+	"batch_create_documents": {
+		{
+			Description: "Add several documents in one request",
+			Arguments: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"documents": []map[string]interface{}{
+					{"url": "https://example.com/a", "text": "First document"},
+					{"url": "https://example.com/b", "text": "Second document", "metadata": map[string]interface{}{"filename": "b.md"}},
+				},
+			},
+			Result: map[string]interface{}{"collection": "WeaveDocs", "count": 2, "status": "created"},
+		},
+	},
+	"get_document": {
+		{
+			Description: "Fetch a document by ID",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "document_id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60"},
+			Result: map[string]interface{}{
+				"id":         "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+				"collection": "WeaveDocs",
+				"url":        "https://example.com/intro",
+				"text":       "Weaviate is a vector database...",
+				"content":    "Weaviate is a vector database...",
+				"metadata":   map[string]interface{}{"filename": "intro.md"},
+			},
+		},
+	},
+	"update_document": {
+		{
+			Description: "Replace a document's content and merge new metadata",
+			Arguments: map[string]interface{}{
+				"collection":  "WeaveDocs",
+				"document_id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+				"content":     "Weaviate is an open source vector database.",
+				"metadata":    map[string]interface{}{"reviewed": true},
+			},
+			Result: map[string]interface{}{
+				"document_id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+				"collection":  "WeaveDocs",
+				"status":      "updated",
+			},
+		},
+	},
+	"delete_document": {
+		{
+			Description: "Delete a document by ID",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "document_id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60"},
+			Result: map[string]interface{}{
+				"document_id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+				"collection":  "WeaveDocs",
+				"status":      "deleted",
+			},
+		},
+	},
+	"count_documents": {
+		{
+			Description: "Count the documents in a collection",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs"},
+			Result:      map[string]interface{}{"collection": "WeaveDocs", "count": 120},
+		},
+	},
+	"query_documents": {
+		{
+			Description: "Semantic search for the 2 most relevant documents",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "query": "how do I install the server?", "limit": 2},
+			Result: map[string]interface{}{
+				"collection": "WeaveDocs",
+				"query":      "how do I install the server?",
+				"count":      2,
+				"results": []map[string]interface{}{
+					{"id": "0b7e2d41-3c5f-4a6b-8d9e-2f1a3b4c5d6e", "url": "https://example.com/setup", "text": "To install the server...", "score": 0.91},
+					{"id": "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60", "url": "https://example.com/intro", "text": "Weaviate is a vector database...", "score": 0.74},
+				},
+			},
+		},
+		{
+			Description: "Rerank results with the collection's reranker module",
+			Arguments:   map[string]interface{}{"collection": "WeaveDocs", "query": "pricing", "limit": 5, "rerank": true},
+		},
+	},
+	"show_collection": {
+		{
+			Description: "Show a collection's schema and document count",
+			Arguments:   map[string]interface{}{"name": "WeaveDocs"},
+		},
+	},
+	"health_check": {
+		{
+			Description: "Check the database connection",
+			Arguments:   map[string]interface{}{},
+			Result:      map[string]interface{}{"status": "healthy", "database": "weaviate-cloud", "url": "https://my-cluster.weaviate.cloud"},
+		},
+	},
+	"describe_tool": {
+		{
+			Description: "Describe the query_documents tool",
+			Arguments:   map[string]interface{}{"name": "query_documents"},
+		},
+	},
+}
+
+// handleDescribeTool handles the describe_tool tool
+func (s *Server) handleDescribeTool(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("tool name is required")
+	}
+	return s.describeTool(name)
+}
+
+// describeTool returns a tool's description, input schema, and examples
+func (s *Server) describeTool(name string) (map[string]interface{}, error) {
+	s.mu.RLock()
+	tool, exists := s.Tools[name]
+	var similar []string
+	if !exists {
+		for toolName := range s.Tools {
+			if strings.Contains(toolName, name) || strings.Contains(name, toolName) {
+				similar = append(similar, toolName)
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	if !exists {
+		if len(similar) > 0 {
+			sort.Strings(similar)
+			return nil, fmt.Errorf("tool '%s' not found; similar tools: %s", name, strings.Join(similar, ", "))
+		}
+		return nil, fmt.Errorf("tool '%s' not found", name)
+	}
+
+	examples := tool.Examples
+	if examples == nil {
+		examples = []ToolExample{}
+	}
+	return map[string]interface{}{
+		"name":        tool.Name,
+		"description": tool.Description,
+		"inputSchema": tool.InputSchema,
+		"examples":    examples,
+	}, nil
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// toolCallsTotal counts HTTP tool calls per tool
+	toolCallsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "weave_mcp_tool_calls_total",
+			Help: "Total number of MCP tool calls",
+		},
+		[]string{"tool"},
+	)
+
+	// toolErrorsTotal counts HTTP tool calls that returned an error per tool
+	toolErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "weave_mcp_tool_errors_total",
+			Help: "Total number of MCP tool calls that failed",
+		},
+		[]string{"tool"},
+	)
+
+	// toolCallDuration tracks HTTP tool call latency per tool
+	toolCallDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "weave_mcp_tool_call_duration_seconds",
+			Help:    "Duration of MCP tool calls in seconds",
+			Buckets: []float64{.005, .01, .05, .1, .5, 1, 5, 10, 30, 60},
+		},
+		[]string{"tool"},
+	)
+)
+
+// recordToolMetrics records a tool call in the Prometheus metrics. Only
+// registered tools are recorded, which keeps the tool label bounded.
+func recordToolMetrics(tool string, duration time.Duration, failed bool) {
+	toolCallsTotal.WithLabelValues(tool).Inc()
+	if failed {
+		toolErrorsTotal.WithLabelValues(tool).Inc()
+	}
+	toolCallDuration.WithLabelValues(tool).Observe(duration.Seconds())
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxLatencySamples bounds the number of latency samples kept per tool.
+// Percentiles are computed over the most recent samples only.
+const maxLatencySamples = 1000
+
+// toolStats keeps in-memory usage counters for tool calls. Stats are not
+// persisted and reset when the server restarts.
+type toolStats struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	tools     map[string]*toolUsage
+}
+
+// toolUsage holds the counters for a single tool
+type toolUsage struct {
+	calls     int64
+	errors    int64
+	latencies []time.Duration // ring buffer of the most recent samples
+	next      int
+}
+
+// record adds one tool call to the stats
+func (t *toolStats) record(tool string, latency time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.tools == nil {
+		t.tools = make(map[string]*toolUsage)
+	}
+
+	usage, exists := t.tools[tool]
+	if !exists {
+		usage = &toolUsage{}
+		t.tools[tool] = usage
+	}
+
+	usage.calls++
+	if failed {
+		usage.errors++
+	}
+
+	if len(usage.latencies) < maxLatencySamples {
+		usage.latencies = append(usage.latencies, latency)
+	} else {
+		usage.latencies[usage.next] = latency
+		usage.next = (usage.next + 1) % maxLatencySamples
+	}
+}
+
+// snapshot returns a JSON-friendly view of the current stats
+func (t *toolStats) snapshot() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var totalCalls, totalErrors int64
+	tools := make(map[string]interface{}, len(t.tools))
+	for name, usage := range t.tools {
+		sorted := make([]time.Duration, len(usage.latencies))
+		copy(sorted, usage.latencies)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		tools[name] = map[string]interface{}{
+			"calls":          usage.calls,
+			"errors":         usage.errors,
+			"error_rate":     errorRate(usage.errors, usage.calls),
+			"p50_latency_ms": durationMillis(percentile(sorted, 0.50)),
+			"p95_latency_ms": durationMillis(percentile(sorted, 0.95)),
+		}
+		totalCalls += usage.calls
+		totalErrors += usage.errors
+	}
+
+	result := map[string]interface{}{
+		"total_calls":  totalCalls,
+		"total_errors": totalErrors,
+		"error_rate":   errorRate(totalErrors, totalCalls),
+		"tools":        tools,
+	}
+	if !t.startedAt.IsZero() {
+		result["started_at"] = t.startedAt.UTC()
+		result["uptime_seconds"] = int64(time.Since(t.startedAt).Seconds())
+	}
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(p*float64(len(sorted))+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// errorRate returns errors/calls, or 0 when there were no calls
+func errorRate(errors, calls int64) float64 {
+	if calls == 0 {
+		return 0
+	}
+	return float64(errors) / float64(calls)
+}
+
+// durationMillis converts a duration to fractional milliseconds
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// handleStats handles tool usage stats requests
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := s.toolStats.snapshot()
+	response["streams"] = s.streams.snapshot(s.config.SSEConnectionLimit())
+	response["security"] = s.security.snapshot(s.streams.rejections())
+	response["timestamp"] = time.Now().UTC()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Error("Failed to encode stats response", zap.Error(err))
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+	"github.com/maximilien/weave-mcp/src/pkg/weaviate"
+)
+
+// getDocumentWithVector gets a document along with its vector through the
+// Weaviate client, since the vectordb adapter does not return vectors
+func (s *Server) getDocumentWithVector(ctx context.Context, collection, documentID string) (interface{}, error) {
+	if err := s.requireWeaviateDatabase(ctx, "include_vector"); err != nil {
+		return nil, err
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with document operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeDocument)
+	defer cancel()
+
+	weaviateDoc, err := client.GetDocumentWithVector(timeoutCtx, collection, documentID)
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to get document", err)
+	}
+
+	doc := &vectordb.Document{
+		ID:        weaviateDoc.ID,
+		Text:      weaviateDoc.Text,
+		Content:   weaviateDoc.Content,
+		Image:     weaviateDoc.Image,
+		ImageData: weaviateDoc.ImageData,
+		URL:       weaviateDoc.URL,
+		Metadata:  weaviateDoc.Metadata,
+	}
+	response := map[string]interface{}{
+		"id":            doc.ID,
+		"url":           s.documentURL(ctx, collection, doc),
+		"text":          doc.Text,
+		"content":       doc.Content,
+		"content_field": weaviateDoc.ContentField,
+		"metadata":      doc.Metadata,
+		"collection":    collection,
+	}
+	addVector(response, weaviateDoc.Vector)
+	return response, nil
+}
+
+// addVector adds a vector and its dimensions to a result. Objects without a
+// stored vector, such as those in collections with no vectorizer, get a null
+// vector with zero dimensions.
+func addVector(result map[string]interface{}, vector []float32) {
+	if len(vector) == 0 {
+		result["vector"] = nil
+		result["vector_dimensions"] = 0
+		return
+	}
+	result["vector"] = vector
+	result["vector_dimensions"] = len(vector)
+}
+
+// includeVectorArg reports whether a tool call asks for vectors, with
+// include_vector or its include_vectors alias
+func includeVectorArg(args map[string]interface{}) bool {
+	includeVector, _ := args["include_vector"].(bool)
+	includeVectors, _ := args["include_vectors"].(bool)
+	return includeVector || includeVectors
+}
+
+// vectorArg converts a JSON array of numbers into a vector
+func vectorArg(raw interface{}) ([]float32, error) {
+	values, ok := raw.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("vector must be a non-empty array of numbers")
+	}
+	vector := make([]float32, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case float64:
+			vector[i] = float32(v)
+		case int64:
+			vector[i] = float32(v)
+		case int:
+			vector[i] = float32(v)
+		default:
+			return nil, fmt.Errorf("vector component %d is not a number", i)
+		}
+	}
+	return vector, nil
+}
+
+// handleSearchByVector handles the search_by_vector tool: a nearVector
+// search with an embedding computed elsewhere, so the query is not embedded
+// again
+func (s *Server) handleSearchByVector(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	collection, ok := args["collection"].(string)
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+
+	vector, err := vectorArg(args["vector"])
+	if err != nil {
+		return nil, err
+	}
+
+	limit := getIntArg(args, "limit", 5)
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	if err := s.requireWeaviateDatabase(ctx, "search_by_vector"); err != nil {
+		return nil, err
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	weaviateResults, err := client.QueryNearVector(timeoutCtx, collection, vector, weaviate.QueryOptions{TopK: limit})
+	if err != nil {
+		return nil, s.enhanceError(ctx, "failed to search by vector", err)
+	}
+
+	// Results use the query_documents format plus the raw distance and certainty
+	results := make([]map[string]interface{}, 0, len(weaviateResults))
+	for _, res := range weaviateResults {
+		doc := vectordb.Document{ID: res.ID, Content: res.Content, Text: res.Content, Metadata: res.Metadata}
+		entry := map[string]interface{}{
+			"id":       doc.ID,
+			"content":  doc.Content,
+			"text":     doc.Text,
+			"url":      s.documentURL(ctx, collection, &doc),
+			"metadata": doc.Metadata,
+			"score":    res.Score,
+		}
+		if res.Distance != nil {
+			entry["distance"] = *res.Distance
+		}
+		if res.Certainty != nil {
+			entry["certainty"] = *res.Certainty
+		}
+		results = append(results, entry)
+	}
+
+	return map[string]interface{}{
+		"results":           results,
+		"count":             len(results),
+		"collection":        collection,
+		"vector_dimensions": len(vector),
+	}, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maximilien/weave-cli/src/pkg/vectordb"
+)
+
+// warmCacheNote explains which prefetched values outlive the call
+const warmCacheNote = "schemas and counts are read fresh on every call, so they are returned here but not cached"
+
+// handleWarmCache handles the warm_cache tool
+func (s *Server) handleWarmCache(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	refresh, _ := args["refresh"].(bool)
+
+	var names []string
+	if raw, ok := args["collections"]; ok {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("collections must be an array of collection names")
+		}
+		for _, item := range list {
+			name, ok := item.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("collections must be an array of collection names")
+			}
+			names = append(names, name)
+		}
+	}
+
+	// Create context with query operation timeout
+	timeoutCtx, cancel := s.createContextWithTimeout(ctx, vectordb.OperationTypeQuery)
+	defer cancel()
+
+	// Without a list, warm every collection
+	var collections []vectordb.CollectionInfo
+	if len(names) == 0 {
+		var err error
+		collections, err = s.db(ctx).ListCollections(timeoutCtx)
+		if err != nil {
+			return nil, s.enhanceError(ctx, "failed to list collections", err)
+		}
+		collections = sortCollectionsByName(collections)
+	} else {
+		for _, name := range names {
+			collections = append(collections, vectordb.CollectionInfo{Name: name})
+		}
+	}
+
+	isWeaviate := s.requireWeaviateDatabase(ctx, "search mode cache") == nil
+	entries := make([]map[string]interface{}, len(collections))
+	errs := s.forEachCollection(timeoutCtx, collections, func(i int, collection string) error {
+		entry, err := s.warmCollection(timeoutCtx, collection, isWeaviate, refresh)
+		entries[i] = entry
+		return err
+	})
+
+	warmed := make([]map[string]interface{}, 0, len(collections))
+	for _, entry := range entries {
+		if entry != nil {
+			warmed = append(warmed, entry)
+		}
+	}
+
+	caches := []string{}
+	if isWeaviate {
+		caches = append(caches, "search_modes")
+	}
+
+	response := map[string]interface{}{
+		"collections":  warmed,
+		"count":        len(warmed),
+		"caches":       caches,
+		"cached_note":  warmCacheNote,
+		"failed_count": len(collections) - len(warmed),
+	}
+	if failed := failedCollections(collections, errs); len(failed) > 0 {
+		response["failed_collections"] = failed
+	}
+	return response, nil
+}
+
+// warmCollection prefetches the schema and count of a collection and, on
+// Weaviate, probes its search modes into the shared search mode cache
+func (s *Server) warmCollection(ctx context.Context, collection string, isWeaviate, refresh bool) (map[string]interface{}, error) {
+	schema, err := s.getSchema(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	count, err := s.getCollectionCount(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection count: %w", err)
+	}
+
+	entry := map[string]interface{}{
+		"collection":     collection,
+		"document_count": count,
+	}
+	if schema != nil {
+		entry["properties"] = len(schema.Properties)
+	}
+	if !isWeaviate {
+		return entry, nil
+	}
+
+	if schema != nil {
+		entry["metadata_format"] = metadataFormatOf(schema)
+	}
+
+	client, err := s.newWeaviateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	capabilities, err := client.GetSearchCapabilities(ctx, collection, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe search modes: %w", err)
+	}
+	entry["search_modes"] = capabilities.Modes
+	entry["probed"] = capabilities.Probed
+	return entry, nil
+}
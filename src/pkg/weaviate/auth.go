@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Authentication modes for Weaviate requests
+const (
+	AuthModeAPIKey = "api_key" // Authorization: Bearer <APIKey>
+	AuthModeNone   = "none"    // anonymous access
+	AuthModeOIDC   = "oidc"    // OIDC client credentials flow
+)
+
+const (
+	// tokenRefreshMargin is how long before expiry an OIDC token is refreshed.
+	// Short-lived tokens are refreshed after three quarters of their lifetime.
+	tokenRefreshMargin = time.Minute
+	// tokenFetchTimeout bounds a single token request to the identity provider
+	tokenFetchTimeout = 30 * time.Second
+)
+
+// OIDCConfig holds OIDC client credentials used to obtain access tokens
+type OIDCConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// authMode returns the configured authentication mode, defaulting to api_key
+// when an API key is set and none otherwise
+func (c *Config) authMode() string {
+	if c.AuthMode != "" {
+		return c.AuthMode
+	}
+	if c.APIKey != "" {
+		return AuthModeAPIKey
+	}
+	return AuthModeNone
+}
+
+// tokenManager caches an OIDC access token with its expiry. It refreshes the
+// token in the background before it expires and on demand when a request is
+// rejected, so long-running servers keep working across token lifetimes.
+// It is safe for concurrent use.
+type tokenManager struct {
+	mu        sync.Mutex
+	fetch     func(ctx context.Context) (*oauth2.Token, error)
+	token     *oauth2.Token
+	refreshAt time.Time
+	timer     *time.Timer
+}
+
+func newTokenManager(fetch func(ctx context.Context) (*oauth2.Token, error)) *tokenManager {
+	return &tokenManager{fetch: fetch}
+}
+
+// Token returns the cached token, fetching a new one when there is none or
+// it is due for refresh
+func (m *tokenManager) Token() (*oauth2.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && (m.refreshAt.IsZero() || time.Now().Before(m.refreshAt)) {
+		return m.token, nil
+	}
+	return m.refreshLocked()
+}
+
+// forceRefresh fetches a new token after stale was rejected. Concurrent
+// callers holding the same stale token share a single refresh.
+func (m *tokenManager) forceRefresh(stale *oauth2.Token) (*oauth2.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && m.token != stale {
+		return m.token, nil
+	}
+	return m.refreshLocked()
+}
+
+// refreshLocked fetches a token and schedules its background refresh. m.mu
+// must be held. On failure the previous token is kept.
+func (m *tokenManager) refreshLocked() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenFetchTimeout)
+	defer cancel()
+
+	token, err := m.fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain OIDC token: %w", err)
+	}
+
+	m.token = token
+	m.refreshAt = time.Time{}
+	if !token.Expiry.IsZero() {
+		margin := time.Until(token.Expiry) / 4
+		if margin > tokenRefreshMargin {
+			margin = tokenRefreshMargin
+		}
+		m.refreshAt = token.Expiry.Add(-margin)
+	}
+
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	if delay := time.Until(m.refreshAt); !m.refreshAt.IsZero() && delay > 0 {
+		m.timer = time.AfterFunc(delay, m.backgroundRefresh)
+	}
+	return token, nil
+}
+
+// backgroundRefresh refreshes the token ahead of expiry. Errors are left for
+// the next Token call to retry.
+func (m *tokenManager) backgroundRefresh() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+}
+
+// tokenManagers shares one token manager per set of OIDC credentials, so
+// short-lived clients reuse tokens and background refreshes are not duplicated
+var tokenManagers = struct {
+	sync.Mutex
+	byCredentials map[string]*tokenManager
+}{byCredentials: make(map[string]*tokenManager)}
+
+// sharedTokenManager returns the token manager for OIDC credentials
+func sharedTokenManager(oidc *OIDCConfig) *tokenManager {
+	key := strings.Join([]string{oidc.TokenURL, oidc.ClientID, oidc.ClientSecret, strings.Join(oidc.Scopes, " ")}, "\x00")
+
+	tokenManagers.Lock()
+	defer tokenManagers.Unlock()
+
+	if manager, ok := tokenManagers.byCredentials[key]; ok {
+		return manager
+	}
+
+	credentials := &clientcredentials.Config{
+		ClientID:     oidc.ClientID,
+		ClientSecret: oidc.ClientSecret,
+		TokenURL:     oidc.TokenURL,
+		Scopes:       oidc.Scopes,
+	}
+	manager := newTokenManager(credentials.Token)
+	tokenManagers.byCredentials[key] = manager
+	return manager
+}
+
+// newTokenManagerForConfig returns the OIDC token manager for a config, or
+// nil when the config does not use OIDC
+func newTokenManagerForConfig(config *Config) (*tokenManager, error) {
+	switch config.authMode() {
+	case AuthModeAPIKey:
+		if config.APIKey == "" {
+			return nil, fmt.Errorf("auth mode api_key requires an API key")
+		}
+		return nil, nil
+	case AuthModeNone:
+		return nil, nil
+	case AuthModeOIDC:
+		if config.OIDC == nil || config.OIDC.TokenURL == "" || config.OIDC.ClientID == "" || config.OIDC.ClientSecret == "" {
+			return nil, fmt.Errorf("auth mode oidc requires a token URL, client ID, and client secret")
+		}
+		return sharedTokenManager(config.OIDC), nil
+	default:
+		return nil, fmt.Errorf("unknown auth mode '%s' (expected api_key, none, or oidc)", config.AuthMode)
+	}
+}
+
+// oidcTransport sets OIDC bearer tokens on requests made through the official
+// client and retries once with a refreshed token on 401
+type oidcTransport struct {
+	tokens *tokenManager
+	base   http.RoundTripper
+}
+
+func (t *oidcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return doWithToken(t.tokens, req, t.base.RoundTrip)
+}
+
+// doWithToken sends req with the current token. When the token is rejected
+// with 401, it forces a refresh and retries once if the body can be replayed.
+func doWithToken(tokens *tokenManager, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	token, err := tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := send(withBearer(req, token.AccessToken))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, err = tokens.forceRefresh(token)
+	if err != nil {
+		return resp, nil
+	}
+	retry := withBearer(req, token.AccessToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	return send(retry)
+}
+
+// withBearer returns a copy of req with a bearer Authorization header
+func withBearer(req *http.Request, accessToken string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+accessToken)
+	return clone
+}
+
+// AccessToken returns a current OIDC access token, refreshing it if needed.
+// It fails when the client does not use OIDC.
+func (c *Client) AccessToken() (string, error) {
+	if c.tokens == nil {
+		return "", fmt.Errorf("client does not use OIDC authentication")
+	}
+	token, err := c.tokens.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// do sends a direct REST/GraphQL request over the client's shared connection
+// pool with its authentication. With OIDC, a 401 triggers one retry with a
+// refreshed token.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.tokens != nil {
+		return doWithToken(c.tokens, req, c.httpClient.Do)
+	}
+	if c.config.authMode() == AuthModeAPIKey {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+	return c.httpClient.Do(req)
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// headerRecorder records the Authorization header of every request it serves
+type headerRecorder struct {
+	mu      sync.Mutex
+	headers []string
+	next    http.Handler
+}
+
+func (h *headerRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.headers = append(h.headers, r.Header.Get("Authorization"))
+	h.mu.Unlock()
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *headerRecorder) last() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.headers) == 0 {
+		return ""
+	}
+	return h.headers[len(h.headers)-1]
+}
+
+// newTokenServer starts an OAuth2 token endpoint issuing numbered tokens
+// that expire after expiresIn seconds
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+// rejectToken wraps a handler, answering 401 to requests carrying a token
+type rejectToken struct {
+	token string
+	next  http.Handler
+}
+
+func (h *rejectToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "Bearer "+h.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// TestAuthModes tests the Authorization header sent in each auth mode, on both
+// direct REST calls and calls through the official client
+func TestAuthModes(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(t *testing.T, config *Config, handlers ...func(http.Handler) http.Handler) (*Client, *headerRecorder) {
+		t.Helper()
+		var handler http.Handler = &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		for _, wrap := range handlers {
+			handler = wrap(handler)
+		}
+		recorder := &headerRecorder{next: handler}
+		server := httptest.NewServer(recorder)
+		t.Cleanup(server.Close)
+
+		config.URL = server.URL
+		client, err := NewClient(config)
+		require.NoError(t, err)
+		return client, recorder
+	}
+
+	t.Run("api_key sends the API key", func(t *testing.T) {
+		client, recorder := newClient(t, &Config{APIKey: "secret"})
+
+		_, err := client.buildMetadataQuery(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", recorder.last())
+
+		_, err = client.GetFullCollectionSchema(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", recorder.last())
+	})
+
+	t.Run("none sends no Authorization header", func(t *testing.T) {
+		client, recorder := newClient(t, &Config{AuthMode: AuthModeNone, APIKey: "ignored"})
+
+		_, err := client.buildMetadataQuery(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Empty(t, recorder.last())
+
+		_, err = client.GetFullCollectionSchema(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Empty(t, recorder.last())
+	})
+
+	t.Run("oidc reuses a token until it is due for refresh", func(t *testing.T) {
+		tokenServer, issued := newTokenServer(t, 3600)
+		client, recorder := newClient(t, &Config{
+			AuthMode: AuthModeOIDC,
+			OIDC:     &OIDCConfig{TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"},
+		})
+
+		_, err := client.buildMetadataQuery(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", recorder.last())
+
+		_, err = client.GetFullCollectionSchema(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", recorder.last())
+		assert.Equal(t, int32(1), atomic.LoadInt32(issued))
+	})
+
+	t.Run("oidc retries once with a refreshed token on 401", func(t *testing.T) {
+		tokenServer, issued := newTokenServer(t, 3600)
+		reject := func(next http.Handler) http.Handler { return &rejectToken{token: "token-1", next: next} }
+		client, recorder := newClient(t, &Config{
+			AuthMode: AuthModeOIDC,
+			OIDC:     &OIDCConfig{TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"},
+		}, reject)
+
+		_, err := client.buildMetadataQuery(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-2", recorder.last())
+
+		_, err = client.GetFullCollectionSchema(ctx, "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-2", recorder.last())
+		assert.Equal(t, int32(2), atomic.LoadInt32(issued))
+	})
+
+	t.Run("oidc token errors fail the request", func(t *testing.T) {
+		client, _ := newClient(t, &Config{
+			AuthMode: AuthModeOIDC,
+			OIDC:     &OIDCConfig{TokenURL: "http://127.0.0.1:1/token", ClientID: "id", ClientSecret: "secret"},
+		})
+
+		_, err := client.buildMetadataQuery(ctx, "Docs")
+		assert.ErrorContains(t, err, "failed to obtain OIDC token")
+	})
+
+	t.Run("invalid configurations are rejected", func(t *testing.T) {
+		_, err := NewClient(&Config{URL: "http://localhost:8080", AuthMode: AuthModeAPIKey})
+		assert.Error(t, err)
+
+		_, err = NewClient(&Config{URL: "http://localhost:8080", AuthMode: AuthModeOIDC})
+		assert.Error(t, err)
+
+		_, err = NewClient(&Config{URL: "http://localhost:8080", AuthMode: "basic"})
+		assert.Error(t, err)
+	})
+}
+
+// countingFetch returns a token fetch function issuing numbered tokens that
+// expire after lifetime
+func countingFetch(lifetime time.Duration) (func(ctx context.Context) (*oauth2.Token, error), *int32) {
+	var fetched int32
+	return func(ctx context.Context) (*oauth2.Token, error) {
+		n := atomic.AddInt32(&fetched, 1)
+		return &oauth2.Token{
+			AccessToken: fmt.Sprintf("token-%d", n),
+			Expiry:      time.Now().Add(lifetime),
+		}, nil
+	}, &fetched
+}
+
+// TestTokenManager tests OIDC token caching, expiry, and refresh
+func TestTokenManager(t *testing.T) {
+	t.Run("refreshes expired tokens on demand", func(t *testing.T) {
+		fetch, fetched := countingFetch(-time.Second)
+		manager := newTokenManager(fetch)
+
+		first, err := manager.Token()
+		require.NoError(t, err)
+		second, err := manager.Token()
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.AccessToken, second.AccessToken)
+		assert.Equal(t, int32(2), atomic.LoadInt32(fetched))
+	})
+
+	t.Run("refreshes in the background before expiry", func(t *testing.T) {
+		fetch, fetched := countingFetch(200 * time.Millisecond)
+		manager := newTokenManager(fetch)
+
+		token, err := manager.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token.AccessToken)
+
+		// The refresh is due after three quarters of the lifetime
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(fetched) >= 2 }, time.Second, 10*time.Millisecond)
+
+		token, err = manager.Token()
+		require.NoError(t, err)
+		assert.NotEqual(t, "token-1", token.AccessToken)
+	})
+
+	t.Run("concurrent requests share one token", func(t *testing.T) {
+		fetch, fetched := countingFetch(time.Hour)
+		manager := newTokenManager(fetch)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := manager.Token()
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(fetched))
+	})
+
+	t.Run("forced refreshes of the same stale token are shared", func(t *testing.T) {
+		fetch, fetched := countingFetch(time.Hour)
+		manager := newTokenManager(fetch)
+
+		stale, err := manager.Token()
+		require.NoError(t, err)
+
+		first, err := manager.forceRefresh(stale)
+		require.NoError(t, err)
+		second, err := manager.forceRefresh(stale)
+		require.NoError(t, err)
+
+		assert.Equal(t, "token-2", first.AccessToken)
+		assert.Equal(t, first, second)
+		assert.Equal(t, int32(2), atomic.LoadInt32(fetched))
+	})
+
+	t.Run("failed refreshes keep the previous token", func(t *testing.T) {
+		fail := false
+		manager := newTokenManager(func(ctx context.Context) (*oauth2.Token, error) {
+			if fail {
+				return nil, fmt.Errorf("identity provider unavailable")
+			}
+			return &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}, nil
+		})
+
+		stale, err := manager.Token()
+		require.NoError(t, err)
+
+		fail = true
+		_, err = manager.forceRefresh(stale)
+		assert.ErrorContains(t, err, "failed to obtain OIDC token")
+
+		token, err := manager.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token", token.AccessToken)
+	})
+}
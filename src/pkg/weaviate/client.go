@@ -6,6 +6,7 @@ package weaviate
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -21,9 +22,10 @@ type FieldDefinition struct {
 
 // CollectionSchema represents a collection schema
 type CollectionSchema struct {
-	Class      string           `json:"class"`
-	Vectorizer string           `json:"vectorizer,omitempty"`
-	Properties []SchemaProperty `json:"properties"`
+	Class             string             `json:"class"`
+	Vectorizer        string             `json:"vectorizer,omitempty"`
+	Properties        []SchemaProperty   `json:"properties"`
+	VectorIndexConfig *VectorIndexConfig `json:"vectorIndexConfig,omitempty"`
 }
 
 // SchemaProperty represents a property in a collection schema
@@ -33,12 +35,17 @@ type SchemaProperty struct {
 	Description      string                 `json:"description,omitempty" yaml:"description,omitempty"`
 	NestedProperties []SchemaProperty       `json:"nestedProperties,omitempty" yaml:"nestedproperties,omitempty"`
 	JSONSchema       map[string]interface{} `json:"json_schema,omitempty" yaml:"json_schema,omitempty"`
+	Tokenization     string                 `json:"tokenization,omitempty" yaml:"tokenization,omitempty"`
 }
 
 // Client wraps the Weaviate client with additional functionality
 type Client struct {
 	client *weaviate.Client
 	config *Config
+	tokens *tokenManager // set for the oidc auth mode
+
+	// httpClient sends the direct REST/GraphQL requests, reusing pooled connections
+	httpClient *http.Client
 }
 
 // Config holds Weaviate client configuration
@@ -46,6 +53,34 @@ type Config struct {
 	URL          string
 	APIKey       string
 	OpenAIAPIKey string
+
+	// AuthMode is api_key, none, or oidc (default: api_key if APIKey is set, else none)
+	AuthMode string
+	// OIDC holds client credentials for the oidc auth mode
+	OIDC *OIDCConfig
+	// SearchFallback lists the searches tried, in order, when the requested
+	// search mode fails (nil: hybrid then simple; empty: no fallback)
+	SearchFallback []string
+	// MaxFallbackDepth bounds how many fallbacks run after the requested
+	// search mode fails (0: the whole chain)
+	MaxFallbackDepth int
+	// ScoreNormalization is the strategy applied to raw similarity scores
+	// (empty: DefaultScoreNormalization)
+	ScoreNormalization string
+
+	// ContentFields overrides DefaultContentFields for all collections
+	ContentFields []string
+	// CollectionContentFields overrides the content field order per collection
+	CollectionContentFields map[string][]string
+	// CompressImageData stores image_data as gzip+base64, marked in metadata
+	CompressImageData bool
+
+	// MaxIdleConnsPerHost bounds the idle REST connections kept for reuse
+	// (0: DefaultMaxIdleConnsPerHost)
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes idle REST connections after this long
+	// (0: DefaultIdleConnTimeout)
+	IdleConnTimeout time.Duration
 }
 
 // SchemaType represents the type of collection schema
@@ -61,6 +96,19 @@ func NewClient(config *Config) (*Client, error) {
 	var client *weaviate.Client
 	var err error
 
+	if err = ValidateSearchFallback(config.SearchFallback); err != nil {
+		return nil, err
+	}
+	if err = ValidateMaxFallbackDepth(config.MaxFallbackDepth); err != nil {
+		return nil, err
+	}
+	if err = ValidateScoreNormalization(config.ScoreNormalization); err != nil {
+		return nil, err
+	}
+	if err = ValidateHTTPTransport(config.MaxIdleConnsPerHost, config.IdleConnTimeout); err != nil {
+		return nil, err
+	}
+
 	// Parse URL to extract host and scheme
 	host := config.URL
 	scheme := "http"
@@ -74,44 +122,64 @@ func NewClient(config *Config) (*Client, error) {
 		scheme = "https"
 	}
 
-	if config.APIKey != "" {
+	tokens, err := newTokenManagerForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	transport := sharedTransport(config)
+
+	clientConfig := weaviate.Config{
+		Host:   host,
+		Scheme: scheme,
+	}
+
+	switch {
+	case config.authMode() == AuthModeAPIKey:
 		// Use API key authentication for Weaviate Cloud
-		headers := map[string]string{
-			"X-Openai-Api-Key": config.OpenAIAPIKey,
+		clientConfig.AuthConfig = auth.ApiKey{
+			Value: config.APIKey,
 		}
-
-		// Add cluster URL header for Weaviate Cloud Serverless (text2vec-weaviate vectorizer)
-		// The cluster URL is the full URL including scheme
-		if scheme == "https" {
-			headers["X-Weaviate-Cluster-Url"] = scheme + "://" + host
+		clientConfig.Headers = cloudHeaders(config, scheme, host)
+	case tokens != nil:
+		// Use OIDC access tokens, refreshed before expiry and on 401
+		clientConfig.ConnectionClient = &http.Client{
+			Transport: &oidcTransport{tokens: tokens, base: transport},
+		}
+		clientConfig.Headers = cloudHeaders(config, scheme, host)
+	case config.OpenAIAPIKey != "":
+		// Anonymous access; vectorizers may still need the OpenAI key
+		clientConfig.Headers = map[string]string{
+			"X-Openai-Api-Key": config.OpenAIAPIKey,
 		}
-
-		client, err = weaviate.NewClient(weaviate.Config{
-			Host:   host,
-			Scheme: scheme,
-			AuthConfig: auth.ApiKey{
-				Value: config.APIKey,
-			},
-			Headers: headers,
-		})
-	} else {
-		// Use no authentication for local Weaviate
-		client, err = weaviate.NewClient(weaviate.Config{
-			Host:   host,
-			Scheme: scheme,
-		})
 	}
 
+	client, err = weaviate.NewClient(clientConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Weaviate client: %w", err)
 	}
 
 	return &Client{
-		client: client,
-		config: config,
+		client:     client,
+		config:     config,
+		tokens:     tokens,
+		httpClient: &http.Client{Transport: transport, Timeout: restRequestTimeout},
 	}, nil
 }
 
+// cloudHeaders returns the extra headers sent to authenticated Weaviate instances
+func cloudHeaders(config *Config, scheme, host string) map[string]string {
+	headers := map[string]string{
+		"X-Openai-Api-Key": config.OpenAIAPIKey,
+	}
+
+	// Add cluster URL header for Weaviate Cloud Serverless (text2vec-weaviate vectorizer)
+	// The cluster URL is the full URL including scheme
+	if scheme == "https" {
+		headers["X-Weaviate-Cluster-Url"] = scheme + "://" + host
+	}
+	return headers
+}
+
 // Health checks the health of the Weaviate instance
 func (c *Client) Health(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -42,6 +42,7 @@ func (c *Client) DeleteCollection(ctx context.Context, collectionName string) er
 		return fmt.Errorf("failed to create weave client: %w", err)
 	}
 
+	c.invalidateCollectionCaches(collectionName)
 	return weaveClient.DeleteCollection(ctx, collectionName)
 }
 
@@ -56,6 +57,7 @@ func (c *Client) DeleteCollectionSchema(ctx context.Context, collectionName stri
 		return fmt.Errorf("failed to create weave client: %w", err)
 	}
 
+	c.invalidateCollectionCaches(collectionName)
 	return weaveClient.DeleteCollectionSchema(ctx, collectionName)
 }
 
@@ -82,6 +84,7 @@ func (c *Client) CreateCollectionWithSchema(ctx context.Context, collectionName,
 	}
 
 	// Create the collection using Weaviate's REST API
+	c.invalidateCollectionCaches(collectionName)
 	err = c.createCollectionViaREST(ctx, collectionName, embeddingModel, customFields, schemaType)
 	if err != nil {
 		return fmt.Errorf("failed to create collection '%s': %w", collectionName, err)
@@ -222,13 +225,9 @@ func (c *Client) createCollectionViaREST(ctx context.Context, collectionName, em
 
 	// Add headers
 	req.Header.Set("Content-Type", "application/json")
-	if c.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	}
 
 	// Make the request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
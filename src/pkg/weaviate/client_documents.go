@@ -4,104 +4,70 @@
 package weaviate
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Document represents a document in Weaviate
 type Document struct {
-	ID        string                 `json:"id"`
-	Text      string                 `json:"text"`
-	Content   string                 `json:"content"`
-	Image     string                 `json:"image"`
-	ImageData string                 `json:"image_data"`
-	URL       string                 `json:"url"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	ID           string                 `json:"id"`
+	Text         string                 `json:"text"`
+	Content      string                 `json:"content"`
+	ContentField string                 `json:"content_field,omitempty"` // Property Content was extracted from, or is created in
+	Image        string                 `json:"image"`
+	ImageData    string                 `json:"image_data"`
+	URL          string                 `json:"url"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	// Properties are additional top-level properties set on create
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// ContentSizeBytes is the size of the content returned by a listing
+	ContentSizeBytes int `json:"content_size_bytes"`
+	// HasLargeFields is set by listings that left out large fields, which
+	// hold placeholders; fetch the document to read them
+	HasLargeFields bool `json:"has_large_fields"`
+	// Vector is the document's embedding, only set by GetDocumentWithVector
+	Vector []float32 `json:"vector,omitempty"`
 }
 
+// Placeholders listings put in metadata for large fields that were not fetched
+const (
+	ExcludedContentPlaceholder = "[large content excluded for performance]"
+	ExcludedBase64Placeholder  = "[base64 data excluded for performance]"
+)
+
 // ListDocuments returns a list of documents in a collection
 // Note: Currently shows document IDs only. To show actual document content/metadata,
 // we would need to implement dynamic schema discovery for each collection.
 func (c *Client) ListDocuments(ctx context.Context, collectionName string, limit int) ([]Document, error) {
-	// Try the basic method first
-	documents, err := c.listDocumentsBasic(ctx, collectionName, limit)
+	// Check the count first so empty collections return early without the
+	// expensive property-discovery listing
+	count, err := c.CountDocuments(ctx, collectionName)
 	if err != nil {
-		// If the basic method fails, try a simpler approach for empty collections
-		if strings.Contains(err.Error(), "chunk_index") || strings.Contains(err.Error(), "not found") {
-			// Try using the aggregation API to check if collection exists
-			aggregationQuery := fmt.Sprintf(`
-				{
-					Aggregate {
-						%s {
-							meta {
-								count
-							}
-						}
-					}
-				}
-			`, collectionName)
-
-			result, queryErr := c.client.GraphQL().Raw().WithQuery(aggregationQuery).Do(ctx)
-			if queryErr != nil {
-				// If aggregation also fails, try a different approach
-				// Use a query with limit 1 to see if collection exists
-				simpleQuery := fmt.Sprintf(`
-					{
-						Get {
-							%s(limit: 1) {
-								_additional {
-									id
-								}
-							}
-						}
-					}
-				`, collectionName)
-
-				result, queryErr = c.client.GraphQL().Raw().WithQuery(simpleQuery).Do(ctx)
-				if queryErr != nil {
-					// If even the simple query fails, return the original error
-					return nil, err
-				}
-
-				// Check if we got any results
-				if data, ok := result.Data["Get"].(map[string]interface{}); ok {
-					if _, ok := data[collectionName].([]interface{}); ok {
-						// Collection exists but is empty
-						return []Document{}, nil
-					}
-				}
-			} else {
-				// Aggregation worked, check the count
-				if data, ok := result.Data["Aggregate"].(map[string]interface{}); ok {
-					if collectionData, ok := data[collectionName].([]interface{}); ok {
-						if len(collectionData) > 0 {
-							if meta, ok := collectionData[0].(map[string]interface{}); ok {
-								if count, ok := meta["meta"].(map[string]interface{}); ok {
-									if countVal, ok := count["count"].(float64); ok && countVal == 0 {
-										// Collection exists but is empty
-										return []Document{}, nil
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
 		return nil, err
 	}
+	if count == 0 {
+		return []Document{}, nil
+	}
 
-	return documents, nil
+	return c.listDocumentsBasic(ctx, collectionName, limit)
 }
 
 // CountDocuments efficiently counts documents in a collection without fetching content
 // This is much faster than ListDocuments for large collections with heavy data
 func (c *Client) CountDocuments(ctx context.Context, collectionName string) (int, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return 0, err
+	}
+
 	// Use Weaviate's aggregation API to count documents efficiently
 	// This doesn't fetch the actual document content, just counts them
 	query := fmt.Sprintf(`
@@ -174,6 +140,10 @@ func (c *Client) CountDocuments(ctx context.Context, collectionName string) (int
 
 // listDocumentsBasic fetches documents with actual properties (excluding large fields)
 func (c *Client) listDocumentsBasic(ctx context.Context, collectionName string, limit int) ([]Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	// First, get the schema to know what fields are available
 	properties, err := c.GetCollectionSchema(ctx, collectionName)
 	if err != nil {
@@ -200,7 +170,11 @@ func (c *Client) listDocumentsBasic(ctx context.Context, collectionName string,
 	`, collectionName, limit)
 
 	// Add available properties to the query, excluding large fields
+	var largeFields []string
 	for _, prop := range properties {
+		if excludedFields[prop] {
+			largeFields = append(largeFields, prop)
+		}
 		if !excludedFields[prop] {
 			if prop == "metadata" {
 				// Dynamically discover metadata schema and build appropriate query
@@ -227,7 +201,9 @@ func (c *Client) listDocumentsBasic(ctx context.Context, collectionName string,
 	if err != nil {
 		// Check for metadata field type mismatch error
 		if strings.Contains(err.Error(), "must not have a sub selection") && strings.Contains(err.Error(), "metadata") {
-			// Retry with simple metadata field (for old collections with string metadata)
+			// The cached metadata schema is stale; drop it and retry with the
+			// simple metadata field (for old collections with string metadata)
+			c.InvalidateMetadataSchema(collectionName)
 			return c.listDocumentsWithSimpleMetadata(ctx, collectionName, limit, properties, excludedFields)
 		}
 		// Check for common connection errors and provide better messages
@@ -284,35 +260,26 @@ func (c *Client) listDocumentsBasic(ctx context.Context, collectionName string,
 					// Extract all properties as metadata
 					doc.Metadata = make(map[string]interface{})
 					doc.Metadata["id"] = doc.ID
-
-					// Extract content from common field names
-					contentFields := []string{"text", "content", "body", "description", "title", "name", "chunk", "pageContent", "document"}
-					doc.Content = ""
-
 					for key, value := range itemMap {
 						if key != "_additional" {
 							doc.Metadata[key] = value
-
-							// Try to find content in common field names
-							for _, field := range contentFields {
-								if key == field {
-									if str, ok := value.(string); ok && str != "" {
-										doc.Content = str
-										break
-									}
-								}
-							}
 						}
 					}
 
-					// Add placeholders for excluded large fields to indicate they exist
-					if isImageCollection(collectionName) {
-						doc.Metadata["image"] = "[base64 data excluded for performance]"
-						doc.Metadata["base64_data"] = "[base64 data excluded for performance]"
-					}
-					if doc.Metadata["content"] == nil {
-						doc.Metadata["content"] = "[large content excluded for performance]"
+					// Extract content from the first non-empty content field, in priority order
+					doc.Content, doc.ContentField = ExtractContent(itemMap, c.contentFieldsFor(collectionName))
+
+					// Add placeholders for the large fields of the collection that were
+					// left out, and flag them so callers know to fetch the document
+					for _, field := range largeFields {
+						if field == "content" {
+							doc.Metadata[field] = ExcludedContentPlaceholder
+						} else {
+							doc.Metadata[field] = ExcludedBase64Placeholder
+						}
 					}
+					doc.HasLargeFields = len(largeFields) > 0
+					doc.ContentSizeBytes = len(doc.Content)
 
 					// If no content found, create a summary
 					if doc.Content == "" {
@@ -328,29 +295,37 @@ func (c *Client) listDocumentsBasic(ctx context.Context, collectionName string,
 	return documents, nil
 }
 
-// buildMetadataQuery dynamically discovers the metadata schema and builds the appropriate GraphQL query
+// buildMetadataQuery returns the GraphQL selection for a collection's
+// metadata property, from the metadata schema cache when possible
 func (c *Client) buildMetadataQuery(ctx context.Context, collectionName string) (string, error) {
-	// Always check the actual schema first to determine metadata field type
+	info, err := c.metadataSchema(ctx, collectionName)
+	if err != nil {
+		return "", err
+	}
+	return info.query, nil
+}
 
+// fetchMetadataSchema discovers the metadata property of a collection via
+// the REST API and builds the appropriate GraphQL selection for it
+func (c *Client) fetchMetadataSchema(ctx context.Context, collectionName string) (metadataSchemaInfo, error) {
 	// Get the collection schema via REST API to understand the metadata structure
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/schema/%s", c.config.URL, collectionName), nil)
 	if err != nil {
-		return "\n\t\t\t\tmetadata", nil
+		return metadataSchemaInfo{}, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
 	if c.config.OpenAIAPIKey != "" {
 		req.Header.Set("X-Openai-Api-Key", c.config.OpenAIAPIKey)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return "\n\t\t\t\tmetadata", nil
+		return metadataSchemaInfo{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "\n\t\t\t\tmetadata", nil
+		return metadataSchemaInfo{}, fmt.Errorf("failed to get schema for %s: status %d", collectionName, resp.StatusCode)
 	}
 
 	var schema struct {
@@ -364,7 +339,7 @@ func (c *Client) buildMetadataQuery(ctx context.Context, collectionName string)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
-		return "\n\t\t\t\tmetadata", nil
+		return metadataSchemaInfo{}, fmt.Errorf("failed to decode schema for %s: %w", collectionName, err)
 	}
 
 	// Find the metadata property
@@ -386,7 +361,7 @@ func (c *Client) buildMetadataQuery(ctx context.Context, collectionName string)
 				// For image collections or collections with image fields, use a simplified metadata query
 				if isImageCollection(collectionName) || hasImageFields {
 					// Provide basic sub-selection for metadata object, avoiding complex nested objects
-					return "\n\t\t\t\tmetadata {\n\t\t\t\t\tfilename\n\t\t\t\t\tfile_size\n\t\t\t\t\tcontent_type\n\t\t\t\t\tdate_added\n\t\t\t\t\tsource_document\n\t\t\t\t\timage_index\n\t\t\t\t\tis_extracted_from_document\n\t\t\t\t}", nil
+					return metadataSchemaInfo{format: MetadataFormatObject, query: "\n\t\t\t\tmetadata {\n\t\t\t\t\tfilename\n\t\t\t\t\tfile_size\n\t\t\t\t\tcontent_type\n\t\t\t\t\tdate_added\n\t\t\t\t\tsource_document\n\t\t\t\t\timage_index\n\t\t\t\t\tis_extracted_from_document\n\t\t\t\t}"}, nil
 				}
 
 				// Build query with available nested properties
@@ -401,20 +376,28 @@ func (c *Client) buildMetadataQuery(ctx context.Context, collectionName string)
 						query += fmt.Sprintf("\t\t\t\t\t%s\n", field)
 					}
 					query += "\t\t\t\t}"
-					return query, nil
+					return metadataSchemaInfo{format: MetadataFormatObject, query: query}, nil
 				}
 			}
 			// If it's not an object (e.g., string type) or has no nested properties, use simple metadata
-			return "\n\t\t\t\tmetadata", nil
+			format := MetadataFormatText
+			if len(prop.DataType) > 0 && prop.DataType[0] == "object" {
+				format = MetadataFormatObject
+			}
+			return metadataSchemaInfo{format: format, query: simpleMetadataQuery}, nil
 		}
 	}
 
 	// If metadata property not found, use simple metadata
-	return "\n\t\t\t\tmetadata", nil
+	return metadataSchemaInfo{format: MetadataFormatText, query: simpleMetadataQuery}, nil
 }
 
 // listDocumentsWithSimpleMetadata handles collections with string metadata (old format)
 func (c *Client) listDocumentsWithSimpleMetadata(ctx context.Context, collectionName string, limit int, properties []string, excludedFields map[string]bool) ([]Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	// Build a query with simple metadata field (no sub-selection)
 	query := fmt.Sprintf(`
 		{
@@ -495,6 +478,10 @@ func (c *Client) listDocumentsWithSimpleMetadata(ctx context.Context, collection
 
 // listDocumentsSimple is a fallback method that only gets IDs
 func (c *Client) listDocumentsSimple(ctx context.Context, collectionName string, limit int) ([]Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
 		{
 			Get {
@@ -575,14 +562,31 @@ func (c *Client) listDocumentsSimple(ctx context.Context, collectionName string,
 
 // GetDocument retrieves a specific document by ID
 func (c *Client) GetDocument(ctx context.Context, collectionName, documentID string) (*Document, error) {
+	return c.getDocument(ctx, collectionName, documentID, false)
+}
+
+// GetDocumentWithVector retrieves a specific document by ID along with its
+// embedding. Vectors are large, so only ask for them when needed.
+func (c *Client) GetDocumentWithVector(ctx context.Context, collectionName, documentID string) (*Document, error) {
+	return c.getDocument(ctx, collectionName, documentID, true)
+}
+
+// getDocument retrieves a document, selecting its vector when includeVector is set
+func (c *Client) getDocument(ctx context.Context, collectionName, documentID string, includeVector bool) (*Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	vectorField := vectorSelection(QueryOptions{IncludeVector: includeVector})
+
 	// First, get the schema to know what fields are available
 	properties, err := c.GetCollectionSchema(ctx, collectionName)
 	if err != nil {
 		// If we can't get schema, fall back to a simple ID-only query
-		return c.getDocumentSimple(ctx, collectionName, documentID)
+		return c.getDocumentSimple(ctx, collectionName, documentID, vectorField)
 	}
 
 	// Build a query with the actual properties from the schema
@@ -592,12 +596,12 @@ func (c *Client) GetDocument(ctx context.Context, collectionName, documentID str
 				%s(where: {
 					path: ["id"]
 					operator: Equal
-					valueString: "%s"
+					valueString: %s
 				}) {
 					_additional {
-						id
+						id%s
 					}
-	`, collectionName, documentID)
+	`, collectionName, graphQLString(documentID), vectorField)
 
 	// Add all available properties to the query
 	for _, prop := range properties {
@@ -624,7 +628,7 @@ func (c *Client) GetDocument(ctx context.Context, collectionName, documentID str
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
 		// If the schema-based query fails, fall back to simple query
-		return c.getDocumentSimple(ctx, collectionName, documentID)
+		return c.getDocumentSimple(ctx, collectionName, documentID, vectorField)
 	}
 
 	var document *Document
@@ -634,42 +638,36 @@ func (c *Client) GetDocument(ctx context.Context, collectionName, documentID str
 				if itemMap, ok := collectionData[0].(map[string]interface{}); ok {
 					doc := Document{}
 
-					// Extract ID
+					// Extract ID and, when selected, the vector
 					if additional, ok := itemMap["_additional"].(map[string]interface{}); ok {
 						if id, ok := additional["id"].(string); ok {
 							doc.ID = id
 						}
+						doc.Vector = parseVector(additional["vector"])
 					}
 
 					// Extract all properties as metadata
 					doc.Metadata = make(map[string]interface{})
 					doc.Metadata["id"] = doc.ID
-
-					// Extract content from common field names
-					contentFields := []string{"text", "content", "body", "description", "title", "name", "chunk", "pageContent", "document"}
-					doc.Content = ""
-
 					for key, value := range itemMap {
 						if key != "_additional" {
 							doc.Metadata[key] = value
-
-							// Try to find content in common field names
-							for _, field := range contentFields {
-								if key == field {
-									if str, ok := value.(string); ok && str != "" {
-										doc.Content = str
-										break
-									}
-								}
-							}
 						}
 					}
 
+					// Extract content from the first non-empty content field, in priority order
+					doc.Content, doc.ContentField = ExtractContent(itemMap, c.contentFieldsFor(collectionName))
+
 					// If no content found, create a summary
 					if doc.Content == "" {
 						doc.Content = fmt.Sprintf("Document ID: %s", doc.ID)
 					}
 
+					// Return image data decompressed, whatever its stored encoding
+					if err := decodeImageData(&doc, itemMap); err != nil {
+						return nil, err
+					}
+
 					document = &doc
 				}
 			}
@@ -683,23 +681,28 @@ func (c *Client) GetDocument(ctx context.Context, collectionName, documentID str
 	return document, nil
 }
 
-// getDocumentSimple is a fallback method that only gets IDs
-func (c *Client) getDocumentSimple(ctx context.Context, collectionName, documentID string) (*Document, error) {
+// getDocumentSimple is a fallback method that only gets IDs, and the vector
+// when vectorField selects it
+func (c *Client) getDocumentSimple(ctx context.Context, collectionName, documentID, vectorField string) (*Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
 		{
 			Get {
 				%s(where: {
 					path: ["id"]
 					operator: Equal
-					valueString: "%s"
+					valueString: %s
 				}) {
 					_additional {
-						id
+						id%s
 					}
 				}
 			}
 		}
-	`, collectionName, documentID)
+	`, collectionName, graphQLString(documentID), vectorField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
@@ -713,11 +716,12 @@ func (c *Client) getDocumentSimple(ctx context.Context, collectionName, document
 				if itemMap, ok := collectionData[0].(map[string]interface{}); ok {
 					doc := Document{}
 
-					// Extract ID
+					// Extract ID and, when selected, the vector
 					if additional, ok := itemMap["_additional"].(map[string]interface{}); ok {
 						if id, ok := additional["id"].(string); ok {
 							doc.ID = id
 						}
+						doc.Vector = parseVector(additional["vector"])
 					}
 
 					// For fallback, we just have the ID
@@ -753,15 +757,10 @@ func (c *Client) DeleteDocument(ctx context.Context, collectionName, documentID
 		return fmt.Errorf("failed to create delete request: %w", err)
 	}
 
-	// Add authorization header
-	if c.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete document %s from collection %s: %w", documentID, collectionName, err)
 	}
@@ -786,10 +785,33 @@ func (c *Client) DeleteDocument(ctx context.Context, collectionName, documentID
 	return fmt.Errorf("failed to delete document %s from collection %s: HTTP %d - %s", documentID, collectionName, resp.StatusCode, string(body))
 }
 
-// DeleteDocumentsBulk deletes multiple documents using concurrent individual requests for better performance
+// BulkDeleteResult reports the outcome of each document of a bulk delete
+type BulkDeleteResult struct {
+	Deleted []string         // IDs deleted, in request order
+	Failed  map[string]error // IDs that remain, with the reason
+}
+
+// DeleteDocumentsBulk deletes multiple documents using concurrent individual
+// requests and returns the number deleted; see DeleteDocumentsBulkDetailed
+// for which documents failed
 func (c *Client) DeleteDocumentsBulk(ctx context.Context, collectionName string, documentIDs []string) (int, error) {
+	result, err := c.DeleteDocumentsBulkDetailed(ctx, collectionName, documentIDs)
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Deleted), nil
+}
+
+// DeleteDocumentsBulkDetailed deletes multiple documents using concurrent
+// individual requests and reports the deleted IDs and the error of each
+// failed one. Individual failures do not make the call fail.
+func (c *Client) DeleteDocumentsBulkDetailed(ctx context.Context, collectionName string, documentIDs []string) (*BulkDeleteResult, error) {
+	result := &BulkDeleteResult{
+		Deleted: []string{},
+		Failed:  map[string]error{},
+	}
 	if len(documentIDs) == 0 {
-		return 0, nil
+		return result, nil
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
@@ -797,65 +819,53 @@ func (c *Client) DeleteDocumentsBulk(ctx context.Context, collectionName string,
 
 	// Use concurrent individual deletions for better performance
 	// This is more reliable than batch API which may not be available in all Weaviate versions
-	type deleteResult struct {
-		success bool
-		err     error
-	}
-
-	// Create a channel to collect results
-	resultChan := make(chan deleteResult, len(documentIDs))
+	errs := make([]error, len(documentIDs))
 
 	// Limit concurrent requests to avoid overwhelming the server
 	maxConcurrency := 10
 	semaphore := make(chan struct{}, maxConcurrency)
 
-	// Launch goroutines for concurrent deletions
-	for _, docID := range documentIDs {
-		go func(id string) {
+	var wg sync.WaitGroup
+	for i, docID := range documentIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
 			semaphore <- struct{}{}        // Acquire semaphore
 			defer func() { <-semaphore }() // Release semaphore
 
-			err := c.DeleteDocument(ctx, collectionName, id)
-			resultChan <- deleteResult{success: err == nil, err: err}
-		}(docID)
+			errs[i] = c.DeleteDocument(ctx, collectionName, id)
+		}(i, docID)
 	}
+	wg.Wait()
 
-	// Collect results
-	successCount := 0
-	errorCount := 0
-	for i := 0; i < len(documentIDs); i++ {
-		result := <-resultChan
-		if result.success {
-			successCount++
-		} else {
-			errorCount++
-			// Log individual errors for debugging
-			if result.err != nil {
-				fmt.Printf("Warning: Failed to delete document: %v\n", result.err)
-			}
+	for i, id := range documentIDs {
+		if errs[i] != nil {
+			result.Failed[id] = errs[i]
+			continue
 		}
+		result.Deleted = append(result.Deleted, id)
 	}
-
-	return successCount, nil
+	return result, nil
 }
 
 // DeleteDocumentsByMetadata deletes documents matching metadata filters using REST API
 func (c *Client) DeleteDocumentsByMetadata(ctx context.Context, collectionName string, metadataFilters []string) (int, error) {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
 	// Parse metadata filters
-	filters := make(map[string]string)
-	for _, filter := range metadataFilters {
-		parts := strings.SplitN(filter, "=", 2)
-		if len(parts) != 2 {
-			return 0, fmt.Errorf("invalid metadata filter format: %s (expected key=value)", filter)
-		}
-		filters[parts[0]] = parts[1]
+	filters, err := ParseMetadataFilters(metadataFilters)
+	if err != nil {
+		return 0, err
 	}
 
-	// First, query for documents matching the metadata filters
-	documents, err := c.queryDocumentsByMetadata(ctx, collectionName, filters)
+	return c.DeleteDocumentsByFilter(ctx, collectionName, AllOf(filters...))
+}
+
+// DeleteDocumentsByFilter deletes documents matching a filter expression using REST API
+func (c *Client) DeleteDocumentsByFilter(ctx context.Context, collectionName string, filter FilterExpression) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// First, query for documents matching the filter
+	documents, err := c.queryDocumentsByMetadata(ctx, collectionName, filter)
 	if err != nil {
 		return 0, fmt.Errorf("failed to query documents by metadata: %w", err)
 	}
@@ -900,79 +910,55 @@ func (c *Client) DeleteAllDocuments(ctx context.Context, collectionName string)
 	}
 
 	// Delete all documents using bulk deletion
-	deletedCount, err := c.DeleteDocumentsBulk(ctx, collectionName, documentIDs)
+	result, err := c.DeleteDocumentsBulkDetailed(ctx, collectionName, documentIDs)
 	if err != nil {
 		return fmt.Errorf("failed to delete documents from collection %s: %w", collectionName, err)
 	}
 
-	if deletedCount != len(documentIDs) {
-		return fmt.Errorf("failed to delete all documents: deleted %d of %d", deletedCount, len(documentIDs))
+	for _, id := range documentIDs {
+		if failure, ok := result.Failed[id]; ok {
+			return fmt.Errorf("failed to delete all documents: deleted %d of %d (first failure: %w)", len(result.Deleted), len(documentIDs), failure)
+		}
 	}
 
 	return nil
 }
 
-// queryDocumentsByMetadata queries for documents matching metadata filters using GraphQL
-func (c *Client) queryDocumentsByMetadata(ctx context.Context, collectionName string, filters map[string]string) ([]Document, error) {
+// queryDocumentsByMetadata queries for documents matching a metadata filter expression using GraphQL
+func (c *Client) queryDocumentsByMetadata(ctx context.Context, collectionName string, filter FilterExpression) ([]Document, error) {
+	return c.queryDocumentsByFilter(ctx, collectionName, filter, 0)
+}
+
+// queryDocumentsByFilter returns the IDs, as documents, of up to limit
+// documents matching a filter expression (0: Weaviate's default limit)
+func (c *Client) queryDocumentsByFilter(ctx context.Context, collectionName string, filter FilterExpression, limit int) ([]Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	// Build the where clause for metadata filtering
-	var whereClauses []string
-	for key, value := range filters {
-		if key == "filename" {
-			// For filename, we need to search within the JSON string in the metadata field
-			// Use Like operator to search for the filename within the JSON string
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["metadata"]
-				operator: Like
-				valueString: "*filename\": \"%s\"*"
-			}`, value))
-		} else if key == "original_filename" {
-			// For original_filename, we need to search within the JSON string in the metadata field
-			// Use Like operator to search for the original_filename within the JSON string
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["metadata"]
-				operator: Like
-				valueString: "*original_filename\": \"%s\"*"
-			}`, value))
-		} else if key == "url" {
-			// For URL, use Like operator to allow partial matching
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["%s"]
-				operator: Like
-				valueString: "*%s*"
-			}`, key, value))
-		} else {
-			// For other fields, use direct path with exact matching
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["%s"]
-				operator: Equal
-				valueString: "%s"
-			}`, key, value))
-		}
+	whereClause, err := buildFilterExpressionClause(filter)
+	if err != nil {
+		return nil, err
 	}
 
-	// Combine multiple filters with AND
-	var whereClause string
-	if len(whereClauses) == 1 {
-		whereClause = whereClauses[0]
-	} else {
-		whereClause = fmt.Sprintf(`{
-			operator: And
-			operands: [%s]
-		}`, strings.Join(whereClauses, ", "))
+	limitClause := ""
+	if limit > 0 {
+		limitClause = fmt.Sprintf(", limit: %d", limit)
 	}
 
 	// Create GraphQL query to get documents
 	query := fmt.Sprintf(`
 		query {
 			Get {
-				%s(where: %s) {
+				%s(where: %s%s) {
 					_additional {
 						id
 					}
 				}
 			}
 		}
-	`, collectionName, whereClause)
+	`, collectionName, whereClause, limitClause)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
@@ -1007,21 +993,40 @@ func (c *Client) queryDocumentsByMetadata(ctx context.Context, collectionName st
 
 // GetDocumentsByMetadata gets documents matching metadata filters
 func (c *Client) GetDocumentsByMetadata(ctx context.Context, collectionName string, metadataFilters []string) ([]Document, error) {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	// Parse metadata filters
+	filters, err := ParseMetadataFilters(metadataFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetDocumentsByFilter(ctx, collectionName, AllOf(filters...))
+}
+
+// GetDocumentIDsByFilter returns the IDs of up to limit documents matching a
+// filter expression with a single GraphQL query, without fetching the documents
+func (c *Client) GetDocumentIDsByFilter(ctx context.Context, collectionName string, filter FilterExpression, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Parse metadata filters
-	filters := make(map[string]string)
-	for _, filter := range metadataFilters {
-		parts := strings.SplitN(filter, "=", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid metadata filter format: %s (expected key=value)", filter)
-		}
-		filters[parts[0]] = parts[1]
+	documents, err := c.queryDocumentsByFilter(ctx, collectionName, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents by metadata: %w", err)
 	}
 
-	// Query for documents matching the metadata filters
-	documents, err := c.queryDocumentsByMetadata(ctx, collectionName, filters)
+	ids := make([]string, len(documents))
+	for i, doc := range documents {
+		ids[i] = doc.ID
+	}
+	return ids, nil
+}
+
+// GetDocumentsByFilter gets documents matching a filter expression
+func (c *Client) GetDocumentsByFilter(ctx context.Context, collectionName string, filter FilterExpression) ([]Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// Query for documents matching the filter
+	documents, err := c.queryDocumentsByMetadata(ctx, collectionName, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query documents by metadata: %w", err)
 	}
@@ -1046,23 +1051,34 @@ func (c *Client) CreateDocument(ctx context.Context, collectionName string, doc
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	// Optionally compress image data; the metadata records the encoding
+	imageData, metadata, err := c.encodeImageData(doc)
+	if err != nil {
+		return err
+	}
+
 	// Create the document using the Weaviate client
-	// Convert metadata to JSON string for compatibility with existing collections
-	var metadataJSON string
-	if doc.Metadata != nil {
-		metadataBytes, err := json.Marshal(doc.Metadata)
-		if err != nil {
-			return fmt.Errorf("failed to marshal metadata: %w", err)
-		}
-		metadataJSON = string(metadataBytes)
+	// Match the metadata shape to the schema: a JSON string for text metadata
+	// properties, a native object for object metadata properties
+	metadataValue, err := FormatMetadata(metadata, c.MetadataFormat(ctx, collectionName))
+	if err != nil {
+		return err
 	}
 
 	properties := map[string]interface{}{
-		"text":     doc.Content, // Use 'text' field for ragmedocs schema compatibility
-		"content":  doc.Content, // Keep 'content' for backward compatibility
 		"image":    doc.Image,
 		"url":      doc.URL,
-		"metadata": metadataJSON, // Store as JSON string for compatibility
+		"metadata": metadataValue,
+	}
+	if imageData != "" {
+		properties["image_data"] = imageData
+	}
+	if doc.ContentField != "" {
+		// Write only the collection's primary text property
+		properties[doc.ContentField] = doc.Content
+	} else {
+		properties["text"] = doc.Content    // Use 'text' field for ragmedocs schema compatibility
+		properties["content"] = doc.Content // Keep 'content' for backward compatibility
 	}
 
 	// Add PDF metadata fields as top-level properties for compatibility with RagMeDocs
@@ -1108,7 +1124,12 @@ func (c *Client) CreateDocument(ctx context.Context, collectionName string, doc
 		}
 	}
 
-	_, err := c.client.Data().Creator().
+	// Explicit properties take precedence over those promoted from metadata
+	for name, value := range doc.Properties {
+		properties[name] = value
+	}
+
+	_, err = c.client.Data().Creator().
 		WithClassName(collectionName).
 		WithID(doc.ID).
 		WithProperties(properties).
@@ -1188,9 +1209,9 @@ func (c *Client) UpdateDocument(ctx context.Context, collectionName, documentID,
 			mergedMetadata[k] = v
 		}
 
-		metadataBytes, err := json.Marshal(mergedMetadata)
+		metadataValue, err := FormatMetadata(mergedMetadata, c.MetadataFormat(ctx, collectionName))
 		if err == nil {
-			properties["metadata"] = string(metadataBytes)
+			properties["metadata"] = metadataValue
 		}
 	} else if currentDoc.Metadata != nil {
 		// Preserve all existing metadata if no updates
@@ -1215,3 +1236,129 @@ func (c *Client) UpdateDocument(ctx context.Context, collectionName, documentID,
 
 	return nil
 }
+
+// reservedDocumentProperties are the document properties RemoveMetadataKeys
+// never removes as top-level properties
+var reservedDocumentProperties = map[string]bool{
+	"text": true, "content": true, "url": true, "image": true, "image_data": true, "metadata": true,
+}
+
+// RemoveMetadataKeys deletes metadata keys from a document and returns the
+// keys it had, in request order. A key is removed from the metadata property
+// and from the top-level properties. Weaviate's PATCH merges the properties it
+// is given with the stored ones, so the object is written back in full with
+// PUT, together with its stored vector so it is not re-embedded or lost.
+func (c *Client) RemoveMetadataKeys(ctx context.Context, collectionName, documentID string, keys []string) ([]string, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	objectURL := fmt.Sprintf("%s/v1/objects/%s/%s", strings.TrimSuffix(c.config.URL, "/"), collectionName, url.PathEscape(documentID))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", objectURL+"?include=vector", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document %s: %w", documentID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("document with ID %s not found in collection %s", documentID, collectionName)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get document %s: status %d, body: %s", documentID, resp.StatusCode, string(body))
+	}
+
+	var object map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil, fmt.Errorf("failed to decode document %s: %w", documentID, err)
+	}
+	properties, _ := object["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+
+	// The metadata property is a JSON string or a native object; keep its shape
+	var metadata map[string]interface{}
+	metadataIsText := false
+	switch value := properties["metadata"].(type) {
+	case string:
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+				return nil, fmt.Errorf("failed to parse metadata of document %s: %w", documentID, err)
+			}
+		}
+		metadataIsText = true
+	case map[string]interface{}:
+		metadata = value
+	}
+
+	removed := []string{}
+	for _, key := range keys {
+		found := false
+		if _, ok := metadata[key]; ok {
+			delete(metadata, key)
+			found = true
+		}
+		if _, ok := properties[key]; ok && !reservedDocumentProperties[key] {
+			delete(properties, key)
+			found = true
+		}
+		if found {
+			removed = append(removed, key)
+		}
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+
+	if metadataIsText && metadata != nil {
+		encoded, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata of document %s: %w", documentID, err)
+		}
+		properties["metadata"] = string(encoded)
+	} else if metadata != nil {
+		properties["metadata"] = metadata
+	}
+
+	replacement := map[string]interface{}{
+		"class":      collectionName,
+		"id":         documentID,
+		"properties": properties,
+	}
+	for _, field := range []string{"vector", "vectors", "tenant"} {
+		if value, ok := object[field]; ok && value != nil {
+			replacement[field] = value
+		}
+	}
+	payload, err := json.Marshal(replacement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document %s: %w", documentID, err)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, "PUT", objectURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	putResp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace document %s: %w", documentID, err)
+	}
+	defer putResp.Body.Close()
+
+	if putResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(putResp.Body)
+		return nil, fmt.Errorf("failed to replace document %s: status %d, body: %s", documentID, putResp.StatusCode, string(body))
+	}
+	return removed, nil
+}
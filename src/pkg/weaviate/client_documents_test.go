@@ -0,0 +1,842 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWeaviate is a minimal Weaviate REST/GraphQL server for client tests
+type fakeWeaviate struct {
+	collection    string
+	count         int
+	moduleConfig  map[string]interface{}
+	generated     string
+	lastQuery     string
+	getQueries    int32
+	metadataType  string                 // adds a metadata property of this data type to the schema
+	lastObject    map[string]interface{} // body of the last object created
+	schemaFetches int32                  // requests for the single-class schema
+	unsupported   []string               // search operators (e.g. "nearText") answered with GraphQL errors
+	largeFields   []string               // extra text properties, such as content or image, added to the schema
+}
+
+var (
+	graphQLLimitPattern  = regexp.MustCompile(`limit:\s*(\d+)`)
+	graphQLVectorPattern = regexp.MustCompile(`(?m)^\s*vector\s*$`)
+)
+
+func (f *fakeWeaviate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/v1/schema":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"classes": []map[string]interface{}{f.class()},
+		})
+	case "/v1/schema/" + f.collection:
+		atomic.AddInt32(&f.schemaFetches, 1)
+		json.NewEncoder(w).Encode(f.class())
+	case "/v1/objects":
+		var object map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&object)
+		f.lastObject = object
+		json.NewEncoder(w).Encode(object)
+	case "/v1/graphql":
+		var request struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		if strings.Contains(request.Query, "Aggregate") {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"Aggregate": map[string]interface{}{
+						f.collection: []interface{}{
+							map[string]interface{}{"meta": map[string]interface{}{"count": f.count}},
+						},
+					},
+				},
+			})
+			return
+		}
+
+		atomic.AddInt32(&f.getQueries, 1)
+		f.lastQuery = request.Query
+		for _, mode := range f.unsupported {
+			if strings.Contains(request.Query, mode+":") {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"errors": []interface{}{map[string]interface{}{"message": "unknown argument " + mode}},
+				})
+				return
+			}
+		}
+		limit := f.count
+		if match := graphQLLimitPattern.FindStringSubmatch(request.Query); match != nil {
+			if parsed, err := strconv.Atoi(match[1]); err == nil && parsed < limit {
+				limit = parsed
+			}
+		}
+
+		items := make([]interface{}, 0, limit)
+		for i := 0; i < limit; i++ {
+			items = append(items, map[string]interface{}{
+				"_additional": map[string]interface{}{"id": fmt.Sprintf("doc-%d", i)},
+				"text":        fmt.Sprintf("text %d", i),
+				"title":       fmt.Sprintf("title %d", i),
+			})
+		}
+		if graphQLVectorPattern.MatchString(request.Query) {
+			for _, item := range items {
+				additional := item.(map[string]interface{})["_additional"].(map[string]interface{})
+				additional["vector"] = []interface{}{0.25, -0.5, 1.0}
+			}
+		}
+		if strings.Contains(request.Query, "rerank(") {
+			// Rerank scores increase with position so reranking reverses the order
+			for i, item := range items {
+				additional := item.(map[string]interface{})["_additional"].(map[string]interface{})
+				additional["rerank"] = []interface{}{map[string]interface{}{"score": float64(i)}}
+			}
+		}
+		if len(items) > 0 && strings.Contains(request.Query, "groupedResult") {
+			additional := items[0].(map[string]interface{})["_additional"].(map[string]interface{})
+			additional["generate"] = map[string]interface{}{"groupedResult": f.generated, "error": nil}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"Get": map[string]interface{}{f.collection: items},
+			},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// class returns the schema of the fake collection
+func (f *fakeWeaviate) class() map[string]interface{} {
+	properties := []map[string]interface{}{
+		{"name": "text", "dataType": []string{"text"}},
+		{"name": "title", "dataType": []string{"text"}},
+	}
+	if f.metadataType != "" {
+		properties = append(properties, map[string]interface{}{"name": "metadata", "dataType": []string{f.metadataType}})
+	}
+	for _, field := range f.largeFields {
+		properties = append(properties, map[string]interface{}{"name": field, "dataType": []string{"text"}})
+	}
+	return map[string]interface{}{
+		"class":        f.collection,
+		"vectorizer":   "text2vec-openai",
+		"moduleConfig": f.moduleConfig,
+		"properties":   properties,
+	}
+}
+
+// newFakeWeaviateClient starts a fake Weaviate server and returns a client for it
+func newFakeWeaviateClient(t *testing.T, fake *fakeWeaviate) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+	return client
+}
+
+// TestListDocuments tests listing documents for empty, small, and large collections
+func TestListDocuments(t *testing.T) {
+	t.Run("empty collection returns early", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 0}
+		client := newFakeWeaviateClient(t, fake)
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 10)
+		require.NoError(t, err)
+		assert.NotNil(t, documents)
+		assert.Empty(t, documents)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fake.getQueries), "empty collections should not be listed")
+	})
+
+	t.Run("small collection returns all documents", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 3}
+		client := newFakeWeaviateClient(t, fake)
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 10)
+		require.NoError(t, err)
+		require.Len(t, documents, 3)
+		assert.Equal(t, "doc-0", documents[0].ID)
+		assert.Equal(t, "text 0", documents[0].Metadata["text"])
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fake.getQueries))
+	})
+
+	t.Run("large fields are flagged instead of fetched", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, largeFields: []string{"content", "image"}}
+		client := newFakeWeaviateClient(t, fake)
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 10)
+		require.NoError(t, err)
+		require.Len(t, documents, 1)
+		assert.True(t, documents[0].HasLargeFields)
+		assert.Equal(t, len("text 0"), documents[0].ContentSizeBytes)
+		assert.Equal(t, ExcludedContentPlaceholder, documents[0].Metadata["content"])
+		assert.Equal(t, ExcludedBase64Placeholder, documents[0].Metadata["image"])
+		assert.NotContains(t, fake.lastQuery, "content")
+	})
+
+	t.Run("collections without large fields have no placeholders", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 10)
+		require.NoError(t, err)
+		require.Len(t, documents, 1)
+		assert.False(t, documents[0].HasLargeFields)
+		assert.NotContains(t, documents[0].Metadata, "content")
+	})
+
+	t.Run("large collection respects limit", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 5000}
+		client := newFakeWeaviateClient(t, fake)
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 25)
+		require.NoError(t, err)
+		assert.Len(t, documents, 25)
+	})
+}
+
+// TestContentFields tests content field priority and configuration
+func TestContentFields(t *testing.T) {
+	properties := map[string]interface{}{
+		"title": "A title",
+		"body":  "The body",
+		"text":  "",
+	}
+
+	t.Run("default order skips empty fields", func(t *testing.T) {
+		client := &Client{config: &Config{}}
+		content, field := ExtractContent(properties, client.contentFieldsFor("Docs"))
+		assert.Equal(t, "The body", content)
+		assert.Equal(t, "body", field)
+	})
+
+	t.Run("global order overrides default", func(t *testing.T) {
+		client := &Client{config: &Config{ContentFields: []string{"title", "body"}}}
+		content, field := ExtractContent(properties, client.contentFieldsFor("Docs"))
+		assert.Equal(t, "A title", content)
+		assert.Equal(t, "title", field)
+	})
+
+	t.Run("per-collection order overrides global", func(t *testing.T) {
+		client := &Client{config: &Config{
+			ContentFields:           []string{"title"},
+			CollectionContentFields: map[string][]string{"Articles": {"body", "title"}},
+		}}
+		_, field := ExtractContent(properties, client.contentFieldsFor("Articles"))
+		assert.Equal(t, "body", field)
+		_, field = ExtractContent(properties, client.contentFieldsFor("Docs"))
+		assert.Equal(t, "title", field)
+	})
+
+	t.Run("no matching field", func(t *testing.T) {
+		content, field := ExtractContent(map[string]interface{}{"other": "x"}, DefaultContentFields)
+		assert.Empty(t, content)
+		assert.Empty(t, field)
+	})
+
+	t.Run("listed documents report the content field", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+		client.config.ContentFields = []string{"title", "text"}
+
+		documents, err := client.ListDocuments(context.Background(), "Docs", 10)
+		require.NoError(t, err)
+		require.Len(t, documents, 1)
+		assert.Equal(t, "title 0", documents[0].Content)
+		assert.Equal(t, "title", documents[0].ContentField)
+	})
+}
+
+// TestGetCollectionModuleConfig tests reading module config from the schema
+func TestGetCollectionModuleConfig(t *testing.T) {
+	t.Run("classifies generative and reranker modules", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", moduleConfig: map[string]interface{}{
+			"text2vec-openai":   map[string]interface{}{"model": "text-embedding-3-small"},
+			"generative-openai": map[string]interface{}{"model": "gpt-4o"},
+			"reranker-cohere":   map[string]interface{}{},
+		}}
+		client := newFakeWeaviateClient(t, fake)
+
+		config, err := client.GetCollectionModuleConfig(context.Background(), "Docs")
+		require.NoError(t, err)
+		assert.Equal(t, "Docs", config.Class)
+		assert.Equal(t, "text2vec-openai", config.Vectorizer)
+		assert.Equal(t, []string{"generative-openai"}, config.GenerativeModules)
+		assert.Equal(t, []string{"reranker-cohere"}, config.RerankerModules)
+		assert.True(t, config.GenerativeEnabled())
+		assert.True(t, config.RerankerEnabled())
+		assert.Len(t, config.ModuleConfig, 3)
+	})
+
+	t.Run("no module config", func(t *testing.T) {
+		client := newFakeWeaviateClient(t, &fakeWeaviate{collection: "Docs"})
+
+		config, err := client.GetCollectionModuleConfig(context.Background(), "Docs")
+		require.NoError(t, err)
+		assert.Empty(t, config.ModuleConfig)
+		assert.False(t, config.GenerativeEnabled())
+		assert.False(t, config.RerankerEnabled())
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		client := newFakeWeaviateClient(t, &fakeWeaviate{collection: "Docs"})
+
+		_, err := client.GetCollectionModuleConfig(context.Background(), "Missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+// TestGenerativeSearch tests generative search over the top results
+func TestGenerativeSearch(t *testing.T) {
+	generativeModules := map[string]interface{}{"generative-openai": map[string]interface{}{}}
+
+	t.Run("returns answer and sources", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2, moduleConfig: generativeModules, generated: "The answer"}
+		client := newFakeWeaviateClient(t, fake)
+
+		result, err := client.GenerativeSearch(context.Background(), "Docs", "what is it", `Summarize "these"`, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "The answer", result.Answer)
+		require.Len(t, result.Sources, 2)
+		assert.Equal(t, "doc-0", result.Sources[0].ID)
+		assert.Equal(t, "text 0", result.Sources[0].Content)
+		assert.Equal(t, []string{"generative-openai"}, result.Modules)
+		assert.Contains(t, fake.lastQuery, `task: "Summarize \"these\""`)
+	})
+
+	t.Run("requires a generative module", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.GenerativeSearch(context.Background(), "Docs", "what is it", "Summarize", 2)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no generative module enabled")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fake.getQueries))
+	})
+}
+
+// TestQueryReranked tests reranking search results with a reranker module
+func TestQueryReranked(t *testing.T) {
+	t.Run("reorders by rerank score", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 3, moduleConfig: map[string]interface{}{
+			"reranker-cohere": map[string]interface{}{},
+		}}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryReranked(context.Background(), "Docs", "query", 3)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, "doc-2", results[0].ID)
+		assert.Equal(t, 3, results[0].OriginalRank)
+		assert.Equal(t, 2.0, results[0].RerankScore)
+		assert.Equal(t, 2.0, results[0].Score)
+		assert.Equal(t, "doc-0", results[2].ID)
+		assert.Equal(t, 1, results[2].OriginalRank)
+		assert.Contains(t, fake.lastQuery, `rerank(property: "text", query: "query")`)
+	})
+
+	t.Run("requires a reranker module", func(t *testing.T) {
+		client := newFakeWeaviateClient(t, &fakeWeaviate{collection: "Docs", count: 3})
+
+		_, err := client.QueryReranked(context.Background(), "Docs", "query", 3)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrNoRerankerModule)
+	})
+}
+
+// TestCreateDocumentMetadataFormat tests that metadata is written in the shape
+// of the collection's metadata property
+func TestCreateDocumentMetadataFormat(t *testing.T) {
+	doc := Document{
+		ID:       "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+		Content:  "hello",
+		URL:      "https://example.com",
+		Metadata: map[string]interface{}{"source": "test"},
+	}
+
+	t.Run("text metadata is stored as a JSON string", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		client := newFakeWeaviateClient(t, fake)
+
+		require.NoError(t, client.CreateDocument(context.Background(), "Docs", doc))
+
+		properties := fake.lastObject["properties"].(map[string]interface{})
+		assert.Equal(t, `{"source":"test"}`, properties["metadata"])
+	})
+
+	t.Run("object metadata is stored as an object", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Images", metadataType: "object"}
+		client := newFakeWeaviateClient(t, fake)
+
+		require.NoError(t, client.CreateDocument(context.Background(), "Images", doc))
+
+		properties := fake.lastObject["properties"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"source": "test"}, properties["metadata"])
+	})
+}
+
+// TestCreateDocumentContentField tests writing content to a single property
+func TestCreateDocumentContentField(t *testing.T) {
+	doc := Document{
+		ID:      "6a1c3f0e-8a4b-4d5e-9f10-1b2c3d4e5f60",
+		Content: "hello",
+		URL:     "https://example.com",
+	}
+
+	t.Run("defaults to text and content", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		client := newFakeWeaviateClient(t, fake)
+
+		require.NoError(t, client.CreateDocument(context.Background(), "Docs", doc))
+
+		properties := fake.lastObject["properties"].(map[string]interface{})
+		assert.Equal(t, "hello", properties["text"])
+		assert.Equal(t, "hello", properties["content"])
+	})
+
+	t.Run("content field only", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		client := newFakeWeaviateClient(t, fake)
+
+		withField := doc
+		withField.ContentField = "body"
+		require.NoError(t, client.CreateDocument(context.Background(), "Docs", withField))
+
+		properties := fake.lastObject["properties"].(map[string]interface{})
+		assert.Equal(t, "hello", properties["body"])
+		assert.NotContains(t, properties, "text")
+		assert.NotContains(t, properties, "content")
+	})
+
+	t.Run("additional properties", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		client := newFakeWeaviateClient(t, fake)
+
+		withProperties := doc
+		withProperties.Properties = map[string]interface{}{"author": "Ada", "views": 3}
+		require.NoError(t, client.CreateDocument(context.Background(), "Docs", withProperties))
+
+		properties := fake.lastObject["properties"].(map[string]interface{})
+		assert.Equal(t, "Ada", properties["author"])
+		assert.Equal(t, float64(3), properties["views"])
+		assert.Equal(t, "hello", properties["text"])
+	})
+}
+
+// TestFormatMetadata tests metadata formatting for both schema shapes
+func TestFormatMetadata(t *testing.T) {
+	metadata := map[string]interface{}{"a": 1}
+
+	text, err := FormatMetadata(metadata, MetadataFormatText)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, text)
+
+	object, err := FormatMetadata(metadata, MetadataFormatObject)
+	require.NoError(t, err)
+	assert.Equal(t, metadata, object)
+
+	empty, err := FormatMetadata(nil, MetadataFormatText)
+	require.NoError(t, err)
+	assert.Equal(t, "", empty)
+
+	assert.Equal(t, MetadataFormatText, MetadataFormatFromSchema(nil))
+	assert.Equal(t, MetadataFormatObject, MetadataFormatFromSchema(&CollectionSchema{
+		Properties: []SchemaProperty{{Name: "metadata", DataType: []string{"object"}}},
+	}))
+}
+
+// TestMetadataSchemaCache tests that the metadata schema is fetched once per
+// collection and again after invalidation
+func TestMetadataSchemaCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repeated lookups reuse the cached schema", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "object"}
+		client := newFakeWeaviateClient(t, fake)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, MetadataFormatObject, client.MetadataFormat(ctx, "Docs"))
+			_, err := client.buildMetadataQuery(ctx, "Docs")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fake.schemaFetches))
+	})
+
+	t.Run("cache is shared by clients of the same instance", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "object"}
+		client := newFakeWeaviateClient(t, fake)
+		assert.Equal(t, MetadataFormatObject, client.MetadataFormat(ctx, "Docs"))
+
+		other, err := NewClient(client.config)
+		require.NoError(t, err)
+		assert.Equal(t, MetadataFormatObject, other.MetadataFormat(ctx, "Docs"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fake.schemaFetches))
+
+		InvalidateCollectionCaches(client.config.URL, "Docs")
+		assert.Equal(t, MetadataFormatObject, other.MetadataFormat(ctx, "Docs"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.schemaFetches))
+	})
+
+	t.Run("invalidation refetches the schema", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "text"}
+		client := newFakeWeaviateClient(t, fake)
+
+		assert.Equal(t, MetadataFormatText, client.MetadataFormat(ctx, "Docs"))
+
+		fake.metadataType = "object"
+		client.InvalidateMetadataSchema("Docs")
+		assert.Equal(t, MetadataFormatObject, client.MetadataFormat(ctx, "Docs"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.schemaFetches))
+	})
+
+	t.Run("failed fetches are not cached", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs"}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.buildMetadataQuery(ctx, "Missing")
+		assert.Error(t, err)
+		_, cached := metadataSchemas.get(client.config.URL, "Missing")
+		assert.False(t, cached)
+	})
+}
+
+// BenchmarkBuildMetadataQuery compares schema fetches per metadata query
+// with and without the metadata schema cache
+func BenchmarkBuildMetadataQuery(b *testing.B) {
+	ctx := context.Background()
+
+	run := func(b *testing.B, invalidate bool) {
+		fake := &fakeWeaviate{collection: "Docs", metadataType: "object"}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		client, err := NewClient(&Config{URL: server.URL})
+		require.NoError(b, err)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if invalidate {
+				client.InvalidateMetadataSchema("Docs")
+			}
+			if _, err := client.buildMetadataQuery(ctx, "Docs"); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(atomic.LoadInt32(&fake.schemaFetches))/float64(b.N), "schema_fetches/op")
+	}
+
+	b.Run("cached", func(b *testing.B) { run(b, false) })
+	b.Run("uncached", func(b *testing.B) { run(b, true) })
+}
+
+// TestQueryHybrid tests hybrid search alpha, fusion type, and validation
+func TestQueryHybrid(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes alpha and fusion type", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryHybrid(ctx, "Docs", `say "hi"`, HybridOptions{TopK: 2, Alpha: 0.25, FusionType: FusionTypeRelativeScore})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "text 0", results[0].Content)
+		assert.Contains(t, fake.lastQuery, "alpha: 0.25")
+		assert.Contains(t, fake.lastQuery, "fusionType: relativeScoreFusion")
+		assert.Contains(t, fake.lastQuery, `query: "say \"hi\""`)
+	})
+
+	t.Run("omits fusion type when unset", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryHybrid(ctx, "Docs", "hello", HybridOptions{Alpha: 1})
+		require.NoError(t, err)
+		assert.Contains(t, fake.lastQuery, "alpha: 1")
+		assert.NotContains(t, fake.lastQuery, "fusionType")
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		assert.Error(t, ValidateHybridOptions(HybridOptions{Alpha: 1.5}))
+		assert.Error(t, ValidateHybridOptions(HybridOptions{Alpha: -0.1}))
+		assert.Error(t, ValidateHybridOptions(HybridOptions{Alpha: 0.5, FusionType: "bestFusion"}))
+		assert.NoError(t, ValidateHybridOptions(HybridOptions{Alpha: 0, FusionType: FusionTypeRanked}))
+	})
+}
+
+func TestQueryBM25Properties(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to content fields", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2, UseBM25: true})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Contains(t, fake.lastQuery, `properties: ["text"]`)
+	})
+
+	t.Run("searches the named properties", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2, UseBM25: true, Properties: []string{"title", "text"}})
+		require.NoError(t, err)
+		assert.Contains(t, fake.lastQuery, `properties: ["title", "text"]`)
+	})
+
+	t.Run("rejects unknown properties", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{UseBM25: true, Properties: []string{"missing"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+		assert.Zero(t, atomic.LoadInt32(&fake.getQueries))
+	})
+}
+
+// TestQueryWithFilters tests filtered semantic search query construction
+func TestQueryWithFilters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adds where clause after limit", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 3}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryWithFilters(ctx, "Docs", "hello", QueryOptions{TopK: 2}, map[string]interface{}{
+			"year": map[string]interface{}{"operator": "GreaterThanEqual", "value": float64(2020)},
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Contains(t, fake.lastQuery, "limit: 2")
+		assert.Contains(t, fake.lastQuery, `path: ["year"]`)
+		assert.Contains(t, fake.lastQuery, "operator: GreaterThanEqual")
+		assert.Contains(t, fake.lastQuery, "valueInt: 2020")
+	})
+
+	t.Run("rejects invalid filters before querying", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryWithFilters(ctx, "Docs", "hello", QueryOptions{}, map[string]interface{}{
+			"year": map[string]interface{}{"operator": "Between", "value": 1},
+		})
+		require.Error(t, err)
+		assert.Equal(t, int32(0), fake.getQueries)
+	})
+
+	t.Run("reports GraphQL errors", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{"nearText"}}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryWithFilters(ctx, "Docs", "hello", QueryOptions{}, map[string]interface{}{"type": "pdf"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown argument nearText")
+	})
+}
+
+// TestIncludeVector tests that vectors are selected and parsed only on request
+func TestIncludeVector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("query leaves vectors out by default", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.False(t, graphQLVectorPattern.MatchString(fake.lastQuery))
+		assert.Nil(t, results[0].Vector)
+	})
+
+	t.Run("query returns vectors", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2, IncludeVector: true})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, []float32{0.25, -0.5, 1.0}, results[1].Vector)
+	})
+
+	t.Run("query fallbacks keep vectors", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{"nearText"}}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{IncludeVector: true})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, []float32{0.25, -0.5, 1.0}, results[0].Vector)
+	})
+
+	t.Run("get document with vector", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		doc, err := client.GetDocument(ctx, "Docs", "doc-0")
+		require.NoError(t, err)
+		assert.Nil(t, doc.Vector)
+
+		doc, err = client.GetDocumentWithVector(ctx, "Docs", "doc-0")
+		require.NoError(t, err)
+		assert.Equal(t, "doc-0", doc.ID)
+		assert.Equal(t, []float32{0.25, -0.5, 1.0}, doc.Vector)
+	})
+}
+
+// TestQueryNearObject tests nearest neighbor queries from an existing object
+func TestQueryNearObject(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("leaves out the object itself", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 5}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryNearObject(ctx, "Docs", "doc-0", 2)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "doc-1", results[0].ID)
+		assert.Equal(t, "doc-2", results[1].ID)
+		assert.Contains(t, fake.lastQuery, `id: "doc-0"`)
+		assert.Contains(t, fake.lastQuery, "limit: 3")
+	})
+
+	t.Run("keeps the limit when the object is not returned", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 5}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryNearObject(ctx, "Docs", "other", 2)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("reports GraphQL errors", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{"nearObject"}}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryNearObject(ctx, "Docs", "doc-0", 2)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown argument nearObject")
+	})
+}
+
+// TestDeleteDocumentsBulkDetailed tests per-document outcomes of bulk deletes
+func TestDeleteDocumentsBulkDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/locked"):
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("shard is read-only"))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+
+	t.Run("mixed outcomes", func(t *testing.T) {
+		ids := []string{"a", "missing", "b", "locked", "c"}
+		result, err := client.DeleteDocumentsBulkDetailed(context.Background(), "Docs", ids)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a", "b", "c"}, result.Deleted)
+		require.Len(t, result.Failed, 2)
+		assert.Contains(t, result.Failed["missing"].Error(), "document not found")
+		assert.Contains(t, result.Failed["locked"].Error(), "shard is read-only")
+
+		deleted, err := client.DeleteDocumentsBulk(context.Background(), "Docs", ids)
+		require.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+	})
+
+	t.Run("no documents", func(t *testing.T) {
+		result, err := client.DeleteDocumentsBulkDetailed(context.Background(), "Docs", nil)
+		require.NoError(t, err)
+		assert.Empty(t, result.Deleted)
+		assert.Empty(t, result.Failed)
+	})
+}
+
+// TestGetDocumentIDsByFilter tests ID-only metadata queries
+func TestGetDocumentIDsByFilter(t *testing.T) {
+	fake := &fakeWeaviate{collection: "Docs", count: 5}
+	client := newFakeWeaviateClient(t, fake)
+
+	ids, err := client.GetDocumentIDsByFilter(context.Background(), "Docs", AllOf(MetadataFilter{Key: "filename", Operator: "Equal", Value: "a.pdf"}), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-0", "doc-1", "doc-2"}, ids)
+	assert.Contains(t, fake.lastQuery, "limit: 3")
+	assert.NotContains(t, fake.lastQuery, "text")
+}
+
+// TestRemoveMetadataKeys tests that removed keys are written back with PUT
+func TestRemoveMetadataKeys(t *testing.T) {
+	var put map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&put)
+			json.NewEncoder(w).Encode(put)
+			return
+		}
+		if r.URL.Path != "/v1/objects/Images/doc-1" {
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+			return
+		}
+		assert.Equal(t, "vector", r.URL.Query().Get("include"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"class": "Images",
+			"id":    "doc-1",
+			"properties": map[string]interface{}{
+				"url":      "a.png",
+				"url_hash": "abc",
+				"metadata": map[string]interface{}{"draft": true, "source": "web"},
+			},
+			"vector": []float64{0.5},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+
+	removed, err := client.RemoveMetadataKeys(context.Background(), "Images", "doc-1", []string{"draft", "url_hash", "url", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"draft", "url_hash"}, removed)
+	assert.Equal(t, map[string]interface{}{
+		"url":      "a.png",
+		"metadata": map[string]interface{}{"source": "web"},
+	}, put["properties"])
+	assert.Equal(t, []interface{}{0.5}, put["vector"])
+}
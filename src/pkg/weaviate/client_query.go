@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -18,6 +19,17 @@ type QueryResult struct {
 	Content  string                 `json:"content"`
 	Metadata map[string]interface{} `json:"metadata"`
 	Score    float64                `json:"score"`
+	// ScoreUnavailable is set when the search produced no relevance score
+	// and Score is 0 rather than a measured similarity
+	ScoreUnavailable bool `json:"score_unavailable,omitempty"`
+	// SearchMode is set to SearchModeFallbackKeyword for degraded results
+	SearchMode string `json:"search_mode,omitempty"`
+	// Vector is the object's embedding, only set when IncludeVector was requested
+	Vector []float32 `json:"vector,omitempty"`
+	// Distance and Certainty are Weaviate's raw vector search measures, set
+	// when the search returned them (certainty only for cosine distance)
+	Distance  *float64 `json:"distance,omitempty"`
+	Certainty *float64 `json:"certainty,omitempty"`
 }
 
 // QueryOptions holds options for semantic search queries
@@ -27,33 +39,61 @@ type QueryOptions struct {
 	SearchMetadata bool    `json:"search_metadata"`
 	NoTruncate     bool    `json:"no_truncate"`
 	UseBM25        bool    `json:"use_bm25"`
+	// Properties limits BM25 search to these properties (default: content, text, and optionally metadata)
+	Properties []string `json:"properties,omitempty"`
+	// IncludeVector also returns each result's embedding, which is large
+	IncludeVector bool `json:"include_vector,omitempty"`
+	// Normalization overrides the client's score normalization strategy
+	Normalization string `json:"normalization,omitempty"`
+	// TargetVector embeds nearText queries with this named vector's module
+	// instead of the default vector's (see ResolveQueryVectorizer)
+	TargetVector string `json:"target_vector,omitempty"`
+	// Concepts are searched together with the query text; BM25 and the
+	// fallbacks search them as extra keywords
+	Concepts []string `json:"concepts,omitempty"`
+	// MoveTo and MoveAway steer nearText results toward or away from
+	// concepts (see QueryWithSteering)
+	MoveTo   *NearTextMove `json:"move_to,omitempty"`
+	MoveAway *NearTextMove `json:"move_away,omitempty"`
 }
 
-// normalizeScore applies a non-linear transformation to spread scores across a wider range.
-// This makes low-relevance results (0.5) appear much lower (0.25) while keeping
-// high-relevance results (0.7+) relatively high.
-//
-// The transformation uses a power function: score^2 which:
-// - Maps 0.5 → 0.25 (low, indicates marginal match)
-// - Maps 0.6 → 0.36 (moderate match)
-// - Maps 0.65 → 0.42 (good match)
-// - Maps 0.7 → 0.49 (good relevance)
-// - Maps 0.8 → 0.64 (strong relevance)
-// - Maps 0.9 → 0.81 (very strong relevance)
-// - Maps 1.0 → 1.0 (perfect match)
-func normalizeScore(rawScore float64) float64 {
-	if rawScore < 0 {
-		return 0
-	}
-	if rawScore > 1 {
-		return 1
-	}
-	// Use quadratic function to amplify differences
-	return math.Pow(rawScore, 2.0)
+// vectorSelection returns the _additional selection for the object's vector
+// when options request it
+func vectorSelection(options QueryOptions) string {
+	if !options.IncludeVector {
+		return ""
+	}
+	return "\n\t\t\t\t\t\tvector"
 }
 
 // Query performs semantic search on a collection using nearText
 func (c *Client) Query(ctx context.Context, collectionName, queryText string, options QueryOptions) ([]QueryResult, error) {
+	results, _, err := c.QueryWithSearchMode(ctx, collectionName, queryText, options)
+	return results, err
+}
+
+// QueryWithSearchMode is Query that also returns the search mode that
+// produced the results: SearchModeNearText or SearchModeBM25 as requested,
+// or the fallback that answered, SearchModeHybrid or
+// SearchModeFallbackKeyword. The mode is known even when no documents match.
+func (c *Client) QueryWithSearchMode(ctx context.Context, collectionName, queryText string, options QueryOptions) ([]QueryResult, string, error) {
+	results, searchMode, _, err := c.QueryWithSteering(ctx, collectionName, queryText, options)
+	return results, searchMode, err
+}
+
+// QueryWithSteering is QueryWithSearchMode that also reports whether the
+// MoveTo and MoveAway steering was applied. Not every vectorizer supports
+// steering, so when nearText rejects it the query is retried without it
+// rather than falling back to keyword search; BM25 and the fallbacks ignore
+// steering too.
+func (c *Client) QueryWithSteering(ctx context.Context, collectionName, queryText string, options QueryOptions) ([]QueryResult, string, bool, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, "", false, err
+	}
+	if err := ValidateNearText(options); err != nil {
+		return nil, "", false, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -65,33 +105,22 @@ func (c *Client) Query(ctx context.Context, collectionName, queryText string, op
 	// Get the collection schema to determine the content field
 	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+		return nil, "", false, fmt.Errorf("failed to get collection schema: %w", err)
 	}
 
 	// Determine the content field name - prefer content, fallback to text
-	contentField := "content"
-	hasContent := false
-	hasText := false
-
-	for _, prop := range schema.Properties {
-		if prop.Name == "content" {
-			hasContent = true
-		}
-		if prop.Name == "text" {
-			hasText = true
-		}
-	}
-
-	// Use content if available, otherwise use text
-	if hasContent {
-		contentField = "content"
-	} else if hasText {
-		contentField = "text"
-	}
+	contentField := queryContentField(schema)
 
 	// If BM25 flag is set, use BM25 search directly
 	if options.UseBM25 {
-		return c.queryWithBM25(ctx, collectionName, queryText, options, contentField)
+		results, searchMode, err := c.queryWithBM25(ctx, collectionName, keywordQueryText(queryText, options), options, contentField)
+		return results, searchMode, false, err
+	}
+
+	// Skip straight to the fallback when nearText is known to fail
+	if c.searchModeUnsupported(collectionName, SearchModeNearText) {
+		results, searchMode, err := c.queryWithFallbacks(ctx, collectionName, keywordQueryText(queryText, options), options, contentField, SearchModeNearText, errSearchModeCached)
+		return results, searchMode, false, err
 	}
 
 	// Build the GraphQL query for semantic search using nearText
@@ -101,54 +130,181 @@ func (c *Client) Query(ctx context.Context, collectionName, queryText string, op
 			Get {
 				%s(
 					nearText: {
-						concepts: ["%s"]
+						concepts: [%s]%s%s
 					}
 					limit: %d
 				) {
 					_additional {
 						id
 						distance
-						certainty
+						certainty%s
 					}
 					%s
 					metadata
 				}
 			}
-		}`, collectionName, strings.ReplaceAll(queryText, `"`, `\"`), options.TopK, contentField)
+		}`, collectionName, nearTextConceptList(queryText, options), nearTextMoveArguments(options), targetVectorsArgument(options), options.TopK, vectorSelection(options), contentField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute semantic search query: %w", err)
+		return nil, "", false, fmt.Errorf("failed to execute semantic search query: %w", err)
 	}
 
 	// Check for GraphQL errors
 	if hasGraphQLErrors(result) {
+		// The vectorizer may reject steering only, so retry plain nearText
+		// before deciding it is unsupported
+		if options.steered() {
+			options.MoveTo, options.MoveAway = nil, nil
+			results, searchMode, _, err := c.QueryWithSteering(ctx, collectionName, queryText, options)
+			return results, searchMode, false, err
+		}
+
 		// Try fallback query with hybrid search instead of nearText
-		return c.queryWithFallback(ctx, collectionName, queryText, options, contentField)
+		c.recordSearchMode(collectionName, SearchModeNearText, false)
+		results, searchMode, err := c.queryWithFallbacks(ctx, collectionName, keywordQueryText(queryText, options), options, contentField, SearchModeNearText, graphQLError(result))
+		return results, searchMode, false, err
 	}
+	c.recordSearchMode(collectionName, SearchModeNearText, true)
 
 	// Parse the results
-	results, err := c.parseQueryResults(result, contentField)
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query results: %v", err)
+		return nil, "", false, fmt.Errorf("failed to parse query results: %v", err)
+	}
+
+	return results, SearchModeNearText, options.steered(), nil
+}
+
+// QueryNearVector searches a collection with a precomputed embedding using
+// nearVector, so the query is not embedded again. The vector must have the
+// dimensions of the vectors stored in the collection (see VectorDimensions).
+func (c *Client) QueryNearVector(ctx context.Context, collectionName string, vector []float32, options QueryOptions) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+	if len(vector) == 0 {
+		return nil, fmt.Errorf("vector must not be empty")
+	}
+	components := make([]string, len(vector))
+	for i, v := range vector {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("vector component %d is not a finite number", i)
+		}
+		components[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Default top_k if not specified
+	if options.TopK <= 0 {
+		options.TopK = 5
 	}
 
+	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	contentField := queryContentField(schema)
+
+	dimensions, err := c.VectorDimensions(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if dimensions > 0 && dimensions != len(vector) {
+		return nil, fmt.Errorf("vector has %d dimensions but collection %s stores %d-dimensional vectors", len(vector), collectionName, dimensions)
+	}
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					nearVector: {
+						vector: [%s]%s
+					}
+					limit: %d
+				) {
+					_additional {
+						id
+						distance
+						certainty%s
+					}
+					%s
+					metadata
+				}
+			}
+		}`, collectionName, strings.Join(components, ", "), targetVectorsArgument(options), options.TopK, vectorSelection(options), contentField)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute nearVector query: %w", err)
+	}
+	if hasGraphQLErrors(result) {
+		return nil, fmt.Errorf("nearVector query failed: %w", graphQLError(result))
+	}
+
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse nearVector query results: %v", err)
+	}
 	return results, nil
 }
 
+// VectorDimensions returns the dimensions of the vectors stored in a
+// collection, read from one of its objects, or 0 when the collection is
+// empty or its objects have no vectors
+func (c *Client) VectorDimensions(ctx context.Context, collectionName string) (int, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(limit: 1) {
+					_additional {
+						id
+						vector
+					}
+				}
+			}
+		}`, collectionName)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read a stored vector: %w", err)
+	}
+	if hasGraphQLErrors(result) {
+		return 0, fmt.Errorf("failed to read a stored vector: %w", graphQLError(result))
+	}
+
+	results, err := c.parseQueryResults(result, "", "", "")
+	if err != nil || len(results) == 0 {
+		return 0, nil
+	}
+	return len(results[0].Vector), nil
+}
+
 // queryWithBM25 performs BM25 keyword search with real similarity scores
-func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText string, options QueryOptions, contentField string) ([]QueryResult, error) {
+func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText string, options QueryOptions, contentField string) ([]QueryResult, string, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, "", err
+	}
+
 	// Get schema to check available fields
 	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get collection schema for BM25: %w", err)
+		return nil, "", fmt.Errorf("failed to get collection schema for BM25: %w", err)
 	}
 
 	// Check available fields
 	hasContent := false
 	hasText := false
 	hasMetadata := false
+	available := make(map[string]bool, len(schema.Properties))
 	for _, prop := range schema.Properties {
+		available[prop.Name] = true
 		if prop.Name == "content" {
 			hasContent = true
 		}
@@ -162,22 +318,36 @@ func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText st
 
 	// Build query fields for BM25 search
 	var queryFields []string
-	if hasContent {
-		queryFields = append(queryFields, "content")
-	}
-	if hasText {
-		queryFields = append(queryFields, "text")
-	}
-	if options.SearchMetadata && hasMetadata {
-		queryFields = append(queryFields, "metadata")
+	if len(options.Properties) > 0 {
+		for _, property := range options.Properties {
+			if !available[property] {
+				return nil, "", fmt.Errorf("property '%s' not found in collection %s", property, collectionName)
+			}
+		}
+		queryFields = options.Properties
+	} else {
+		if hasContent {
+			queryFields = append(queryFields, "content")
+		}
+		if hasText {
+			queryFields = append(queryFields, "text")
+		}
+		if options.SearchMetadata && hasMetadata {
+			queryFields = append(queryFields, "metadata")
+		}
 	}
 
 	if len(queryFields) == 0 {
-		return nil, fmt.Errorf("no searchable fields found in collection")
+		return nil, "", fmt.Errorf("no searchable fields found in collection")
+	}
+
+	// Skip straight to the fallback when BM25 is known to fail
+	if c.searchModeUnsupported(collectionName, SearchModeBM25) {
+		return c.queryWithFallbacks(ctx, collectionName, queryText, options, contentField, SearchModeBM25, errSearchModeCached)
 	}
 
-	// Escape query text for GraphQL
-	queryTextEscaped := strings.ReplaceAll(queryText, `"`, `\"`)
+	// Quote query text for GraphQL
+	queryTextQuoted := graphQLString(queryText)
 
 	// Build properties list for BM25 query
 	propertiesList := ""
@@ -185,7 +355,7 @@ func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText st
 		// Quote each field name properly
 		quotedFields := make([]string, len(queryFields))
 		for i, field := range queryFields {
-			quotedFields[i] = `"` + field + `"`
+			quotedFields[i] = graphQLString(field)
 		}
 		propertiesList = fmt.Sprintf(`properties: [%s]`, strings.Join(quotedFields, ", "))
 	}
@@ -196,7 +366,7 @@ func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText st
 			Get {
 				%s(
 					bm25: {
-						query: "%s"
+						query: %s
 						%s
 					}
 					limit: %d
@@ -204,37 +374,57 @@ func (c *Client) queryWithBM25(ctx context.Context, collectionName, queryText st
 					_additional {
 						id
 						score
-						explainScore
+						explainScore%s
 					}
 					%s
 					metadata
 				}
 			}
-		}`, collectionName, queryTextEscaped, propertiesList, options.TopK, contentField)
+		}`, collectionName, queryTextQuoted, propertiesList, options.TopK, vectorSelection(options), contentField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute BM25 search query: %w", err)
+		return nil, "", fmt.Errorf("failed to execute BM25 search query: %w", err)
 	}
 
-	// Check for GraphQL errors
+	// Check for GraphQL errors; an explicit property list may be the cause,
+	// so only the default fields say anything about BM25 support
 	if hasGraphQLErrors(result) {
 		// BM25 might not be supported, fall back to hybrid search
-		return c.queryWithFallback(ctx, collectionName, queryText, options, contentField)
+		if len(options.Properties) == 0 {
+			c.recordSearchMode(collectionName, SearchModeBM25, false)
+		}
+		return c.queryWithFallbacks(ctx, collectionName, queryText, options, contentField, SearchModeBM25, graphQLError(result))
 	}
+	c.recordSearchMode(collectionName, SearchModeBM25, true)
 
 	// Parse results
-	results, err := c.parseQueryResults(result, contentField)
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse BM25 query results: %v", err)
+		return nil, "", fmt.Errorf("failed to parse BM25 query results: %v", err)
 	}
 
 	// BM25 provides real similarity scores, so we don't need to modify them
-	return results, nil
+	return results, SearchModeBM25, nil
 }
 
-// QueryWithFilters performs semantic search with additional metadata filters
+// QueryWithFilters performs semantic search with additional metadata filters.
+// Filters use the ParseFilterMap syntax and are combined with And.
 func (c *Client) QueryWithFilters(ctx context.Context, collectionName, queryText string, options QueryOptions, filters map[string]interface{}) ([]QueryResult, error) {
+	parsed, err := ParseFilterMap(filters)
+	if err != nil {
+		return nil, err
+	}
+	return c.QueryWithFilterExpression(ctx, collectionName, queryText, options, AllOf(parsed...))
+}
+
+// QueryWithFilterExpression performs semantic search restricted to documents
+// matching a filter expression
+func (c *Client) QueryWithFilterExpression(ctx context.Context, collectionName, queryText string, options QueryOptions, filter FilterExpression) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -250,34 +440,16 @@ func (c *Client) QueryWithFilters(ctx context.Context, collectionName, queryText
 	}
 
 	// Determine the content field name - prefer content, fallback to text
-	contentField := "content"
-	hasContent := false
-	hasText := false
-
-	for _, prop := range schema.Properties {
-		if prop.Name == "content" {
-			hasContent = true
-		}
-		if prop.Name == "text" {
-			hasText = true
-		}
-	}
-
-	// Use content if available, otherwise use text
-	if hasContent {
-		contentField = "content"
-	} else if hasText {
-		contentField = "text"
-	}
+	contentField := queryContentField(schema)
 
 	// Build where clause for filters
 	whereClause := ""
-	if len(filters) > 0 {
-		whereClause = "where: {\n"
-		for key, value := range filters {
-			whereClause += fmt.Sprintf("\t\t\t\t%s: {\n\t\t\t\t\tequal: \"%v\"\n\t\t\t\t}\n", key, value)
+	if filter.Filter != nil || len(filter.Operands) > 0 {
+		clause, err := buildFilterExpressionClause(filter)
+		if err != nil {
+			return nil, err
 		}
-		whereClause += "\t\t\t}"
+		whereClause = "\n\t\t\t\t\twhere: " + clause
 	}
 
 	// Build the GraphQL query for semantic search with filters
@@ -286,33 +458,30 @@ func (c *Client) QueryWithFilters(ctx context.Context, collectionName, queryText
 			Get {
 				%s(
 					nearText: {
-						concepts: ["%s"]
-						limit: %d
-					}%s
+						concepts: [%s]
+					}
+					limit: %d%s
 				) {
 					_additional {
 						id
-						distance
+						distance%s
 					}
 					%s
 					metadata
 				}
 			}
-		}`, collectionName, strings.ReplaceAll(queryText, `"`, `\"`), options.TopK,
-		func() string {
-			if whereClause != "" {
-				return ",\n\t\t\t" + whereClause
-			}
-			return ""
-		}(), contentField)
+		}`, collectionName, graphQLString(queryText), options.TopK, whereClause, vectorSelection(options), contentField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute semantic search query with filters: %w", err)
 	}
+	if hasGraphQLErrors(result) {
+		return nil, fmt.Errorf("failed to execute semantic search query with filters: %w", graphQLError(result))
+	}
 
 	// Parse the results
-	results, err := c.parseQueryResults(result, contentField)
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query results: %v", err)
 	}
@@ -321,7 +490,7 @@ func (c *Client) QueryWithFilters(ctx context.Context, collectionName, queryText
 }
 
 // parseQueryResults parses the GraphQL response into QueryResult objects
-func (c *Client) parseQueryResults(result interface{}, contentField string) ([]QueryResult, error) {
+func (c *Client) parseQueryResults(result interface{}, contentField, distanceMetric, normalization string) ([]QueryResult, error) {
 	if result == nil {
 		return nil, fmt.Errorf("received nil result from GraphQL query")
 	}
@@ -393,19 +562,17 @@ func (c *Client) parseQueryResults(result interface{}, contentField string) ([]Q
 
 		// Extract similarity score (handle distance, certainty, and score fields)
 		var rawScore float64
-		if certainty, exists := additional["certainty"]; exists {
+		if certainty, exists := additional["certainty"]; exists && certainty != nil {
 			// nearText can provide certainty (0.0 to 1.0, higher is better)
+			// Weaviate only computes certainty for cosine distance and returns null otherwise
 			if cert, ok := certainty.(float64); ok {
 				rawScore = cert
 			}
 		} else if distance, exists := additional["distance"]; exists {
 			// nearText can also provide distance (lower is better, convert to score)
 			if dist, ok := distance.(float64); ok {
-				// Convert distance to similarity score (assuming cosine distance 0-2 range)
-				rawScore = 1.0 - (dist / 2.0)
-				if rawScore < 0 {
-					rawScore = 0
-				}
+				// Convert distance to similarity score using the collection's distance metric
+				rawScore = distanceToScore(dist, distanceMetric)
 			}
 		} else if scoreVal, exists := additional["score"]; exists {
 			// BM25/hybrid provides score directly
@@ -417,8 +584,8 @@ func (c *Client) parseQueryResults(result interface{}, contentField string) ([]Q
 			rawScore = 0.5
 		}
 
-		// Apply score normalization to spread scores across wider range
-		score := normalizeScore(rawScore)
+		// Apply the score normalization strategy
+		score := normalizeScore(rawScore, normalization)
 
 		// Extract content
 		content, _ := resultItem[contentField].(string)
@@ -427,16 +594,44 @@ func (c *Client) parseQueryResults(result interface{}, contentField string) ([]Q
 		metadata, _ := resultItem["metadata"].(map[string]interface{})
 
 		queryResults = append(queryResults, QueryResult{
-			ID:       id,
-			Content:  content,
-			Metadata: metadata,
-			Score:    score,
+			ID:        id,
+			Content:   content,
+			Metadata:  metadata,
+			Score:     score,
+			Vector:    parseVector(additional["vector"]),
+			Distance:  optionalFloat(additional["distance"]),
+			Certainty: optionalFloat(additional["certainty"]),
 		})
 	}
 
 	return queryResults, nil
 }
 
+// parseVector converts an _additional vector from the GraphQL response,
+// returning nil when none was selected
+func parseVector(value interface{}) []float32 {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	vector := make([]float32, 0, len(values))
+	for _, v := range values {
+		if f, ok := v.(float64); ok {
+			vector = append(vector, float32(f))
+		}
+	}
+	return vector
+}
+
+// optionalFloat returns a GraphQL number, or nil when it is null or missing
+func optionalFloat(value interface{}) *float64 {
+	f, ok := value.(float64)
+	if !ok {
+		return nil
+	}
+	return &f
+}
+
 // hasGraphQLErrors checks if the GraphQL response contains errors
 func hasGraphQLErrors(result interface{}) bool {
 	if result == nil {
@@ -461,8 +656,13 @@ func hasGraphQLErrors(result interface{}) bool {
 	return false
 }
 
-// queryWithFallback performs a fallback search using hybrid search for real similarity scores
+// queryWithFallback performs a fallback search using hybrid search for real similarity scores.
+// It returns an errSearchModeUnsupported error when hybrid search fails.
 func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryText string, options QueryOptions, contentField string) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	// Get schema to check available fields
 	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
 	if err != nil {
@@ -501,8 +701,13 @@ func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryTex
 		return nil, fmt.Errorf("no searchable fields found in collection")
 	}
 
-	// Escape query text for GraphQL
-	queryTextEscaped := strings.ReplaceAll(queryText, `"`, `\"`)
+	// Move on to the next fallback when hybrid is known to fail
+	if c.searchModeUnsupported(collectionName, SearchModeHybrid) {
+		return nil, errSearchModeCached
+	}
+
+	// Quote query text for GraphQL
+	queryTextQuoted := graphQLString(queryText)
 
 	// Build the GraphQL query using hybrid search for real similarity scores
 	// Hybrid search combines vector search with keyword search
@@ -511,7 +716,7 @@ func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryTex
 			Get {
 				%s(
 					hybrid: {
-						query: "%s"
+						query: %s
 						alpha: 0.75
 					}
 					limit: %d
@@ -519,13 +724,13 @@ func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryTex
 					_additional {
 						id
 						score
-						explainScore
+						explainScore%s
 					}
 					%s
 					metadata
 				}
 			}
-		}`, collectionName, queryTextEscaped, options.TopK, contentField)
+		}`, collectionName, queryTextQuoted, options.TopK, vectorSelection(options), contentField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
@@ -534,12 +739,14 @@ func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryTex
 
 	// Check for GraphQL errors
 	if hasGraphQLErrors(result) {
-		// Hybrid search might not be supported, fall back to simple where clause
-		return c.queryWithSimpleFallback(ctx, collectionName, queryText, options, contentField)
+		// Hybrid search might not be supported, move on to the next fallback
+		c.recordSearchMode(collectionName, SearchModeHybrid, false)
+		return nil, graphQLError(result)
 	}
+	c.recordSearchMode(collectionName, SearchModeHybrid, true)
 
 	// Parse results
-	results, err := c.parseQueryResults(result, contentField)
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse hybrid fallback query results: %v", err)
 	}
@@ -548,8 +755,15 @@ func (c *Client) queryWithFallback(ctx context.Context, collectionName, queryTex
 	return results, nil
 }
 
-// queryWithSimpleFallback performs a simple text search using where clause as final fallback
+// queryWithSimpleFallback performs a simple text search using a where clause.
+// Matches have no relevance score, so results are marked ScoreUnavailable
+// with SearchMode SearchModeFallbackKeyword. It returns
+// an errSearchModeUnsupported error when the where query fails.
 func (c *Client) queryWithSimpleFallback(ctx context.Context, collectionName, queryText string, options QueryOptions, contentField string) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	// Get schema to check available fields
 	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
 	if err != nil {
@@ -574,32 +788,32 @@ func (c *Client) queryWithSimpleFallback(ctx context.Context, collectionName, qu
 
 	// Build query based on available fields and search options
 	var operands []string
-	queryTextEscaped := strings.ReplaceAll(queryText, `"`, `\"`)
+	queryTextQuoted := graphQLString(queryText)
 
 	// Always search content/text fields
 	if hasContent && hasText {
 		operands = append(operands, fmt.Sprintf(`{
 							path: ["content"]
 							operator: Equal
-							valueText: "%s"
-						}`, queryTextEscaped))
+							valueText: %s
+						}`, queryTextQuoted))
 		operands = append(operands, fmt.Sprintf(`{
 							path: ["text"]
 							operator: Equal
-							valueText: "%s"
-						}`, queryTextEscaped))
+							valueText: %s
+						}`, queryTextQuoted))
 	} else if hasContent {
 		operands = append(operands, fmt.Sprintf(`{
 							path: ["content"]
 							operator: Equal
-							valueText: "%s"
-						}`, queryTextEscaped))
+							valueText: %s
+						}`, queryTextQuoted))
 	} else if hasText {
 		operands = append(operands, fmt.Sprintf(`{
 							path: ["text"]
 							operator: Equal
-							valueText: "%s"
-						}`, queryTextEscaped))
+							valueText: %s
+						}`, queryTextQuoted))
 	}
 
 	// Add metadata search if enabled and available
@@ -607,8 +821,8 @@ func (c *Client) queryWithSimpleFallback(ctx context.Context, collectionName, qu
 		operands = append(operands, fmt.Sprintf(`{
 							path: ["metadata"]
 							operator: Equal
-							valueText: "%s"
-						}`, queryTextEscaped))
+							valueText: %s
+						}`, queryTextQuoted))
 	}
 
 	// Build the where clause
@@ -639,13 +853,13 @@ func (c *Client) queryWithSimpleFallback(ctx context.Context, collectionName, qu
 					limit: %d
 				) {
 					_additional {
-						id
+						id%s
 					}
 					%s
 					metadata
 				}
 			}
-		}`, collectionName, whereClause, options.TopK, contentField)
+		}`, collectionName, whereClause, options.TopK, vectorSelection(options), contentField)
 
 	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
 	if err != nil {
@@ -654,18 +868,21 @@ func (c *Client) queryWithSimpleFallback(ctx context.Context, collectionName, qu
 
 	// Check for GraphQL errors
 	if hasGraphQLErrors(result) {
-		return nil, fmt.Errorf("simple fallback query also returned errors")
+		return nil, graphQLError(result)
 	}
 
 	// Parse the results
-	results, err := c.parseQueryResults(result, contentField)
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(options.Normalization))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse simple fallback query results: %v", err)
 	}
 
-	// Since this is a simple text search, set all scores to 1.0
+	// A where-clause match is not ranked, so report no score rather than a
+	// perfect one that would mislead relevance-based callers
 	for i := range results {
-		results[i].Score = 1.0
+		results[i].Score = 0
+		results[i].ScoreUnavailable = true
+		results[i].SearchMode = SearchModeFallbackKeyword
 	}
 
 	return results, nil
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryNearVector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("matching dimensions run a nearVector query", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 3}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryNearVector(ctx, "Docs", []float32{0.1, -0.2, 0.3}, QueryOptions{TopK: 2})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "doc-0", results[0].ID)
+		assert.Contains(t, fake.lastQuery, "nearVector:")
+		assert.Contains(t, fake.lastQuery, "vector: [0.1, -0.2, 0.3]")
+		assert.Contains(t, fake.lastQuery, "certainty")
+	})
+
+	t.Run("dimension mismatch is rejected", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryNearVector(ctx, "Docs", []float32{0.1, 0.2}, QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "vector has 2 dimensions but collection Docs stores 3-dimensional vectors")
+		assert.NotContains(t, fake.lastQuery, "nearVector:")
+	})
+
+	t.Run("empty collection skips the dimension check", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 0}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.QueryNearVector(ctx, "Docs", []float32{0.1, 0.2}, QueryOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Contains(t, fake.lastQuery, "nearVector:")
+	})
+
+	t.Run("invalid vectors are rejected before querying", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.QueryNearVector(ctx, "Docs", nil, QueryOptions{})
+		assert.Error(t, err)
+		_, err = client.QueryNearVector(ctx, "Docs", []float32{0.1, float32(math.NaN()), 0.3}, QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "component 1")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fake.getQueries))
+	})
+}
+
+func TestVectorDimensions(t *testing.T) {
+	fake := &fakeWeaviate{collection: "Docs", count: 2}
+	client := newFakeWeaviateClient(t, fake)
+
+	dimensions, err := client.VectorDimensions(context.Background(), "Docs")
+	require.NoError(t, err)
+	assert.Equal(t, 3, dimensions)
+
+	empty := newFakeWeaviateClient(t, &fakeWeaviate{collection: "Empty", count: 0})
+	dimensions, err = empty.VectorDimensions(context.Background(), "Empty")
+	require.NoError(t, err)
+	assert.Equal(t, 0, dimensions)
+}
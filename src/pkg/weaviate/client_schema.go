@@ -52,16 +52,18 @@ func (c *Client) GetFullCollectionSchema(ctx context.Context, collectionName str
 		if class.Class == collectionName {
 			// Convert to our schema format
 			result := &CollectionSchema{
-				Class:      class.Class,
-				Vectorizer: class.Vectorizer,
-				Properties: make([]SchemaProperty, len(class.Properties)),
+				Class:             class.Class,
+				Vectorizer:        class.Vectorizer,
+				Properties:        make([]SchemaProperty, len(class.Properties)),
+				VectorIndexConfig: vectorIndexConfigFromSchema(class.VectorIndexConfig),
 			}
 
 			for i, prop := range class.Properties {
 				result.Properties[i] = SchemaProperty{
-					Name:        prop.Name,
-					DataType:    prop.DataType,
-					Description: prop.Description,
+					Name:         prop.Name,
+					DataType:     prop.DataType,
+					Description:  prop.Description,
+					Tokenization: prop.Tokenization,
 				}
 
 				// Convert nested properties if available
@@ -100,6 +102,8 @@ func (c *Client) CreateCollectionFromSchema(ctx context.Context, schema *Collect
 		}
 	}
 
+	c.invalidateCollectionCaches(schema.Class)
+
 	// Build the schema payload
 	classSchema := map[string]interface{}{
 		"class": schema.Class,
@@ -110,6 +114,11 @@ func (c *Client) CreateCollectionFromSchema(ctx context.Context, schema *Collect
 		classSchema["vectorizer"] = schema.Vectorizer
 	}
 
+	// Add HNSW vector index settings if specified
+	if schema.VectorIndexConfig != nil {
+		classSchema["vectorIndexConfig"] = schema.VectorIndexConfig
+	}
+
 	// Convert properties to the format expected by Weaviate API
 	if len(schema.Properties) > 0 {
 		properties := make([]map[string]interface{}, len(schema.Properties))
@@ -123,6 +132,10 @@ func (c *Client) CreateCollectionFromSchema(ctx context.Context, schema *Collect
 				property["description"] = prop.Description
 			}
 
+			if prop.Tokenization != "" {
+				property["tokenization"] = prop.Tokenization
+			}
+
 			// Handle nested properties
 			if len(prop.NestedProperties) > 0 {
 				nestedProps := make([]map[string]interface{}, len(prop.NestedProperties))
@@ -168,12 +181,8 @@ func (c *Client) CreateCollectionFromSchema(ctx context.Context, schema *Collect
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if c.config.APIKey != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.APIKey))
-	}
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+// DefaultContentFields is the default priority order of properties used to
+// extract a document's Content. The first non-empty string field wins.
+var DefaultContentFields = []string{"text", "content", "body", "description", "title", "name", "chunk", "pageContent", "document"}
+
+// contentFieldsFor returns the content field priority order for a collection.
+// Per-collection settings take precedence over the global setting, which takes
+// precedence over DefaultContentFields.
+func (c *Client) contentFieldsFor(collectionName string) []string {
+	if c.config != nil {
+		if fields, ok := c.config.CollectionContentFields[collectionName]; ok && len(fields) > 0 {
+			return fields
+		}
+		if len(c.config.ContentFields) > 0 {
+			return c.config.ContentFields
+		}
+	}
+	return DefaultContentFields
+}
+
+// ExtractContent returns the value of the first non-empty string property in
+// fields, along with the name of that property ("" when none is set)
+func ExtractContent(properties map[string]interface{}, fields []string) (string, string) {
+	for _, field := range fields {
+		if str, ok := properties[field].(string); ok && str != "" {
+			return str, field
+		}
+	}
+	return "", ""
+}
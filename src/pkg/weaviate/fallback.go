@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Search fallbacks tried, in the configured order, when the requested search
+// mode fails on a collection
+const (
+	SearchFallbackHybrid = "hybrid" // hybrid search, real similarity scores
+	SearchFallbackSimple = "simple" // where-clause text match, no relevance score
+)
+
+// SearchModeFallbackKeyword marks results of the simple fallback, whose
+// where-clause match has no relevance score
+const SearchModeFallbackKeyword = "fallback_keyword"
+
+// DefaultSearchFallback is the fallback chain used when none is configured
+var DefaultSearchFallback = []string{SearchFallbackHybrid, SearchFallbackSimple}
+
+// errSearchModeUnsupported reports that a fallback's query returned GraphQL
+// errors, so the next fallback in the chain should be tried
+var errSearchModeUnsupported = errors.New("search mode not supported")
+
+// errSearchModeCached reports a search mode skipped because the search mode
+// cache knows it fails on the collection
+var errSearchModeCached = fmt.Errorf("%w (cached)", errSearchModeUnsupported)
+
+// graphQLError returns errSearchModeUnsupported annotated with the error
+// messages of a GraphQL result
+func graphQLError(result interface{}) error {
+	var messages []string
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if errorsField := v.FieldByName("Errors"); errorsField.Kind() == reflect.Slice {
+			for i := 0; i < errorsField.Len(); i++ {
+				item := reflect.Indirect(errorsField.Index(i))
+				if item.Kind() != reflect.Struct {
+					continue
+				}
+				if message := item.FieldByName("Message"); message.Kind() == reflect.String && message.String() != "" {
+					messages = append(messages, message.String())
+				}
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		return errSearchModeUnsupported
+	}
+	return fmt.Errorf("%w: %s", errSearchModeUnsupported, strings.Join(messages, "; "))
+}
+
+// ValidateMaxFallbackDepth checks the maximum fallback depth of a client
+func ValidateMaxFallbackDepth(depth int) error {
+	if depth < 0 {
+		return fmt.Errorf("max fallback depth must not be negative (got %d)", depth)
+	}
+	return nil
+}
+
+// ValidateSearchFallback checks a fallback chain for unknown or repeated entries
+func ValidateSearchFallback(chain []string) error {
+	seen := make(map[string]bool, len(chain))
+	for _, mode := range chain {
+		if mode != SearchFallbackHybrid && mode != SearchFallbackSimple {
+			return fmt.Errorf("unknown search fallback '%s' (expected %s or %s)", mode, SearchFallbackHybrid, SearchFallbackSimple)
+		}
+		if seen[mode] {
+			return fmt.Errorf("search fallback '%s' is listed more than once", mode)
+		}
+		seen[mode] = true
+	}
+	return nil
+}
+
+// searchFallback returns the configured fallback chain; nil means the default
+// and an empty chain disables fallbacks
+func (c *Client) searchFallback() []string {
+	if c.config.SearchFallback == nil {
+		return DefaultSearchFallback
+	}
+	return c.config.SearchFallback
+}
+
+// queryWithFallbacks runs the configured fallback chain, up to the maximum
+// fallback depth, after failedMode failed with failedErr, and returns the
+// search mode of the fallback that answered. Once the chain is exhausted it
+// fails with the error of every attempted mode instead of degrading further.
+func (c *Client) queryWithFallbacks(ctx context.Context, collectionName, queryText string, options QueryOptions, contentField, failedMode string, failedErr error) ([]QueryResult, string, error) {
+	chain := c.searchFallback()
+	if len(chain) == 0 {
+		return nil, "", fmt.Errorf("%s search failed on collection %s and search fallbacks are disabled: %v", failedMode, collectionName, failedErr)
+	}
+
+	limited := false
+	if depth := c.config.MaxFallbackDepth; depth > 0 && depth < len(chain) {
+		chain = chain[:depth]
+		limited = true
+	}
+
+	tried := []string{failedMode}
+	attempts := []string{fmt.Sprintf("%s: %v", failedMode, failedErr)}
+	for _, mode := range chain {
+		var results []QueryResult
+		var searchMode string
+		var err error
+		switch mode {
+		case SearchFallbackHybrid:
+			results, err = c.queryWithFallback(ctx, collectionName, queryText, options, contentField)
+			searchMode = SearchModeHybrid
+		case SearchFallbackSimple:
+			results, err = c.queryWithSimpleFallback(ctx, collectionName, queryText, options, contentField)
+			searchMode = SearchModeFallbackKeyword
+		default:
+			return nil, "", fmt.Errorf("unknown search fallback '%s'", mode)
+		}
+		if err == nil {
+			return results, searchMode, nil
+		}
+		if !errors.Is(err, errSearchModeUnsupported) {
+			return nil, "", err
+		}
+		tried = append(tried, mode)
+		attempts = append(attempts, fmt.Sprintf("%s: %v", mode, err))
+	}
+
+	reason := "no search fallback is left"
+	if limited {
+		reason = fmt.Sprintf("the maximum fallback depth of %d was reached", len(chain))
+	}
+	return nil, "", fmt.Errorf("search failed on collection %s: %s all returned errors and %s (%s)",
+		collectionName, strings.Join(tried, ", "), reason, strings.Join(attempts, "; "))
+}
@@ -0,0 +1,504 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetadataFilter represents a single metadata filter condition
+type MetadataFilter struct {
+	Key      string
+	Operator string      // Weaviate operator (Equal, GreaterThan, ...)
+	Value    interface{} // string, int64, float64, or bool
+}
+
+// filterOperators maps filter syntax to Weaviate operators.
+// Longer tokens come first so ">=" is matched before ">". Word operators
+// must be surrounded by whitespace and are matched case-insensitively.
+var filterOperators = []struct {
+	token    string
+	operator string
+}{
+	{" like ", "Like"},
+	{" in ", "ContainsAny"},
+	{">=", "GreaterThanEqual"},
+	{"<=", "LessThanEqual"},
+	{">", "GreaterThan"},
+	{"<", "LessThan"},
+	{"=", "Equal"},
+}
+
+// ParseMetadataFilters parses metadata filter expressions. Supported forms:
+//
+//	key=value          exact match (Equal)
+//	key>value          numeric comparison (also <, >= and <=)
+//	key in [a,b,c]     matches any of the listed values (ContainsAny)
+//	key like pattern   wildcard match using * and ? (Like)
+//
+// Values are typed automatically: true/false become booleans, integers become
+// ints, decimals become numbers and everything else is a string. Wrap a value
+// in double quotes to force a string (e.g. version="5").
+func ParseMetadataFilters(metadataFilters []string) ([]MetadataFilter, error) {
+	filters := make([]MetadataFilter, 0, len(metadataFilters))
+	for _, raw := range metadataFilters {
+		filter, err := parseMetadataFilter(raw)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// parseMetadataFilter parses a single filter expression
+func parseMetadataFilter(raw string) (MetadataFilter, error) {
+	// Find the first operator in the expression
+	idx := -1
+	var token, operator string
+	for i := 0; i < len(raw) && idx == -1; i++ {
+		for _, op := range filterOperators {
+			if len(raw)-i >= len(op.token) && strings.EqualFold(raw[i:i+len(op.token)], op.token) {
+				idx, token, operator = i, op.token, op.operator
+				break
+			}
+		}
+	}
+
+	if idx <= 0 || strings.TrimSpace(raw[:idx]) == "" {
+		return MetadataFilter{}, fmt.Errorf("invalid metadata filter format: %s (expected key=value)", raw)
+	}
+
+	key := strings.TrimSpace(raw[:idx])
+	rawValue := strings.TrimSpace(raw[idx+len(token):])
+
+	switch operator {
+	case "Like":
+		if rawValue == "" {
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: like requires a pattern", raw)
+		}
+		return MetadataFilter{Key: key, Operator: operator, Value: strings.Trim(rawValue, `"`)}, nil
+	case "ContainsAny":
+		values, err := parseFilterList(rawValue)
+		if err != nil {
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: %w", raw, err)
+		}
+		return MetadataFilter{Key: key, Operator: operator, Value: values}, nil
+	}
+
+	value := inferFilterValue(rawValue)
+	if operator != "Equal" {
+		switch value.(type) {
+		case int64, float64:
+		default:
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: operator %s requires a numeric value", raw, token)
+		}
+	}
+
+	return MetadataFilter{Key: key, Operator: operator, Value: value}, nil
+}
+
+// parseFilterList parses a bracketed list like [a,b,c] into typed values.
+// All values must share the same type.
+func parseFilterList(raw string) ([]interface{}, error) {
+	if !strings.HasPrefix(raw, "[") || !strings.HasSuffix(raw, "]") {
+		return nil, fmt.Errorf("in requires a list like [a,b,c]")
+	}
+
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+	if inner == "" {
+		return nil, fmt.Errorf("in requires at least one value")
+	}
+
+	parts := strings.Split(inner, ",")
+	values := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		value := inferFilterValue(strings.TrimSpace(part))
+		if len(values) > 0 && fmt.Sprintf("%T", value) != fmt.Sprintf("%T", values[0]) {
+			return nil, fmt.Errorf("in list values must all have the same type")
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// filterObjectOperators maps the operator names accepted in filter objects to
+// Weaviate operators. Names are matched case-insensitively.
+var filterObjectOperators = map[string]string{
+	"equal":            "Equal",
+	"notequal":         "NotEqual",
+	"like":             "Like",
+	"greaterthan":      "GreaterThan",
+	"greaterthanequal": "GreaterThanEqual",
+	"lessthan":         "LessThan",
+	"lessthanequal":    "LessThanEqual",
+	"containsany":      "ContainsAny",
+	"containsall":      "ContainsAll",
+}
+
+// ParseFilterMap parses a map of metadata filters keyed by property. A plain
+// value is an exact match; an object such as
+//
+//	{"operator": "GreaterThan", "value": 5}
+//
+// selects another Weaviate operator (Equal, NotEqual, Like, GreaterThan,
+// GreaterThanEqual, LessThan, LessThanEqual, ContainsAny, ContainsAll).
+// Filters are returned sorted by key.
+func ParseFilterMap(filters map[string]interface{}) ([]MetadataFilter, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parsed := make([]MetadataFilter, 0, len(keys))
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid metadata filter: key must not be empty")
+		}
+		filter, err := parseFilterObject(key, filters[key])
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, filter)
+	}
+	return parsed, nil
+}
+
+// parseFilterObject parses the filter for one key of a filter map
+func parseFilterObject(key string, raw interface{}) (MetadataFilter, error) {
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		value, err := typedFilterValue(raw)
+		if err != nil {
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: %w", key, err)
+		}
+		return MetadataFilter{Key: key, Operator: "Equal", Value: value}, nil
+	}
+
+	opName, _ := object["operator"].(string)
+	operator, ok := filterObjectOperators[strings.ToLower(opName)]
+	if !ok {
+		return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: unknown operator '%s'", key, opName)
+	}
+	rawValue, ok := object["value"]
+	if !ok {
+		return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: value is required", key)
+	}
+
+	switch operator {
+	case "ContainsAny", "ContainsAll":
+		list, ok := rawValue.([]interface{})
+		if !ok || len(list) == 0 {
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: %s requires a non-empty list", key, operator)
+		}
+		values := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			value, err := typedFilterValue(item)
+			if err != nil {
+				return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: %w", key, err)
+			}
+			if len(values) > 0 && fmt.Sprintf("%T", value) != fmt.Sprintf("%T", values[0]) {
+				return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: list values must all have the same type", key)
+			}
+			values = append(values, value)
+		}
+		return MetadataFilter{Key: key, Operator: operator, Value: values}, nil
+	}
+
+	value, err := typedFilterValue(rawValue)
+	if err != nil {
+		return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: %w", key, err)
+	}
+	switch operator {
+	case "Like":
+		if _, ok := value.(string); !ok {
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: Like requires a string pattern", key)
+		}
+	case "GreaterThan", "GreaterThanEqual", "LessThan", "LessThanEqual":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			return MetadataFilter{}, fmt.Errorf("invalid metadata filter %s: operator %s requires a numeric value", key, operator)
+		}
+	}
+	return MetadataFilter{Key: key, Operator: operator, Value: value}, nil
+}
+
+// typedFilterValue converts a decoded JSON value into a bool, int64, float64
+// or string. Whole numbers become ints so they match int properties.
+func typedFilterValue(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case string, bool, int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v), nil
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", raw)
+	}
+}
+
+// inferFilterValue converts a raw filter value into a bool, int64, float64 or string
+func inferFilterValue(raw string) interface{} {
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		return raw[1 : len(raw)-1]
+	}
+	if raw == "true" || raw == "false" {
+		return raw == "true"
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+	return raw
+}
+
+// filterValueClause returns the GraphQL value field for a typed filter value
+func filterValueClause(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return fmt.Sprintf("valueBoolean: %t", v)
+	case int64:
+		return fmt.Sprintf("valueInt: %d", v)
+	case float64:
+		return fmt.Sprintf("valueNumber: %s", formatFilterNumber(v))
+	case []interface{}:
+		return filterListClause(v)
+	default:
+		return "valueString: " + graphQLString(fmt.Sprint(v))
+	}
+}
+
+// filterListClause returns the GraphQL value field for a list of typed values
+func filterListClause(values []interface{}) string {
+	items := make([]string, 0, len(values))
+	field := "valueString"
+	for _, value := range values {
+		switch v := value.(type) {
+		case bool:
+			field = "valueBoolean"
+			items = append(items, fmt.Sprintf("%t", v))
+		case int64:
+			field = "valueInt"
+			items = append(items, fmt.Sprintf("%d", v))
+		case float64:
+			field = "valueNumber"
+			items = append(items, formatFilterNumber(v))
+		default:
+			items = append(items, graphQLString(fmt.Sprint(v)))
+		}
+	}
+	return fmt.Sprintf("%s: [%s]", field, strings.Join(items, ", "))
+}
+
+// formatFilterNumber formats a float without exponent or trailing zeros
+func formatFilterNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// buildFilterClause builds the GraphQL where clause for a single filter
+func buildFilterClause(filter MetadataFilter) string {
+	switch {
+	case (filter.Key == "filename" || filter.Key == "original_filename") && filter.Operator == "Equal":
+		// For filename fields, we need to search within the JSON string in the metadata field
+		// Use Like operator to search for the value within the JSON string
+		return fmt.Sprintf(`{
+				path: ["metadata"]
+				operator: Like
+				valueString: %s
+			}`, graphQLString(fmt.Sprintf(`*%s": "%v"*`, filter.Key, filter.Value)))
+	case filter.Key == "url" && filter.Operator == "Equal":
+		// For URL, use Like operator to allow partial matching
+		return fmt.Sprintf(`{
+				path: [%s]
+				operator: Like
+				valueString: %s
+			}`, graphQLString(filter.Key), graphQLString(fmt.Sprintf("*%v*", filter.Value)))
+	default:
+		return fmt.Sprintf(`{
+				path: [%s]
+				operator: %s
+				%s
+			}`, graphQLString(filter.Key), filter.Operator, filterValueClause(filter.Value))
+	}
+}
+
+// FilterExpression is a boolean combination of metadata filters.
+// A leaf expression holds a single Filter; a group expression combines its
+// Operands with And, Or, or Not (Not takes exactly one operand).
+type FilterExpression struct {
+	Operator string
+	Operands []FilterExpression
+	Filter   *MetadataFilter
+}
+
+// AllOf returns an expression matching documents that satisfy every filter
+func AllOf(filters ...MetadataFilter) FilterExpression {
+	operands := make([]FilterExpression, 0, len(filters))
+	for i := range filters {
+		operands = append(operands, FilterExpression{Filter: &filters[i]})
+	}
+	return FilterExpression{Operator: "And", Operands: operands}
+}
+
+// ParseFilterExpression parses a nested filter structure such as
+//
+//	{"operator": "And", "operands": [
+//	    {"operator": "Or", "operands": ["a=1", "b=2"]},
+//	    {"operator": "Not", "operands": ["c=3"]}
+//	]}
+//
+// Operands are either filter strings (see ParseMetadataFilters) or nested
+// objects with "operator" and "operands". Operator names are case-insensitive.
+func ParseFilterExpression(raw interface{}) (FilterExpression, error) {
+	switch v := raw.(type) {
+	case string:
+		filter, err := parseMetadataFilter(v)
+		if err != nil {
+			return FilterExpression{}, err
+		}
+		return FilterExpression{Filter: &filter}, nil
+	case map[string]interface{}:
+		opName, _ := v["operator"].(string)
+		var operator string
+		switch strings.ToLower(opName) {
+		case "and":
+			operator = "And"
+		case "or":
+			operator = "Or"
+		case "not":
+			operator = "Not"
+		default:
+			return FilterExpression{}, fmt.Errorf("invalid filter operator '%s': must be And, Or, or Not", opName)
+		}
+
+		rawOperands, ok := v["operands"].([]interface{})
+		if !ok || len(rawOperands) == 0 {
+			return FilterExpression{}, fmt.Errorf("filter operator %s requires a non-empty operands array", operator)
+		}
+		if operator == "Not" && len(rawOperands) != 1 {
+			return FilterExpression{}, fmt.Errorf("filter operator Not requires exactly one operand")
+		}
+
+		operands := make([]FilterExpression, 0, len(rawOperands))
+		for _, rawOperand := range rawOperands {
+			operand, err := ParseFilterExpression(rawOperand)
+			if err != nil {
+				return FilterExpression{}, err
+			}
+			operands = append(operands, operand)
+		}
+		return FilterExpression{Operator: operator, Operands: operands}, nil
+	default:
+		return FilterExpression{}, fmt.Errorf("invalid filter: expected a filter string or an object with operator and operands")
+	}
+}
+
+// negatedOperators maps comparison operators to their negation
+var negatedOperators = map[string]string{
+	"Equal":            "NotEqual",
+	"NotEqual":         "Equal",
+	"GreaterThan":      "LessThanEqual",
+	"GreaterThanEqual": "LessThan",
+	"LessThan":         "GreaterThanEqual",
+	"LessThanEqual":    "GreaterThan",
+}
+
+// negateFilterExpression returns the logical negation of an expression.
+// Weaviate's GraphQL where filter has no Not operator, so negation is pushed
+// down to the leaves using De Morgan's laws and inverted comparison operators.
+func negateFilterExpression(expr FilterExpression) (FilterExpression, error) {
+	switch expr.Operator {
+	case "Not":
+		return expr.Operands[0], nil
+	case "And", "Or":
+		flipped := "Or"
+		if expr.Operator == "Or" {
+			flipped = "And"
+		}
+		operands := make([]FilterExpression, 0, len(expr.Operands))
+		for _, operand := range expr.Operands {
+			negated, err := negateFilterExpression(operand)
+			if err != nil {
+				return FilterExpression{}, err
+			}
+			operands = append(operands, negated)
+		}
+		return FilterExpression{Operator: flipped, Operands: operands}, nil
+	}
+
+	if expr.Filter == nil {
+		return FilterExpression{}, fmt.Errorf("invalid filter expression")
+	}
+
+	filter := *expr.Filter
+	negated, ok := negatedOperators[filter.Operator]
+	if !ok || isPatternMatchKey(filter.Key) {
+		return FilterExpression{}, fmt.Errorf("cannot negate filter on '%s': operator %s does not support Not", filter.Key, filter.Operator)
+	}
+	filter.Operator = negated
+	return FilterExpression{Filter: &filter}, nil
+}
+
+// isPatternMatchKey reports whether equality on a key is implemented as a Like match
+func isPatternMatchKey(key string) bool {
+	return key == "filename" || key == "original_filename" || key == "url"
+}
+
+// buildFilterExpressionClause builds the GraphQL where clause for an expression
+func buildFilterExpressionClause(expr FilterExpression) (string, error) {
+	switch expr.Operator {
+	case "":
+		if expr.Filter == nil {
+			return "", fmt.Errorf("invalid filter expression")
+		}
+		if err := validateOperator(expr.Filter.Operator); err != nil {
+			return "", err
+		}
+		return buildFilterClause(*expr.Filter), nil
+	case "Not":
+		negated, err := negateFilterExpression(expr.Operands[0])
+		if err != nil {
+			return "", err
+		}
+		return buildFilterExpressionClause(negated)
+	}
+	if err := validateOperator(expr.Operator); err != nil {
+		return "", err
+	}
+
+	clauses := make([]string, 0, len(expr.Operands))
+	for _, operand := range expr.Operands {
+		clause, err := buildFilterExpressionClause(operand)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0], nil
+	}
+	return fmt.Sprintf(`{
+			operator: %s
+			operands: [%s]
+		}`, expr.Operator, strings.Join(clauses, ", ")), nil
+}
+
+// buildMetadataWhereClause combines filters into a single GraphQL where clause using And
+func buildMetadataWhereClause(filters []MetadataFilter) string {
+	// An And of plain filters cannot fail to build
+	clause, _ := buildFilterExpressionClause(AllOf(filters...))
+	return clause
+}
@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseMetadataFilters tests typed metadata filter parsing
+func TestParseMetadataFilters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   string
+		expected MetadataFilter
+	}{
+		{
+			name:     "string value",
+			filter:   "author=jane",
+			expected: MetadataFilter{Key: "author", Operator: "Equal", Value: "jane"},
+		},
+		{
+			name:     "int value",
+			filter:   "count=5",
+			expected: MetadataFilter{Key: "count", Operator: "Equal", Value: int64(5)},
+		},
+		{
+			name:     "float value",
+			filter:   "score=0.75",
+			expected: MetadataFilter{Key: "score", Operator: "Equal", Value: 0.75},
+		},
+		{
+			name:     "bool value",
+			filter:   "published=true",
+			expected: MetadataFilter{Key: "published", Operator: "Equal", Value: true},
+		},
+		{
+			name:     "quoted value stays a string",
+			filter:   `version="5"`,
+			expected: MetadataFilter{Key: "version", Operator: "Equal", Value: "5"},
+		},
+		{
+			name:     "greater than",
+			filter:   "count>5",
+			expected: MetadataFilter{Key: "count", Operator: "GreaterThan", Value: int64(5)},
+		},
+		{
+			name:     "less than",
+			filter:   "score<0.5",
+			expected: MetadataFilter{Key: "score", Operator: "LessThan", Value: 0.5},
+		},
+		{
+			name:     "greater than or equal",
+			filter:   "count>=10",
+			expected: MetadataFilter{Key: "count", Operator: "GreaterThanEqual", Value: int64(10)},
+		},
+		{
+			name:     "less than or equal",
+			filter:   "count<=10",
+			expected: MetadataFilter{Key: "count", Operator: "LessThanEqual", Value: int64(10)},
+		},
+		{
+			name:     "in list of strings",
+			filter:   "category in [news,blog]",
+			expected: MetadataFilter{Key: "category", Operator: "ContainsAny", Value: []interface{}{"news", "blog"}},
+		},
+		{
+			name:     "in list of ints",
+			filter:   "year IN [2023, 2024]",
+			expected: MetadataFilter{Key: "year", Operator: "ContainsAny", Value: []interface{}{int64(2023), int64(2024)}},
+		},
+		{
+			name:     "like pattern",
+			filter:   "title like *report*",
+			expected: MetadataFilter{Key: "title", Operator: "Like", Value: "*report*"},
+		},
+		{
+			name:     "equality value containing a word operator",
+			filter:   "title=winter in paris",
+			expected: MetadataFilter{Key: "title", Operator: "Equal", Value: "winter in paris"},
+		},
+		{
+			name:     "value containing operator characters",
+			filter:   "url=https://example.com/?a=1",
+			expected: MetadataFilter{Key: "url", Operator: "Equal", Value: "https://example.com/?a=1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filters, err := ParseMetadataFilters([]string{tc.filter})
+			require.NoError(t, err)
+			require.Len(t, filters, 1)
+			assert.Equal(t, tc.expected, filters[0])
+		})
+	}
+
+	t.Run("missing operator", func(t *testing.T) {
+		_, err := ParseMetadataFilters([]string{"author"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected key=value")
+	})
+
+	t.Run("in requires a bracketed list", func(t *testing.T) {
+		_, err := ParseMetadataFilters([]string{"category in news"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "in requires a list")
+	})
+
+	t.Run("in list values must share a type", func(t *testing.T) {
+		_, err := ParseMetadataFilters([]string{"year in [2023, latest]"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "same type")
+	})
+
+	t.Run("comparison requires numeric value", func(t *testing.T) {
+		_, err := ParseMetadataFilters([]string{"author>jane"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires a numeric value")
+	})
+}
+
+// TestBuildMetadataWhereClause tests GraphQL where clause generation for typed filters
+func TestBuildMetadataWhereClause(t *testing.T) {
+	t.Run("int filter uses valueInt", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "count", Operator: "Equal", Value: int64(5)},
+		})
+		assert.Contains(t, clause, `path: ["count"]`)
+		assert.Contains(t, clause, "operator: Equal")
+		assert.Contains(t, clause, "valueInt: 5")
+		assert.NotContains(t, clause, "valueString")
+	})
+
+	t.Run("float filter uses valueNumber", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "score", Operator: "GreaterThan", Value: 0.75},
+		})
+		assert.Contains(t, clause, "operator: GreaterThan")
+		assert.Contains(t, clause, "valueNumber: 0.75")
+	})
+
+	t.Run("bool filter uses valueBoolean", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "published", Operator: "Equal", Value: false},
+		})
+		assert.Contains(t, clause, "valueBoolean: false")
+	})
+
+	t.Run("string filter uses valueString", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "author", Operator: "Equal", Value: "jane"},
+		})
+		assert.Contains(t, clause, `valueString: "jane"`)
+	})
+
+	t.Run("in filter uses ContainsAny with a list value", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "category", Operator: "ContainsAny", Value: []interface{}{"news", "blog"}},
+		})
+		assert.Contains(t, clause, "operator: ContainsAny")
+		assert.Contains(t, clause, `valueString: ["news", "blog"]`)
+	})
+
+	t.Run("in filter with ints uses valueInt list", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "year", Operator: "ContainsAny", Value: []interface{}{int64(2023), int64(2024)}},
+		})
+		assert.Contains(t, clause, "valueInt: [2023, 2024]")
+	})
+
+	t.Run("like filter uses Like operator", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "title", Operator: "Like", Value: "*report*"},
+		})
+		assert.Contains(t, clause, "operator: Like")
+		assert.Contains(t, clause, `valueString: "*report*"`)
+	})
+
+	t.Run("multiple filters are combined with And", func(t *testing.T) {
+		clause := buildMetadataWhereClause([]MetadataFilter{
+			{Key: "count", Operator: "GreaterThanEqual", Value: int64(1)},
+			{Key: "published", Operator: "Equal", Value: true},
+		})
+		assert.Contains(t, clause, "operator: And")
+		assert.Contains(t, clause, "valueInt: 1")
+		assert.Contains(t, clause, "valueBoolean: true")
+	})
+}
+
+// TestParseFilterExpression tests nested And/Or/Not filter expressions
+func TestParseFilterExpression(t *testing.T) {
+	t.Run("(a=1 OR b=2) AND NOT c=3", func(t *testing.T) {
+		expr, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "And",
+			"operands": []interface{}{
+				map[string]interface{}{
+					"operator": "or",
+					"operands": []interface{}{"a=1", "b=2"},
+				},
+				map[string]interface{}{
+					"operator": "Not",
+					"operands": []interface{}{"c=3"},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "And", expr.Operator)
+		require.Len(t, expr.Operands, 2)
+		assert.Equal(t, "Or", expr.Operands[0].Operator)
+		assert.Equal(t, "Not", expr.Operands[1].Operator)
+
+		clause, err := buildFilterExpressionClause(expr)
+		require.NoError(t, err)
+		assert.Contains(t, clause, "operator: And")
+		assert.Contains(t, clause, "operator: Or")
+		assert.Contains(t, clause, `path: ["a"]`)
+		assert.Contains(t, clause, `path: ["b"]`)
+		assert.Contains(t, clause, "operator: NotEqual")
+		assert.Contains(t, clause, "valueInt: 3")
+		assert.NotContains(t, clause, "operator: Not,")
+	})
+
+	t.Run("single filter string is a leaf", func(t *testing.T) {
+		expr, err := ParseFilterExpression("count>=5")
+		require.NoError(t, err)
+		require.NotNil(t, expr.Filter)
+		assert.Equal(t, MetadataFilter{Key: "count", Operator: "GreaterThanEqual", Value: int64(5)}, *expr.Filter)
+	})
+
+	t.Run("not over or applies De Morgan", func(t *testing.T) {
+		expr, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "Not",
+			"operands": []interface{}{
+				map[string]interface{}{
+					"operator": "Or",
+					"operands": []interface{}{"a=1", "b>2"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		clause, err := buildFilterExpressionClause(expr)
+		require.NoError(t, err)
+		assert.Contains(t, clause, "operator: And")
+		assert.Contains(t, clause, "operator: NotEqual")
+		assert.Contains(t, clause, "operator: LessThanEqual")
+		assert.NotContains(t, clause, "operator: Or")
+	})
+
+	t.Run("not requires exactly one operand", func(t *testing.T) {
+		_, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "Not",
+			"operands": []interface{}{"a=1", "b=2"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one operand")
+	})
+
+	t.Run("invalid operator", func(t *testing.T) {
+		_, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "Xor",
+			"operands": []interface{}{"a=1"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be And, Or, or Not")
+	})
+
+	t.Run("empty operands", func(t *testing.T) {
+		_, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "And",
+			"operands": []interface{}{},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "non-empty operands")
+	})
+
+	t.Run("like filters cannot be negated", func(t *testing.T) {
+		expr, err := ParseFilterExpression(map[string]interface{}{
+			"operator": "Not",
+			"operands": []interface{}{"title like *report*"},
+		})
+		require.NoError(t, err)
+
+		_, err = buildFilterExpressionClause(expr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not support Not")
+	})
+}
+
+func TestParseFilterMap(t *testing.T) {
+	t.Run("plain values and operator objects", func(t *testing.T) {
+		filters, err := ParseFilterMap(map[string]interface{}{
+			"type":   "pdf",
+			"pages":  map[string]interface{}{"operator": "greaterThan", "value": float64(10)},
+			"score":  map[string]interface{}{"operator": "LessThanEqual", "value": 0.5},
+			"title":  map[string]interface{}{"operator": "Like", "value": "*report*"},
+			"tags":   map[string]interface{}{"operator": "ContainsAny", "value": []interface{}{"a", "b"}},
+			"public": true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []MetadataFilter{
+			{Key: "pages", Operator: "GreaterThan", Value: int64(10)},
+			{Key: "public", Operator: "Equal", Value: true},
+			{Key: "score", Operator: "LessThanEqual", Value: 0.5},
+			{Key: "tags", Operator: "ContainsAny", Value: []interface{}{"a", "b"}},
+			{Key: "title", Operator: "Like", Value: "*report*"},
+			{Key: "type", Operator: "Equal", Value: "pdf"},
+		}, filters)
+
+		clause := buildMetadataWhereClause(filters)
+		assert.Contains(t, clause, "operator: GreaterThan")
+		assert.Contains(t, clause, "valueInt: 10")
+		assert.Contains(t, clause, `valueString: ["a", "b"]`)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		cases := map[string]struct {
+			filters map[string]interface{}
+			err     string
+		}{
+			"unknown operator":    {map[string]interface{}{"a": map[string]interface{}{"operator": "Between", "value": 1}}, "unknown operator"},
+			"missing value":       {map[string]interface{}{"a": map[string]interface{}{"operator": "Equal"}}, "value is required"},
+			"non-numeric compare": {map[string]interface{}{"a": map[string]interface{}{"operator": "GreaterThan", "value": "x"}}, "requires a numeric value"},
+			"non-string like":     {map[string]interface{}{"a": map[string]interface{}{"operator": "Like", "value": 1}}, "requires a string pattern"},
+			"empty contains list": {map[string]interface{}{"a": map[string]interface{}{"operator": "ContainsAll", "value": []interface{}{}}}, "non-empty list"},
+			"mixed contains list": {map[string]interface{}{"a": map[string]interface{}{"operator": "ContainsAny", "value": []interface{}{"x", 1}}}, "same type"},
+			"unsupported value":   {map[string]interface{}{"a": []interface{}{"x"}}, "unsupported value type"},
+			"empty key":           {map[string]interface{}{" ": "x"}, "key must not be empty"},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := ParseFilterMap(tc.filters)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GenerativeSearchResult holds the generated answer and the documents it was
+// generated from
+type GenerativeSearchResult struct {
+	Answer  string        `json:"answer"`
+	Sources []QueryResult `json:"sources"`
+	Modules []string      `json:"modules"`
+}
+
+// GenerativeSearch runs a nearText search and asks the collection's
+// generative module to synthesize a single answer from the top results using
+// the prompt as the grouped task. It fails if the collection has no
+// generative module enabled.
+func (c *Client) GenerativeSearch(ctx context.Context, collectionName, queryText, prompt string, limit int) (*GenerativeSearchResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 5
+	}
+
+	moduleConfig, err := c.GetCollectionModuleConfig(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if !moduleConfig.GenerativeEnabled() {
+		return nil, fmt.Errorf("collection '%s' has no generative module enabled; configure a generative-* module in its moduleConfig", collectionName)
+	}
+
+	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	contentField := queryContentField(schema)
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					nearText: {
+						concepts: [%s]
+					}
+					limit: %d
+				) {
+					_additional {
+						id
+						distance
+						certainty
+						generate(groupedResult: {task: %s}) {
+							groupedResult
+							error
+						}
+					}
+					%s
+					metadata
+				}
+			}
+		}`, collectionName, graphQLString(queryText), limit, graphQLString(prompt), contentField)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute generative search query: %w", err)
+	}
+	if hasGraphQLErrors(result) {
+		return nil, fmt.Errorf("generative search failed: %s", result.Errors[0].Message)
+	}
+
+	sources, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generative search results: %v", err)
+	}
+	if sources == nil {
+		sources = []QueryResult{}
+	}
+
+	getData, _ := result.Data["Get"].(map[string]interface{})
+	answer, err := groupedResult(getData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenerativeSearchResult{
+		Answer:  answer,
+		Sources: sources,
+		Modules: moduleConfig.GenerativeModules,
+	}, nil
+}
+
+// groupedResult extracts the generated answer, which Weaviate attaches to the
+// first result object
+func groupedResult(getData map[string]interface{}) (string, error) {
+	for _, results := range getData {
+		items, _ := results.([]interface{})
+		if len(items) == 0 {
+			return "", nil
+		}
+		item, _ := items[0].(map[string]interface{})
+		additional, _ := item["_additional"].(map[string]interface{})
+		generate, _ := additional["generate"].(map[string]interface{})
+		if message, ok := generate["error"].(string); ok && message != "" {
+			return "", fmt.Errorf("generative module error: %s", message)
+		}
+		answer, _ := generate["groupedResult"].(string)
+		return answer, nil
+	}
+	return "", nil
+}
+
+// queryContentField picks the property searched results are read from,
+// preferring content over text
+func queryContentField(schema *CollectionSchema) string {
+	hasText := false
+	for _, prop := range schema.Properties {
+		if prop.Name == "content" {
+			return "content"
+		}
+		if prop.Name == "text" {
+			hasText = true
+		}
+	}
+	if hasText {
+		return "text"
+	}
+	return "content"
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// collectionNamePattern matches the collection (class) names accepted in
+// GraphQL queries
+var collectionNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// operatorPattern matches GraphQL enum values such as filter operators
+var operatorPattern = regexp.MustCompile(`^[A-Za-z]+$`)
+
+// ValidateCollectionName checks that a collection name is a valid Weaviate
+// class name. Queries place collection names in GraphQL verbatim, so anything
+// else could change the query.
+func ValidateCollectionName(name string) error {
+	if !collectionNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid collection name %q: must start with a letter and contain only letters, digits and underscores", name)
+	}
+	return nil
+}
+
+// validateOperator checks that a filter operator is a bare GraphQL enum value
+func validateOperator(operator string) error {
+	if !operatorPattern.MatchString(operator) {
+		return fmt.Errorf("invalid filter operator %q", operator)
+	}
+	return nil
+}
+
+// graphQLString returns s as a quoted GraphQL string literal, escaping
+// backslashes, quotes and control characters. GraphQL string escapes are a
+// subset of JSON's, so JSON encoding produces a valid literal. Every string
+// value placed in a query goes through it.
+func graphQLString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adversarialValues are strings that would break out of a naively quoted
+// GraphQL string literal
+var adversarialValues = []string{
+	`say "hi"`,
+	`"}) { __schema { types { name } } } #`,
+	"line one\nline two\r\ttabbed",
+	`trailing backslash \`,
+	`\"escaped quote`,
+	"nul \x00 and bell \x07",
+	`*wild* ] } )`,
+}
+
+// checkWellFormedGraphQL checks that every string literal in a query is
+// terminated and free of raw control characters, and that braces, brackets
+// and parentheses balance outside of strings
+func checkWellFormedGraphQL(query string) error {
+	closers := map[rune]rune{'}': '{', ']': '[', ')': '('}
+	var stack []rune
+	inString := false
+	escaped := false
+	for _, r := range query {
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case inString && r == '"':
+			inString = false
+		case inString && r < 0x20:
+			return fmt.Errorf("raw control character %q in string literal", r)
+		case inString:
+		case r == '"':
+			inString = true
+		case r == '{' || r == '[' || r == '(':
+			stack = append(stack, r)
+		case closers[r] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != closers[r] {
+				return fmt.Errorf("unbalanced %q", r)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if inString {
+		return fmt.Errorf("unterminated string literal")
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+// TestValidateCollectionName tests collection name validation
+func TestValidateCollectionName(t *testing.T) {
+	for _, name := range []string{"Docs", "docs", "WeaveDocs_2", "A"} {
+		assert.NoError(t, ValidateCollectionName(name), name)
+	}
+	for _, name := range []string{"", "2Docs", "_Docs", "Docs-2", "Docs Two", "Docs(where: {})", "Docs\n", "Docs}", "Dócs"} {
+		assert.Error(t, ValidateCollectionName(name), name)
+	}
+}
+
+// TestGraphQLString tests that quoted literals are well-formed and round-trip
+func TestGraphQLString(t *testing.T) {
+	for _, value := range adversarialValues {
+		literal := graphQLString(value)
+		require.NoError(t, checkWellFormedGraphQL(literal), value)
+
+		var decoded string
+		require.NoError(t, json.Unmarshal([]byte(literal), &decoded))
+		assert.Equal(t, value, decoded)
+	}
+}
+
+// TestGraphQLQueriesWithAdversarialInput tests that query text, filter values
+// and document IDs cannot break out of their string literals
+func TestGraphQLQueriesWithAdversarialInput(t *testing.T) {
+	ctx := context.Background()
+
+	for _, value := range adversarialValues {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		queries := map[string]func() error{
+			"nearText": func() error {
+				_, err := client.Query(ctx, "Docs", value, QueryOptions{})
+				return err
+			},
+			"bm25": func() error {
+				_, err := client.Query(ctx, "Docs", value, QueryOptions{UseBM25: true, Properties: []string{"text"}})
+				return err
+			},
+			"hybrid": func() error {
+				_, err := client.QueryHybrid(ctx, "Docs", value, HybridOptions{Alpha: 0.5})
+				return err
+			},
+			"filter value": func() error {
+				_, err := client.QueryWithFilters(ctx, "Docs", "hello", QueryOptions{}, map[string]interface{}{"title": value})
+				return err
+			},
+			"filter list": func() error {
+				_, err := client.QueryWithFilters(ctx, "Docs", "hello", QueryOptions{}, map[string]interface{}{
+					"title": map[string]interface{}{"operator": "ContainsAny", "value": []interface{}{value, "other"}},
+				})
+				return err
+			},
+			"document id": func() error {
+				_, err := client.GetDocument(ctx, "Docs", value)
+				return err
+			},
+		}
+		for name, run := range queries {
+			t.Run(fmt.Sprintf("%s %q", name, value), func(t *testing.T) {
+				fake.lastQuery = ""
+				_ = run()
+				require.NotEmpty(t, fake.lastQuery, "query was not sent")
+				assert.NoError(t, checkWellFormedGraphQL(fake.lastQuery), fake.lastQuery)
+				assert.Contains(t, fake.lastQuery, graphQLString(value))
+			})
+		}
+	}
+}
+
+// TestGraphQLQueriesRejectInvalidNames tests that invalid collection names and
+// filter operators are rejected before any query is sent
+func TestGraphQLQueriesRejectInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWeaviate{collection: "Docs", count: 1}
+	client := newFakeWeaviateClient(t, fake)
+
+	collection := `Docs(where: {path: ["id"], operator: Like, valueString: "*"}) { _additional { id } } Other`
+
+	_, err := client.Query(ctx, collection, "hello", QueryOptions{})
+	assert.ErrorContains(t, err, "invalid collection name")
+	_, err = client.QueryHybrid(ctx, collection, "hello", HybridOptions{Alpha: 0.5})
+	assert.ErrorContains(t, err, "invalid collection name")
+	_, err = client.QueryNearObject(ctx, collection, "doc-0", 1)
+	assert.ErrorContains(t, err, "invalid collection name")
+	_, err = client.GetDocument(ctx, collection, "doc-0")
+	assert.ErrorContains(t, err, "invalid collection name")
+	_, err = client.CountDocuments(ctx, collection)
+	assert.ErrorContains(t, err, "invalid collection name")
+
+	filter := FilterExpression{Filter: &MetadataFilter{Key: "title", Operator: "Equal } ) {", Value: "x"}}
+	_, err = client.QueryWithFilterExpression(ctx, "Docs", "hello", QueryOptions{}, filter)
+	assert.ErrorContains(t, err, "invalid filter operator")
+
+	assert.Zero(t, atomic.LoadInt32(&fake.getQueries))
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connection pool defaults of the REST client. Go's default of 2 idle
+// connections per host is below DeleteDocumentsBulk's concurrency, so most
+// deletes would otherwise open a new connection.
+const (
+	DefaultMaxIdleConnsPerHost = 16
+	DefaultIdleConnTimeout     = 90 * time.Second
+
+	restRequestTimeout = 30 * time.Second
+)
+
+// ValidateHTTPTransport checks the connection pool settings of a client
+func ValidateHTTPTransport(maxIdleConnsPerHost int, idleConnTimeout time.Duration) error {
+	if maxIdleConnsPerHost < 0 {
+		return fmt.Errorf("max idle connections per host must not be negative (got %d)", maxIdleConnsPerHost)
+	}
+	if idleConnTimeout < 0 {
+		return fmt.Errorf("idle connection timeout must not be negative (got %s)", idleConnTimeout)
+	}
+	return nil
+}
+
+// transportKey identifies the connection pool settings of a transport
+type transportKey struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+// sharedTransport returns the transport for the client's pool settings.
+// Clients are created per request, so they share transports to keep idle
+// connections reusable across requests instead of stranding them.
+func sharedTransport(config *Config) *http.Transport {
+	key := transportKey{
+		maxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		idleConnTimeout:     config.IdleConnTimeout,
+	}
+	if key.maxIdleConnsPerHost == 0 {
+		key.maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if key.idleConnTimeout == 0 {
+		key.idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if transport, ok := transports[key]; ok {
+		return transport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = key.maxIdleConnsPerHost
+	if transport.MaxIdleConns < key.maxIdleConnsPerHost {
+		transport.MaxIdleConns = key.maxIdleConnsPerHost
+	}
+	transport.IdleConnTimeout = key.idleConnTimeout
+	transports[key] = transport
+	return transport
+}
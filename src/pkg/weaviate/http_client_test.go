@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSharedTransport tests the connection pool settings and their sharing
+func TestSharedTransport(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		transport := sharedTransport(&Config{})
+		assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+		assert.GreaterOrEqual(t, transport.MaxIdleConns, DefaultMaxIdleConnsPerHost)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		transport := sharedTransport(&Config{MaxIdleConnsPerHost: 200, IdleConnTimeout: 5 * time.Second})
+		assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 200, transport.MaxIdleConns)
+		assert.Equal(t, 5*time.Second, transport.IdleConnTimeout)
+	})
+
+	t.Run("shared by clients with the same settings", func(t *testing.T) {
+		first, err := NewClient(&Config{URL: "http://localhost:8080"})
+		require.NoError(t, err)
+		second, err := NewClient(&Config{URL: "http://localhost:8081", IdleConnTimeout: DefaultIdleConnTimeout})
+		require.NoError(t, err)
+		assert.Same(t, first.httpClient.Transport, second.httpClient.Transport)
+
+		other, err := NewClient(&Config{URL: "http://localhost:8080", MaxIdleConnsPerHost: 4})
+		require.NoError(t, err)
+		assert.NotSame(t, first.httpClient.Transport, other.httpClient.Transport)
+	})
+
+	t.Run("negative settings rejected", func(t *testing.T) {
+		_, err := NewClient(&Config{URL: "http://localhost:8080", MaxIdleConnsPerHost: -1})
+		assert.Error(t, err)
+		_, err = NewClient(&Config{URL: "http://localhost:8080", IdleConnTimeout: -time.Second})
+		assert.Error(t, err)
+	})
+}
+
+// TestDeleteDocumentsBulkReusesConnections tests that bulk deletes share pooled connections
+func TestDeleteDocumentsBulkReusesConnections(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{URL: server.URL, MaxIdleConnsPerHost: 10})
+	require.NoError(t, err)
+
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+	deleted, err := client.DeleteDocumentsBulk(context.Background(), "Docs", ids)
+	require.NoError(t, err)
+	assert.Equal(t, len(ids), deleted)
+	// A connection may close before its goroutine's next delete picks it up,
+	// but most deletes must reuse one instead of dialling
+	assert.Less(t, atomic.LoadInt32(&connections), int32(20))
+}
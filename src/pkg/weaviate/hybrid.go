@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Hybrid search fusion algorithms
+const (
+	FusionTypeRanked        = "rankedFusion"
+	FusionTypeRelativeScore = "relativeScoreFusion"
+)
+
+// DefaultHybridAlpha weights hybrid search towards vector search (1.0 is
+// pure vector search, 0.0 pure keyword search)
+const DefaultHybridAlpha = 0.75
+
+// HybridOptions holds options for hybrid search queries
+type HybridOptions struct {
+	TopK       int
+	Alpha      float64
+	FusionType string // FusionTypeRanked or FusionTypeRelativeScore; empty uses the server default
+}
+
+// ValidateHybridOptions checks alpha and the fusion type
+func ValidateHybridOptions(options HybridOptions) error {
+	if !(options.Alpha >= 0 && options.Alpha <= 1) { // also rejects NaN
+		return fmt.Errorf("alpha must be between 0.0 and 1.0, got %g", options.Alpha)
+	}
+	switch options.FusionType {
+	case "", FusionTypeRanked, FusionTypeRelativeScore:
+		return nil
+	}
+	return fmt.Errorf("fusion_type must be %s or %s, got %q", FusionTypeRanked, FusionTypeRelativeScore, options.FusionType)
+}
+
+// QueryHybrid runs a hybrid search combining vector and keyword (BM25)
+// search, weighted by options.Alpha and combined with options.FusionType.
+// Scores are normalized like semantic search scores.
+func (c *Client) QueryHybrid(ctx context.Context, collectionName, queryText string, options HybridOptions) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := ValidateHybridOptions(options); err != nil {
+		return nil, err
+	}
+	if options.TopK <= 0 {
+		options.TopK = 5
+	}
+
+	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	contentField := queryContentField(schema)
+
+	hybrid := fmt.Sprintf("query: %s\n\t\t\t\t\t\talpha: %g", graphQLString(queryText), options.Alpha)
+	if options.FusionType != "" {
+		hybrid += "\n\t\t\t\t\t\tfusionType: " + options.FusionType
+	}
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					hybrid: {
+						%s
+					}
+					limit: %d
+				) {
+					_additional {
+						id
+						score
+					}
+					%s
+					metadata
+				}
+			}
+		}`, collectionName, hybrid, options.TopK, contentField)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute hybrid search query: %w", err)
+	}
+	if result != nil && len(result.Errors) > 0 {
+		return nil, fmt.Errorf("hybrid search failed: %s", result.Errors[0].Message)
+	}
+
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hybrid search results: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ImageDataEncodingKey is the metadata key that marks how image_data is stored.
+// Documents without the marker hold plain base64 and are returned unchanged,
+// so existing uncompressed collections keep working.
+const ImageDataEncodingKey = "image_data_encoding"
+
+// ImageDataEncodingGzip marks image_data stored as base64(gzip(image_data))
+const ImageDataEncodingGzip = "gzip+base64"
+
+// compressImageData gzips the image data and base64-encodes the result
+func compressImageData(imageData string) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(imageData)); err != nil {
+		return "", fmt.Errorf("failed to compress image data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress image data: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decompressImageData reverses compressImageData
+func decompressImageData(stored string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode compressed image data: %w", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress image data: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress image data: %w", err)
+	}
+	return string(data), nil
+}
+
+// encodeImageData returns the image data to store and the metadata to store
+// with it. When compression is enabled and actually shrinks the data, the
+// compressed form is returned and the metadata is marked; otherwise the
+// document is stored as-is.
+func (c *Client) encodeImageData(document Document) (string, map[string]interface{}, error) {
+	if !c.config.CompressImageData || document.ImageData == "" {
+		return document.ImageData, document.Metadata, nil
+	}
+
+	compressed, err := compressImageData(document.ImageData)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(compressed) >= len(document.ImageData) {
+		return document.ImageData, document.Metadata, nil
+	}
+
+	metadata := make(map[string]interface{}, len(document.Metadata)+1)
+	for key, value := range document.Metadata {
+		metadata[key] = value
+	}
+	metadata[ImageDataEncodingKey] = ImageDataEncodingGzip
+	return compressed, metadata, nil
+}
+
+// decodeImageData sets doc.ImageData from the retrieved properties,
+// decompressing it when the metadata carries the gzip marker
+func decodeImageData(doc *Document, properties map[string]interface{}) error {
+	imageData, ok := properties["image_data"].(string)
+	if !ok || imageData == "" {
+		return nil
+	}
+
+	if imageDataEncoding(properties) == ImageDataEncodingGzip {
+		decompressed, err := decompressImageData(imageData)
+		if err != nil {
+			return err
+		}
+		imageData = decompressed
+		if doc.Metadata != nil {
+			doc.Metadata["image_data"] = imageData
+		}
+	}
+
+	doc.ImageData = imageData
+	return nil
+}
+
+// imageDataEncoding reads the encoding marker from the metadata property,
+// which may be stored as an object or as a JSON string
+func imageDataEncoding(properties map[string]interface{}) string {
+	switch metadata := properties["metadata"].(type) {
+	case map[string]interface{}:
+		encoding, _ := metadata[ImageDataEncodingKey].(string)
+		return encoding
+	case string:
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(metadata), &parsed); err != nil {
+			return ""
+		}
+		encoding, _ := parsed[ImageDataEncodingKey].(string)
+		return encoding
+	}
+	return ""
+}
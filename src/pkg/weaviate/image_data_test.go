@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImageDataCompression tests gzip+base64 image data storage
+func TestImageDataCompression(t *testing.T) {
+	imageData := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("pixel", 2000)))
+
+	t.Run("round trip", func(t *testing.T) {
+		compressed, err := compressImageData(imageData)
+		require.NoError(t, err)
+		assert.Less(t, len(compressed), len(imageData))
+
+		decompressed, err := decompressImageData(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, imageData, decompressed)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client := &Client{config: &Config{}}
+		stored, metadata, err := client.encodeImageData(Document{ImageData: imageData})
+		require.NoError(t, err)
+		assert.Equal(t, imageData, stored)
+		assert.NotContains(t, metadata, ImageDataEncodingKey)
+	})
+
+	t.Run("enabled compresses and marks metadata", func(t *testing.T) {
+		client := &Client{config: &Config{CompressImageData: true}}
+		original := map[string]interface{}{"source": "camera"}
+		stored, metadata, err := client.encodeImageData(Document{ImageData: imageData, Metadata: original})
+		require.NoError(t, err)
+		assert.Less(t, len(stored), len(imageData))
+		assert.Equal(t, ImageDataEncodingGzip, metadata[ImageDataEncodingKey])
+		assert.Equal(t, "camera", metadata["source"])
+		assert.NotContains(t, original, ImageDataEncodingKey, "caller metadata should not be modified")
+	})
+
+	t.Run("incompressible data is stored as-is", func(t *testing.T) {
+		client := &Client{config: &Config{CompressImageData: true}}
+		stored, metadata, err := client.encodeImageData(Document{ImageData: "aGk="})
+		require.NoError(t, err)
+		assert.Equal(t, "aGk=", stored)
+		assert.NotContains(t, metadata, ImageDataEncodingKey)
+	})
+
+	t.Run("retrieval decompresses marked data", func(t *testing.T) {
+		compressed, err := compressImageData(imageData)
+		require.NoError(t, err)
+
+		for name, metadata := range map[string]interface{}{
+			"object metadata": map[string]interface{}{ImageDataEncodingKey: ImageDataEncodingGzip},
+			"json metadata":   `{"image_data_encoding":"gzip+base64"}`,
+		} {
+			properties := map[string]interface{}{"image_data": compressed, "metadata": metadata}
+			doc := Document{Metadata: map[string]interface{}{"image_data": compressed}}
+			require.NoError(t, decodeImageData(&doc, properties), name)
+			assert.Equal(t, imageData, doc.ImageData, name)
+			assert.Equal(t, imageData, doc.Metadata["image_data"], name)
+		}
+	})
+
+	t.Run("retrieval leaves unmarked data unchanged", func(t *testing.T) {
+		doc := Document{}
+		require.NoError(t, decodeImageData(&doc, map[string]interface{}{"image_data": imageData}))
+		assert.Equal(t, imageData, doc.ImageData)
+	})
+}
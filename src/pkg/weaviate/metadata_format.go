@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Formats of a collection's metadata property
+const (
+	// MetadataFormatText stores metadata as a JSON string (RagMeDocs-style schemas)
+	MetadataFormatText = "text"
+	// MetadataFormatObject stores metadata as a native object (RagMeImages-style schemas)
+	MetadataFormatObject = "object"
+)
+
+// metadataSchemaCacheTTL bounds how long a collection's metadata schema is
+// reused before it is fetched again
+const metadataSchemaCacheTTL = 5 * time.Minute
+
+// simpleMetadataQuery selects the metadata property without a sub-selection
+const simpleMetadataQuery = "\n\t\t\t\tmetadata"
+
+// metadataSchemaInfo is what list and get queries need to know about a
+// collection's metadata property
+type metadataSchemaInfo struct {
+	format  string // MetadataFormatText or MetadataFormatObject
+	query   string // GraphQL selection for the metadata property
+	expires time.Time
+}
+
+// metadataSchemaCache caches metadataSchemaInfo per collection so the hot
+// list/get path does not fetch the schema on every query. It is keyed by
+// Weaviate URL and collection and shared by all clients, since the server
+// creates short-lived clients per call.
+type metadataSchemaCache struct {
+	mu      sync.Mutex
+	entries map[string]metadataSchemaInfo
+}
+
+var metadataSchemas metadataSchemaCache
+
+func metadataSchemaKey(url, collectionName string) string {
+	return url + "\x00" + collectionName
+}
+
+func (m *metadataSchemaCache) get(url, collectionName string) (metadataSchemaInfo, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	info, ok := m.entries[metadataSchemaKey(url, collectionName)]
+	if !ok || time.Now().After(info.expires) {
+		return metadataSchemaInfo{}, false
+	}
+	return info, true
+}
+
+func (m *metadataSchemaCache) set(url, collectionName string, info metadataSchemaInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string]metadataSchemaInfo)
+	}
+	info.expires = time.Now().Add(metadataSchemaCacheTTL)
+	m.entries[metadataSchemaKey(url, collectionName)] = info
+}
+
+func (m *metadataSchemaCache) invalidate(url, collectionName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, metadataSchemaKey(url, collectionName))
+}
+
+// metadataSchema returns the metadata schema of a collection, fetching it
+// when it is not cached or has expired. Failed fetches are not cached.
+func (c *Client) metadataSchema(ctx context.Context, collectionName string) (metadataSchemaInfo, error) {
+	if info, ok := metadataSchemas.get(c.config.URL, collectionName); ok {
+		return info, nil
+	}
+
+	info, err := c.fetchMetadataSchema(ctx, collectionName)
+	if err != nil {
+		return metadataSchemaInfo{}, err
+	}
+	metadataSchemas.set(c.config.URL, collectionName, info)
+	return info, nil
+}
+
+// InvalidateMetadataSchema drops the cached metadata schema of a collection.
+// Call it after changing the collection's schema outside this client.
+func (c *Client) InvalidateMetadataSchema(collectionName string) {
+	metadataSchemas.invalidate(c.config.URL, collectionName)
+}
+
+// MetadataFormatFromSchema returns how a schema stores document metadata:
+// as an object when its metadata property is an object, otherwise as JSON text
+func MetadataFormatFromSchema(schema *CollectionSchema) string {
+	if schema == nil {
+		return MetadataFormatText
+	}
+	for _, prop := range schema.Properties {
+		if prop.Name == "metadata" && len(prop.DataType) > 0 && prop.DataType[0] == "object" {
+			return MetadataFormatObject
+		}
+	}
+	return MetadataFormatText
+}
+
+// MetadataFormat returns the metadata format of a collection, falling back to
+// text when the schema cannot be read
+func (c *Client) MetadataFormat(ctx context.Context, collectionName string) string {
+	info, err := c.metadataSchema(ctx, collectionName)
+	if err != nil {
+		return MetadataFormatText
+	}
+	return info.format
+}
+
+// FormatMetadata returns metadata as the value to store in the metadata
+// property: the map itself for object properties, a JSON string otherwise.
+// Writing the wrong shape makes later queries fail with "must not have a sub
+// selection".
+func FormatMetadata(metadata map[string]interface{}, format string) (interface{}, error) {
+	if format == MetadataFormatObject {
+		if metadata == nil {
+			return map[string]interface{}{}, nil
+		}
+		return metadata, nil
+	}
+
+	if metadata == nil {
+		return "", nil
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return string(metadataBytes), nil
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Module name prefixes used by Weaviate for generative and reranker modules
+const (
+	generativeModulePrefix = "generative-"
+	rerankerModulePrefix   = "reranker-"
+)
+
+// CollectionModuleConfig describes the modules configured on a collection
+type CollectionModuleConfig struct {
+	Class             string                 `json:"class"`
+	Vectorizer        string                 `json:"vectorizer,omitempty"`
+	ModuleConfig      map[string]interface{} `json:"moduleConfig"`
+	GenerativeModules []string               `json:"generativeModules"`
+	RerankerModules   []string               `json:"rerankerModules"`
+}
+
+// GenerativeEnabled reports whether a generative module is configured
+func (m *CollectionModuleConfig) GenerativeEnabled() bool {
+	return len(m.GenerativeModules) > 0
+}
+
+// RerankerEnabled reports whether a reranker module is configured
+func (m *CollectionModuleConfig) RerankerEnabled() bool {
+	return len(m.RerankerModules) > 0
+}
+
+// GetCollectionModuleConfig returns the moduleConfig of a collection from the
+// Weaviate schema, along with the generative and reranker modules it enables
+func (c *Client) GetCollectionModuleConfig(ctx context.Context, collectionName string) (*CollectionModuleConfig, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	schema, err := c.client.Schema().Getter().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema: %w", err)
+	}
+
+	for _, class := range schema.Classes {
+		if class.Class == collectionName {
+			return newCollectionModuleConfig(class.Class, class.Vectorizer, class.ModuleConfig), nil
+		}
+	}
+
+	return nil, fmt.Errorf("collection '%s' not found in schema", collectionName)
+}
+
+// newCollectionModuleConfig classifies the modules in a raw class moduleConfig
+func newCollectionModuleConfig(class, vectorizer string, raw interface{}) *CollectionModuleConfig {
+	config := &CollectionModuleConfig{
+		Class:             class,
+		Vectorizer:        vectorizer,
+		ModuleConfig:      make(map[string]interface{}),
+		GenerativeModules: []string{},
+		RerankerModules:   []string{},
+	}
+
+	modules, ok := raw.(map[string]interface{})
+	if !ok {
+		return config
+	}
+
+	for name, settings := range modules {
+		config.ModuleConfig[name] = settings
+		switch {
+		case strings.HasPrefix(name, generativeModulePrefix):
+			config.GenerativeModules = append(config.GenerativeModules, name)
+		case strings.HasPrefix(name, rerankerModulePrefix):
+			config.RerankerModules = append(config.RerankerModules, name)
+		}
+	}
+	sort.Strings(config.GenerativeModules)
+	sort.Strings(config.RerankerModules)
+
+	return config
+}
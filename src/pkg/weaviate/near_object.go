@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// QueryNearObject returns the objects closest to an existing object in the
+// collection's vector space, using nearObject. The object itself is left out
+// of the results, which are ordered by similarity.
+func (c *Client) QueryNearObject(ctx context.Context, collectionName, objectID string, limit int) ([]QueryResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 5
+	}
+
+	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	contentField := queryContentField(schema)
+
+	// Ask for one extra result since the object is its own nearest neighbor
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					nearObject: {
+						id: %s
+					}
+					limit: %d
+				) {
+					_additional {
+						id
+						distance
+						certainty
+					}
+					%s
+					metadata
+				}
+			}
+		}`, collectionName, graphQLString(objectID), limit+1, contentField)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute nearObject query: %w", err)
+	}
+	if hasGraphQLErrors(result) {
+		return nil, fmt.Errorf("nearObject query failed: %w", graphQLError(result))
+	}
+
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse nearObject query results: %v", err)
+	}
+
+	neighbors := make([]QueryResult, 0, len(results))
+	for _, res := range results {
+		if res.ID == objectID {
+			continue
+		}
+		if len(neighbors) == limit {
+			break
+		}
+		neighbors = append(neighbors, res)
+	}
+	return neighbors, nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NearTextMove steers a nearText search toward (moveTo) or away from
+// (moveAway) concepts. Force, between 0 and 1, sets how far the query vector
+// is moved.
+type NearTextMove struct {
+	Concepts []string `json:"concepts"`
+	Force    float64  `json:"force"`
+}
+
+// validate rejects a move without concepts or with a force outside [0, 1]
+func (m *NearTextMove) validate(name string) error {
+	if len(m.Concepts) == 0 {
+		return fmt.Errorf("%s needs at least one concept", name)
+	}
+	for _, concept := range m.Concepts {
+		if strings.TrimSpace(concept) == "" {
+			return fmt.Errorf("%s concepts must not be empty", name)
+		}
+	}
+	if m.Force < 0 || m.Force > 1 {
+		return fmt.Errorf("%s force must be between 0 and 1 (got %g)", name, m.Force)
+	}
+	return nil
+}
+
+// ValidateNearText checks the Concepts, MoveTo, and MoveAway options
+func ValidateNearText(options QueryOptions) error {
+	for _, concept := range options.Concepts {
+		if strings.TrimSpace(concept) == "" {
+			return fmt.Errorf("concepts must not be empty")
+		}
+	}
+	if options.MoveTo != nil {
+		if err := options.MoveTo.validate("move_to"); err != nil {
+			return err
+		}
+	}
+	if options.MoveAway != nil {
+		if err := options.MoveAway.validate("move_away"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// steered reports whether options ask for moveTo or moveAway
+func (o QueryOptions) steered() bool {
+	return o.MoveTo != nil || o.MoveAway != nil
+}
+
+// nearTextConcepts returns the query text followed by the extra concepts.
+// An empty query text is left out when there are extra concepts.
+func nearTextConcepts(queryText string, options QueryOptions) []string {
+	concepts := make([]string, 0, len(options.Concepts)+1)
+	if queryText != "" || len(options.Concepts) == 0 {
+		concepts = append(concepts, queryText)
+	}
+	return append(concepts, options.Concepts...)
+}
+
+// nearTextConceptList quotes the nearText concepts for a GraphQL list
+func nearTextConceptList(queryText string, options QueryOptions) string {
+	concepts := nearTextConcepts(queryText, options)
+	quoted := make([]string, len(concepts))
+	for i, concept := range concepts {
+		quoted[i] = graphQLString(concept)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// keywordQueryText joins the query text and extra concepts for the keyword
+// searches (BM25 and the fallbacks), which take a single query string
+func keywordQueryText(queryText string, options QueryOptions) string {
+	return strings.Join(nearTextConcepts(queryText, options), " ")
+}
+
+// nearTextMoveArguments returns the moveTo and moveAway arguments of a
+// nearText search, or "" when options do not steer it
+func nearTextMoveArguments(options QueryOptions) string {
+	var arguments strings.Builder
+	for _, move := range []struct {
+		name string
+		move *NearTextMove
+	}{
+		{"moveTo", options.MoveTo},
+		{"moveAway", options.MoveAway},
+	} {
+		if move.move == nil {
+			continue
+		}
+		quoted := make([]string, len(move.move.Concepts))
+		for i, concept := range move.move.Concepts {
+			quoted[i] = graphQLString(concept)
+		}
+		fmt.Fprintf(&arguments, "\n\t\t\t\t\t\t%s: {\n\t\t\t\t\t\t\tconcepts: [%s]\n\t\t\t\t\t\t\tforce: %s\n\t\t\t\t\t\t}",
+			move.name, strings.Join(quoted, ", "), strconv.FormatFloat(move.move.Force, 'f', -1, 64))
+	}
+	return arguments.String()
+}
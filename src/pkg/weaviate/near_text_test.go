@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNearText(t *testing.T) {
+	tests := []struct {
+		name    string
+		options QueryOptions
+		wantErr string
+	}{
+		{"no steering", QueryOptions{}, ""},
+		{"valid moves", QueryOptions{
+			Concepts: []string{"cooking"},
+			MoveTo:   &NearTextMove{Concepts: []string{"italian"}, Force: 0.5},
+			MoveAway: &NearTextMove{Concepts: []string{"dessert"}, Force: 1},
+		}, ""},
+		{"empty concept", QueryOptions{Concepts: []string{" "}}, "concepts must not be empty"},
+		{"move without concepts", QueryOptions{MoveTo: &NearTextMove{Force: 0.5}}, "move_to needs at least one concept"},
+		{"force above one", QueryOptions{MoveAway: &NearTextMove{Concepts: []string{"x"}, Force: 1.5}}, "move_away force must be between 0 and 1"},
+		{"negative force", QueryOptions{MoveTo: &NearTextMove{Concepts: []string{"x"}, Force: -0.1}}, "move_to force must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNearText(tt.options)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNearTextMoveArguments(t *testing.T) {
+	assert.Empty(t, nearTextMoveArguments(QueryOptions{}))
+
+	arguments := nearTextMoveArguments(QueryOptions{
+		MoveTo:   &NearTextMove{Concepts: []string{"italian", `say "ciao"`}, Force: 0.85},
+		MoveAway: &NearTextMove{Concepts: []string{"dessert"}, Force: 0.45},
+	})
+	assert.Contains(t, arguments, `moveTo: {`)
+	assert.Contains(t, arguments, `concepts: ["italian", "say \"ciao\""]`)
+	assert.Contains(t, arguments, `force: 0.85`)
+	assert.Contains(t, arguments, `moveAway: {`)
+	assert.Contains(t, arguments, `concepts: ["dessert"]`)
+	assert.Contains(t, arguments, `force: 0.45`)
+}
+
+func TestQueryWithSteering(t *testing.T) {
+	ctx := context.Background()
+	steering := QueryOptions{
+		TopK:     2,
+		Concepts: []string{"pasta"},
+		MoveTo:   &NearTextMove{Concepts: []string{"italian"}, Force: 0.5},
+	}
+
+	t.Run("concepts and moves are sent with nearText", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, searchMode, steered, err := client.QueryWithSteering(ctx, "Docs", "dinner", steering)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, SearchModeNearText, searchMode)
+		assert.True(t, steered)
+		assert.Contains(t, fake.lastQuery, `concepts: ["dinner", "pasta"]`)
+		assert.Contains(t, fake.lastQuery, "moveTo:")
+	})
+
+	t.Run("query text is optional with concepts", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, _, steered, err := client.QueryWithSteering(ctx, "Docs", "", QueryOptions{Concepts: []string{"pasta", "pizza"}})
+		require.NoError(t, err)
+		assert.False(t, steered)
+		assert.Contains(t, fake.lastQuery, `concepts: ["pasta", "pizza"]`)
+	})
+
+	t.Run("rejected steering is retried without it", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2, unsupported: []string{"moveTo"}}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, searchMode, steered, err := client.QueryWithSteering(ctx, "Docs", "dinner", steering)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, SearchModeNearText, searchMode)
+		assert.False(t, steered)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.getQueries), "steered nearText, then plain nearText")
+		assert.NotContains(t, fake.lastQuery, "moveTo:")
+		assert.False(t, client.searchModeUnsupported("Docs", SearchModeNearText))
+	})
+
+	t.Run("fallbacks search the concepts as keywords", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText}}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, searchMode, steered, err := client.QueryWithSteering(ctx, "Docs", "dinner", steering)
+		require.NoError(t, err)
+		assert.Equal(t, SearchModeHybrid, searchMode)
+		assert.False(t, steered)
+		assert.Contains(t, fake.lastQuery, `"dinner pasta"`)
+	})
+
+	t.Run("invalid steering is rejected before querying", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, _, _, err := client.QueryWithSteering(ctx, "Docs", "dinner", QueryOptions{MoveAway: &NearTextMove{Concepts: []string{"x"}, Force: 2}})
+		require.Error(t, err)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fake.getQueries))
+	})
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// NodeStatusHealthy is the status Weaviate reports for a healthy node
+const NodeStatusHealthy = "HEALTHY"
+
+// NodeShard is a shard hosted by a node
+type NodeShard struct {
+	Name                 string `json:"name"`
+	Class                string `json:"class"`
+	ObjectCount          int64  `json:"objectCount"`
+	VectorIndexingStatus string `json:"vectorIndexingStatus"`
+	VectorQueueLength    int64  `json:"vectorQueueLength"`
+}
+
+// NodeStats are the totals of a node
+type NodeStats struct {
+	ShardCount  int64 `json:"shardCount"`
+	ObjectCount int64 `json:"objectCount"`
+}
+
+// NodeStatus is the status of one node of a Weaviate cluster
+type NodeStatus struct {
+	Name    string      `json:"name"`
+	Status  string      `json:"status"`
+	Version string      `json:"version"`
+	GitHash string      `json:"gitHash"`
+	Stats   NodeStats   `json:"stats"`
+	Shards  []NodeShard `json:"shards"`
+}
+
+// GetNodes returns the status of each node of the cluster with its shards,
+// limited to the shards of collectionName when it is set
+func (c *Client) GetNodes(ctx context.Context, collectionName string) ([]NodeStatus, error) {
+	endpoint := c.config.URL + "/v1/nodes"
+	if collectionName != "" {
+		endpoint += "/" + url.PathEscape(collectionName)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?output=verbose", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get nodes: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var nodes struct {
+		Nodes []NodeStatus `json:"nodes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("failed to decode nodes: %w", err)
+	}
+	return nodes.Nodes, nil
+}
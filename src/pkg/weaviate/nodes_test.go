@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetNodes tests reading cluster node status, for all collections and one
+func TestGetNodes(t *testing.T) {
+	var lastRequest *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"nodes": []map[string]interface{}{{
+				"name":    "node-1",
+				"status":  "HEALTHY",
+				"version": "1.25.0",
+				"stats":   map[string]interface{}{"shardCount": 1, "objectCount": 42},
+				"shards": []map[string]interface{}{
+					{"name": "abc", "class": "Articles", "objectCount": 42, "vectorIndexingStatus": "READY"},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+
+	nodes, err := client.GetNodes(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/nodes", lastRequest.URL.Path)
+	assert.Equal(t, "verbose", lastRequest.URL.Query().Get("output"))
+	require.Len(t, nodes, 1)
+	assert.Equal(t, NodeStatus{
+		Name:    "node-1",
+		Status:  NodeStatusHealthy,
+		Version: "1.25.0",
+		Stats:   NodeStats{ShardCount: 1, ObjectCount: 42},
+		Shards:  []NodeShard{{Name: "abc", Class: "Articles", ObjectCount: 42, VectorIndexingStatus: "READY"}},
+	}, nodes[0])
+
+	_, err = client.GetNodes(context.Background(), "Articles")
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/nodes/Articles", lastRequest.URL.Path)
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// vectorizerNone is the vectorizer of collections that embed nothing themselves
+const vectorizerNone = "none"
+
+// CollectionVectorizers are the vectorizer modules of a collection: the
+// class-level one, and those of its named vectors by vector name
+type CollectionVectorizers struct {
+	Default string
+	Named   map[string]string
+}
+
+// GetCollectionVectorizers reads a collection's vectorizer modules from the
+// REST schema, which also carries named vectors (vectorConfig)
+func (c *Client) GetCollectionVectorizers(ctx context.Context, collectionName string) (*CollectionVectorizers, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		fmt.Sprintf("%s/v1/schema/%s", c.config.URL, url.PathEscape(collectionName)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get schema for %s: status %d, body: %s", collectionName, resp.StatusCode, string(body))
+	}
+
+	var schema struct {
+		Vectorizer   string `json:"vectorizer"`
+		VectorConfig map[string]struct {
+			Vectorizer map[string]interface{} `json:"vectorizer"`
+		} `json:"vectorConfig"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("failed to decode schema for %s: %w", collectionName, err)
+	}
+
+	vectorizers := &CollectionVectorizers{Default: schema.Vectorizer, Named: make(map[string]string)}
+	for name, config := range schema.VectorConfig {
+		// The vectorizer object has a single key, the module name
+		for module := range config.Vectorizer {
+			vectorizers.Named[name] = module
+		}
+	}
+	return vectorizers, nil
+}
+
+// ResolveQueryVectorizer returns the named vector whose module embeds query
+// text as the vectorizer module (or named vector) requested, or "" when the
+// collection's default vector already uses it. Weaviate only embeds queries
+// with modules configured on the collection, and another module's vectors
+// would not match the dimension or space of the stored ones, so any other
+// vectorizer is an error.
+func (c *Client) ResolveQueryVectorizer(ctx context.Context, collectionName, vectorizer string) (string, error) {
+	vectorizers, err := c.GetCollectionVectorizers(ctx, collectionName)
+	if err != nil {
+		return "", err
+	}
+
+	if vectorizer == vectorizers.Default && vectorizer != vectorizerNone {
+		return "", nil
+	}
+	if _, ok := vectorizers.Named[vectorizer]; ok {
+		return vectorizer, nil
+	}
+
+	names := make([]string, 0, len(vectorizers.Named))
+	for name := range vectorizers.Named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var matches []string
+	for _, name := range names {
+		if vectorizers.Named[name] == vectorizer {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+	default:
+		return "", fmt.Errorf("collection %s has several vectors embedded with %s (%s); pass the vector name instead",
+			collectionName, vectorizer, strings.Join(matches, ", "))
+	}
+
+	var available []string
+	if vectorizers.Default != "" && vectorizers.Default != vectorizerNone {
+		available = append(available, vectorizers.Default)
+	}
+	for _, name := range names {
+		available = append(available, fmt.Sprintf("%s (vector %s)", vectorizers.Named[name], name))
+	}
+	if len(available) == 0 {
+		return "", fmt.Errorf("collection %s has no vectorizer module, so query text cannot be embedded with %s", collectionName, vectorizer)
+	}
+	return "", fmt.Errorf("collection %s has no vector embedded with %s, so its query vectors would not match the stored ones (available: %s)",
+		collectionName, vectorizer, strings.Join(available, ", "))
+}
+
+// targetVectorsArgument returns the nearText targetVectors argument for the
+// named vector options select, if any
+func targetVectorsArgument(options QueryOptions) string {
+	if options.TargetVector == "" {
+		return ""
+	}
+	return "\n\t\t\t\t\t\ttargetVectors: [" + graphQLString(options.TargetVector) + "]"
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolveQueryVectorizer tests mapping a requested vectorizer module to
+// the named vector that embeds queries with it
+func TestResolveQueryVectorizer(t *testing.T) {
+	schemas := map[string]interface{}{
+		"Articles": map[string]interface{}{
+			"class":      "Articles",
+			"vectorizer": "text2vec-openai",
+		},
+		"Named": map[string]interface{}{
+			"class": "Named",
+			"vectorConfig": map[string]interface{}{
+				"english":      map[string]interface{}{"vectorizer": map[string]interface{}{"text2vec-openai": map[string]interface{}{}}},
+				"multilingual": map[string]interface{}{"vectorizer": map[string]interface{}{"text2vec-cohere": map[string]interface{}{}}},
+				"backup":       map[string]interface{}{"vectorizer": map[string]interface{}{"text2vec-openai": map[string]interface{}{}}},
+			},
+		},
+		"Plain": map[string]interface{}{
+			"class":      "Plain",
+			"vectorizer": "none",
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		schema, ok := schemas[r.URL.Path[len("/v1/schema/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(schema)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		collection string
+		vectorizer string
+		expected   string
+		err        string
+	}{
+		{"default vectorizer", "Articles", "text2vec-openai", "", ""},
+		{"module of one named vector", "Named", "text2vec-cohere", "multilingual", ""},
+		{"vector name", "Named", "backup", "backup", ""},
+		{"module of several named vectors", "Named", "text2vec-openai", "", "several vectors embedded with text2vec-openai (backup, english)"},
+		{"module not on the collection", "Articles", "text2vec-cohere", "", "no vector embedded with text2vec-cohere, so its query vectors would not match the stored ones (available: text2vec-openai)"},
+		{"collection without a vectorizer", "Plain", "text2vec-openai", "", "has no vectorizer module"},
+		{"none is never a query vectorizer", "Plain", "none", "", "has no vectorizer module"},
+		{"unknown collection", "Missing", "text2vec-openai", "", "status 404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := client.ResolveQueryVectorizer(ctx, tt.collection, tt.vectorizer)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+// TestQueryTargetVector tests that a target vector is passed to nearText
+func TestQueryTargetVector(t *testing.T) {
+	fake := &fakeWeaviate{collection: "Docs", count: 1}
+	client := newFakeWeaviateClient(t, fake)
+
+	_, err := client.Query(context.Background(), "Docs", "hola", QueryOptions{TargetVector: "multilingual"})
+	require.NoError(t, err)
+	assert.Contains(t, fake.lastQuery, `targetVectors: ["multilingual"]`)
+
+	_, err = client.Query(context.Background(), "Docs", "hello", QueryOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, fake.lastQuery, "targetVectors")
+}
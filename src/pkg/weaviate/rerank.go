@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrNoRerankerModule is returned when reranking is requested for a
+// collection without a reranker module
+var ErrNoRerankerModule = errors.New("collection has no reranker module enabled")
+
+// RerankedResult is a query result reordered by a reranker module
+type RerankedResult struct {
+	QueryResult
+	OriginalScore float64 `json:"original_score"`
+	OriginalRank  int     `json:"original_rank"`
+	RerankScore   float64 `json:"rerank_score"`
+}
+
+// QueryReranked runs a nearText search and reorders the results with the
+// collection's reranker module. Results are returned in reranked order, with
+// Score set to the rerank score and the vector search score and rank kept as
+// OriginalScore and OriginalRank (1-based). Returns ErrNoRerankerModule if the
+// collection has no reranker module.
+func (c *Client) QueryReranked(ctx context.Context, collectionName, queryText string, limit int) ([]RerankedResult, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 5
+	}
+
+	moduleConfig, err := c.GetCollectionModuleConfig(ctx, collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if !moduleConfig.RerankerEnabled() {
+		return nil, fmt.Errorf("%w: %s", ErrNoRerankerModule, collectionName)
+	}
+
+	schema, err := c.GetFullCollectionSchema(ctx, collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+	contentField := queryContentField(schema)
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					nearText: {
+						concepts: [%s]
+					}
+					limit: %d
+				) {
+					_additional {
+						id
+						distance
+						certainty
+						rerank(property: %s, query: %s) {
+							score
+						}
+					}
+					%s
+					metadata
+				}
+			}
+		}`, collectionName, graphQLString(queryText), limit, graphQLString(contentField), graphQLString(queryText), contentField)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute reranked query: %w", err)
+	}
+	if hasGraphQLErrors(result) {
+		return nil, fmt.Errorf("reranked query failed: %s", result.Errors[0].Message)
+	}
+
+	results, err := c.parseQueryResults(result, contentField, schema.DistanceMetric(), c.scoreNormalization(""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reranked query results: %v", err)
+	}
+
+	getData, _ := result.Data["Get"].(map[string]interface{})
+	scores := rerankScores(getData)
+
+	reranked := make([]RerankedResult, len(results))
+	for i, res := range results {
+		reranked[i] = RerankedResult{
+			QueryResult:   res,
+			OriginalScore: res.Score,
+			OriginalRank:  i + 1,
+			RerankScore:   scores[res.ID],
+		}
+		reranked[i].Score = reranked[i].RerankScore
+	}
+
+	// Keep the vector search order among equal rerank scores
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].RerankScore > reranked[j].RerankScore
+	})
+
+	return reranked, nil
+}
+
+// rerankScores maps result IDs to their rerank score
+func rerankScores(getData map[string]interface{}) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, results := range getData {
+		items, _ := results.([]interface{})
+		for _, item := range items {
+			itemMap, _ := item.(map[string]interface{})
+			additional, _ := itemMap["_additional"].(map[string]interface{})
+			id, _ := additional["id"].(string)
+			// Weaviate returns rerank as a list with a single entry
+			rerank, _ := additional["rerank"].([]interface{})
+			if len(rerank) == 0 {
+				continue
+			}
+			entry, _ := rerank[0].(map[string]interface{})
+			if score, ok := entry["score"].(float64); ok {
+				scores[id] = score
+			}
+		}
+	}
+	return scores
+}
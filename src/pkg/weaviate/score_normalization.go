@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Score normalization strategies, applied to raw similarity scores
+const (
+	ScoreNormalizationNone      = "none"      // raw scores, unclamped
+	ScoreNormalizationQuadratic = "quadratic" // score^2 (default)
+	ScoreNormalizationLinear    = "linear"    // raw scores clamped to [0, 1]
+	ScoreNormalizationSigmoid   = "sigmoid"   // logistic curve centred on 0.5
+)
+
+// DefaultScoreNormalization is the strategy used when none is configured
+const DefaultScoreNormalization = ScoreNormalizationQuadratic
+
+// sigmoidSteepness sets how sharply the sigmoid strategy separates scores
+// either side of 0.5
+const sigmoidSteepness = 10.0
+
+// ValidateScoreNormalization checks a score normalization strategy; empty
+// selects the default
+func ValidateScoreNormalization(strategy string) error {
+	switch strategy {
+	case "", ScoreNormalizationNone, ScoreNormalizationQuadratic, ScoreNormalizationLinear, ScoreNormalizationSigmoid:
+		return nil
+	}
+	return fmt.Errorf("unknown score normalization '%s' (expected %s, %s, %s, or %s)", strategy,
+		ScoreNormalizationNone, ScoreNormalizationQuadratic, ScoreNormalizationLinear, ScoreNormalizationSigmoid)
+}
+
+// scoreNormalization returns the strategy for a query: the query's own when
+// set, otherwise the client's configured one, otherwise the default
+func (c *Client) scoreNormalization(override string) string {
+	if override != "" {
+		return override
+	}
+	if c.config != nil && c.config.ScoreNormalization != "" {
+		return c.config.ScoreNormalization
+	}
+	return DefaultScoreNormalization
+}
+
+// normalizeScore maps a raw similarity score with a normalization strategy.
+// Every strategy but none first clamps the score to [0, 1].
+//
+// quadratic (score^2) spreads scores across a wider range, making
+// low-relevance results (0.5) appear much lower (0.25) while keeping
+// high-relevance results (0.7 → 0.49, 0.9 → 0.81) relatively high. linear
+// keeps the clamped score. sigmoid pushes scores away from 0.5 (0.3 → 0.11,
+// 0.7 → 0.89), rescaled so 0 and 1 map to themselves.
+func normalizeScore(rawScore float64, strategy string) float64 {
+	if strategy == ScoreNormalizationNone {
+		return rawScore
+	}
+
+	score := math.Max(0, math.Min(1, rawScore))
+	switch strategy {
+	case ScoreNormalizationLinear:
+		return score
+	case ScoreNormalizationSigmoid:
+		low, high := sigmoid(0), sigmoid(1)
+		return (sigmoid(score) - low) / (high - low)
+	default:
+		// Use quadratic function to amplify differences
+		return math.Pow(score, 2.0)
+	}
+}
+
+// sigmoid is the logistic curve centred on 0.5 with sigmoidSteepness
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-sigmoidSteepness*(x-0.5)))
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalizeScore tests each strategy on known inputs, including raw
+// scores outside [0, 1]
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		strategy string
+		raw      float64
+		expected float64
+	}{
+		{ScoreNormalizationQuadratic, 0.5, 0.25},
+		{ScoreNormalizationQuadratic, 0.9, 0.81},
+		{ScoreNormalizationQuadratic, 1, 1},
+		{ScoreNormalizationQuadratic, -0.2, 0},
+		{ScoreNormalizationQuadratic, 1.7, 1},
+		{ScoreNormalizationLinear, 0.5, 0.5},
+		{ScoreNormalizationLinear, 0.83, 0.83},
+		{ScoreNormalizationLinear, -3, 0},
+		{ScoreNormalizationLinear, 4.2, 1},
+		{ScoreNormalizationSigmoid, 0, 0},
+		{ScoreNormalizationSigmoid, 0.5, 0.5},
+		{ScoreNormalizationSigmoid, 1, 1},
+		{ScoreNormalizationSigmoid, 0.3, 0.1140},
+		{ScoreNormalizationSigmoid, 0.7, 0.8860},
+		{ScoreNormalizationSigmoid, -1, 0},
+		{ScoreNormalizationSigmoid, 2, 1},
+		{ScoreNormalizationNone, 0.5, 0.5},
+		{ScoreNormalizationNone, -0.2, -0.2},
+		{ScoreNormalizationNone, 7.5, 7.5},
+		{"", 0.5, 0.25},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, normalizeScore(tt.raw, tt.strategy), 0.0005, "%s(%g)", tt.strategy, tt.raw)
+	}
+}
+
+// TestScoreNormalizationSelection tests validation and the precedence of
+// per-query, configured, and default strategies
+func TestScoreNormalizationSelection(t *testing.T) {
+	assert.NoError(t, ValidateScoreNormalization(""))
+	assert.NoError(t, ValidateScoreNormalization(ScoreNormalizationSigmoid))
+	assert.Error(t, ValidateScoreNormalization("cubic"))
+
+	_, err := NewClient(&Config{URL: "http://localhost:8080", ScoreNormalization: "cubic"})
+	assert.ErrorContains(t, err, "unknown score normalization 'cubic'")
+
+	client := &Client{config: &Config{}}
+	assert.Equal(t, ScoreNormalizationQuadratic, client.scoreNormalization(""))
+	assert.Equal(t, ScoreNormalizationLinear, client.scoreNormalization(ScoreNormalizationLinear))
+
+	client.config.ScoreNormalization = ScoreNormalizationNone
+	assert.Equal(t, ScoreNormalizationNone, client.scoreNormalization(""))
+	assert.Equal(t, ScoreNormalizationSigmoid, client.scoreNormalization(ScoreNormalizationSigmoid))
+}
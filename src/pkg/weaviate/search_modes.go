@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Search modes probed per collection
+const (
+	SearchModeNearText = "nearText"
+	SearchModeBM25     = "bm25"
+	SearchModeHybrid   = "hybrid"
+)
+
+// SearchModes lists the probed search modes in fallback order
+var SearchModes = []string{SearchModeNearText, SearchModeBM25, SearchModeHybrid}
+
+// searchModeCacheTTL bounds how long a search mode result is trusted, so
+// modules enabled later are picked up without a restart
+const searchModeCacheTTL = 10 * time.Minute
+
+// SearchCapabilities reports which search modes a collection supports
+type SearchCapabilities struct {
+	Collection string          `json:"collection"`
+	Modes      map[string]bool `json:"modes"`
+	// Probed lists the modes queried for this call; the rest came from the cache
+	Probed []string `json:"probed"`
+}
+
+type searchModeEntry struct {
+	supported bool
+	expires   time.Time
+}
+
+// searchModeCache remembers which search modes each collection supports, so
+// queries skip modes known to fail instead of paying for a failing request
+// before falling back. It is keyed by Weaviate URL and collection and shared
+// by all clients, since the server creates short-lived clients per call.
+type searchModeCache struct {
+	mu      sync.Mutex
+	entries map[string]searchModeEntry
+}
+
+var searchModes searchModeCache
+
+func searchModeKey(url, collectionName, mode string) string {
+	return url + "\x00" + collectionName + "\x00" + mode
+}
+
+func (m *searchModeCache) get(url, collectionName, mode string) (supported, known bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[searchModeKey(url, collectionName, mode)]
+	if !ok || time.Now().After(entry.expires) {
+		return false, false
+	}
+	return entry.supported, true
+}
+
+func (m *searchModeCache) set(url, collectionName, mode string, supported bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string]searchModeEntry)
+	}
+	m.entries[searchModeKey(url, collectionName, mode)] = searchModeEntry{
+		supported: supported,
+		expires:   time.Now().Add(searchModeCacheTTL),
+	}
+}
+
+func (m *searchModeCache) invalidate(url, collectionName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, mode := range SearchModes {
+		delete(m.entries, searchModeKey(url, collectionName, mode))
+	}
+}
+
+// CachedSearchMode returns whether a mode is supported on a collection of the
+// Weaviate instance at url, and whether that is known from an earlier query or probe
+func CachedSearchMode(url, collectionName, mode string) (supported, known bool) {
+	return searchModes.get(url, collectionName, mode)
+}
+
+// searchModeUnsupported reports whether a mode is known to fail on a collection
+func (c *Client) searchModeUnsupported(collectionName, mode string) bool {
+	supported, known := CachedSearchMode(c.config.URL, collectionName, mode)
+	return known && !supported
+}
+
+// recordSearchMode caches whether a mode worked on a collection
+func (c *Client) recordSearchMode(collectionName, mode string, supported bool) {
+	searchModes.set(c.config.URL, collectionName, mode, supported)
+}
+
+// InvalidateSearchModes drops the cached search modes of a collection
+func (c *Client) InvalidateSearchModes(collectionName string) {
+	searchModes.invalidate(c.config.URL, collectionName)
+}
+
+// InvalidateCollectionCaches drops everything cached about a collection of
+// the Weaviate instance at url. Call it when the collection is created or
+// deleted without going through a Client.
+func InvalidateCollectionCaches(url, collectionName string) {
+	metadataSchemas.invalidate(url, collectionName)
+	searchModes.invalidate(url, collectionName)
+}
+
+// invalidateCollectionCaches drops everything cached about a collection
+// when it is created or deleted
+func (c *Client) invalidateCollectionCaches(collectionName string) {
+	InvalidateCollectionCaches(c.config.URL, collectionName)
+}
+
+// GetSearchCapabilities returns the search modes a collection supports.
+// Modes not in the cache (or all modes, with refresh) are probed with a
+// one-result query and cached.
+func (c *Client) GetSearchCapabilities(ctx context.Context, collectionName string, refresh bool) (*SearchCapabilities, error) {
+	// Probing a missing collection would mark every mode unsupported
+	if _, err := c.GetFullCollectionSchema(ctx, collectionName); err != nil {
+		return nil, fmt.Errorf("failed to get collection schema: %w", err)
+	}
+
+	capabilities := &SearchCapabilities{
+		Collection: collectionName,
+		Modes:      make(map[string]bool, len(SearchModes)),
+		Probed:     []string{},
+	}
+	for _, mode := range SearchModes {
+		if !refresh {
+			if supported, known := searchModes.get(c.config.URL, collectionName, mode); known {
+				capabilities.Modes[mode] = supported
+				continue
+			}
+		}
+
+		supported, err := c.probeSearchMode(ctx, collectionName, mode)
+		if err != nil {
+			return nil, err
+		}
+		c.recordSearchMode(collectionName, mode, supported)
+		capabilities.Modes[mode] = supported
+		capabilities.Probed = append(capabilities.Probed, mode)
+	}
+	return capabilities, nil
+}
+
+// probeSearchMode runs a one-result query in a search mode. GraphQL errors
+// mean the mode is unsupported; request failures are returned as errors.
+func (c *Client) probeSearchMode(ctx context.Context, collectionName, mode string) (bool, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return false, err
+	}
+
+	var operator string
+	switch mode {
+	case SearchModeNearText:
+		operator = `nearText: { concepts: ["probe"] }`
+	case SearchModeBM25:
+		operator = `bm25: { query: "probe" }`
+	case SearchModeHybrid:
+		operator = `hybrid: { query: "probe" }`
+	default:
+		return false, fmt.Errorf("unknown search mode '%s' (expected %s)", mode, strings.Join(SearchModes, ", "))
+	}
+
+	query := fmt.Sprintf(`
+		{
+			Get {
+				%s(
+					%s
+					limit: 1
+				) {
+					_additional {
+						id
+					}
+				}
+			}
+		}`, collectionName, operator)
+
+	result, err := c.client.GraphQL().Raw().WithQuery(query).Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to probe %s search: %w", mode, err)
+	}
+	return !hasGraphQLErrors(result), nil
+}
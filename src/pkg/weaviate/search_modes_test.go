@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchModeCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unsupported modes are skipped after the first failure", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2, unsupported: []string{SearchModeNearText}}
+		client := newFakeWeaviateClient(t, fake)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.getQueries), "nearText fails, then hybrid")
+
+		_, err = client.Query(ctx, "Docs", "hello", QueryOptions{TopK: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&fake.getQueries), "nearText is skipped")
+		assert.Contains(t, fake.lastQuery, "hybrid:")
+	})
+
+	t.Run("cache is shared by clients of the same instance", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&fake.getQueries), "nearText, hybrid, then where")
+
+		other, err := NewClient(client.config)
+		require.NoError(t, err)
+		_, err = other.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), atomic.LoadInt32(&fake.getQueries), "only the where query runs")
+		assert.Contains(t, fake.lastQuery, "where:")
+	})
+
+	t.Run("capabilities are probed once and cached", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeBM25}}
+		client := newFakeWeaviateClient(t, fake)
+
+		capabilities, err := client.GetSearchCapabilities(ctx, "Docs", false)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{SearchModeNearText: true, SearchModeBM25: false, SearchModeHybrid: true}, capabilities.Modes)
+		assert.Equal(t, SearchModes, capabilities.Probed)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&fake.getQueries))
+
+		capabilities, err = client.GetSearchCapabilities(ctx, "Docs", false)
+		require.NoError(t, err)
+		assert.Empty(t, capabilities.Probed)
+		assert.False(t, capabilities.Modes[SearchModeBM25])
+		assert.Equal(t, int32(3), atomic.LoadInt32(&fake.getQueries))
+
+		capabilities, err = client.GetSearchCapabilities(ctx, "Docs", true)
+		require.NoError(t, err)
+		assert.Equal(t, SearchModes, capabilities.Probed)
+		assert.Equal(t, int32(6), atomic.LoadInt32(&fake.getQueries))
+	})
+
+	t.Run("invalidation forgets cached modes", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText}}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.NoError(t, err)
+		assert.True(t, client.searchModeUnsupported("Docs", SearchModeNearText))
+
+		client.InvalidateSearchModes("Docs")
+		assert.False(t, client.searchModeUnsupported("Docs", SearchModeNearText))
+	})
+
+	t.Run("missing collections are not probed", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs"}
+		client := newFakeWeaviateClient(t, fake)
+
+		_, err := client.GetSearchCapabilities(ctx, "Missing", false)
+		assert.Error(t, err)
+		assert.Zero(t, atomic.LoadInt32(&fake.getQueries))
+	})
+}
+
+func TestSearchFallbackChain(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(t *testing.T, fake *fakeWeaviate, chain []string) *Client {
+		client := newFakeWeaviateClient(t, fake)
+		client.config.SearchFallback = chain
+		return client
+	}
+
+	t.Run("default chain ends with the simple fallback", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newClient(t, fake, nil)
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 0.0, results[0].Score)
+		assert.True(t, results[0].ScoreUnavailable)
+		assert.Equal(t, SearchModeFallbackKeyword, results[0].SearchMode)
+		assert.Contains(t, fake.lastQuery, "where:")
+	})
+
+	t.Run("hybrid only never runs the simple fallback", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newClient(t, fake, []string{SearchFallbackHybrid})
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nearText, hybrid")
+		assert.NotContains(t, fake.lastQuery, "where:")
+	})
+
+	t.Run("empty chain disables fallbacks", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText}}
+		client := newClient(t, fake, []string{})
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallbacks are disabled")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fake.getQueries))
+	})
+
+	t.Run("successful fallback returns its results", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 2, unsupported: []string{SearchModeBM25}}
+		client := newClient(t, fake, []string{SearchFallbackHybrid})
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{UseBM25: true})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Contains(t, fake.lastQuery, "hybrid:")
+	})
+
+	t.Run("max fallback depth bounds the chain", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newClient(t, fake, nil)
+		client.config.MaxFallbackDepth = 1
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "maximum fallback depth of 1")
+		assert.Contains(t, err.Error(), "nearText: search mode not supported: unknown argument nearText")
+		assert.Contains(t, err.Error(), "hybrid: search mode not supported: unknown argument hybrid")
+		assert.NotContains(t, fake.lastQuery, "where:")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fake.getQueries))
+	})
+
+	t.Run("depth beyond the chain runs every fallback", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newClient(t, fake, nil)
+		client.config.MaxFallbackDepth = 5
+
+		results, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Contains(t, fake.lastQuery, "where:")
+	})
+
+	t.Run("cached failures are reported", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid}}
+		client := newClient(t, fake, []string{SearchFallbackHybrid})
+
+		_, err := client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.Error(t, err)
+		_, err = client.Query(ctx, "Docs", "hello", QueryOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nearText: search mode not supported (cached)")
+	})
+
+	t.Run("invalid chains are rejected", func(t *testing.T) {
+		assert.Error(t, ValidateSearchFallback([]string{"nearVector"}))
+		assert.Error(t, ValidateSearchFallback([]string{SearchFallbackHybrid, SearchFallbackHybrid}))
+		assert.NoError(t, ValidateSearchFallback([]string{SearchFallbackSimple}))
+		assert.NoError(t, ValidateSearchFallback(nil))
+
+		_, err := NewClient(&Config{URL: "http://localhost:8080", SearchFallback: []string{"where"}})
+		assert.Error(t, err)
+		_, err = NewClient(&Config{URL: "http://localhost:8080", MaxFallbackDepth: -1})
+		assert.Error(t, err)
+	})
+}
+
+func TestQueryWithSearchMode(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		unsupported []string
+		options     QueryOptions
+		expected    string
+	}{
+		{"nearText answers", nil, QueryOptions{}, SearchModeNearText},
+		{"bm25 answers", nil, QueryOptions{UseBM25: true}, SearchModeBM25},
+		{"hybrid fallback", []string{SearchModeNearText}, QueryOptions{}, SearchModeHybrid},
+		{"simple fallback", []string{SearchModeNearText, SearchModeHybrid}, QueryOptions{}, SearchModeFallbackKeyword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An empty collection still reports the mode that answered
+			fake := &fakeWeaviate{collection: "Docs", count: 0, unsupported: tt.unsupported}
+			results, searchMode, err := newFakeWeaviateClient(t, fake).QueryWithSearchMode(ctx, "Docs", "hello", tt.options)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+			assert.Equal(t, tt.expected, searchMode)
+		})
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		fake := &fakeWeaviate{collection: "Docs", count: 1, unsupported: []string{SearchModeNearText, SearchModeHybrid, "where"}}
+		_, searchMode, err := newFakeWeaviateClient(t, fake).QueryWithSearchMode(ctx, "Docs", "hello", QueryOptions{})
+		assert.Error(t, err)
+		assert.Empty(t, searchMode)
+	})
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Shard statuses reported by Weaviate
+const (
+	ShardStatusReady    = "READY"
+	ShardStatusReadOnly = "READONLY"
+)
+
+// ShardStatus is the status of one shard of a collection
+type ShardStatus struct {
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	VectorQueueSize int64  `json:"vectorQueueSize"`
+}
+
+// GetShards returns the status of each shard of a collection
+func (c *Client) GetShards(ctx context.Context, collectionName string) ([]ShardStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		fmt.Sprintf("%s/v1/schema/%s/shards", c.config.URL, url.PathEscape(collectionName)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get shards for %s: status %d, body: %s", collectionName, resp.StatusCode, string(body))
+	}
+
+	var shards []ShardStatus
+	if err := json.NewDecoder(resp.Body).Decode(&shards); err != nil {
+		return nil, fmt.Errorf("failed to decode shards for %s: %w", collectionName, err)
+	}
+	return shards, nil
+}
+
+// UpdateShardStatus sets the status of a shard, e.g. to return a shard that
+// Weaviate marked READONLY (typically under disk pressure) to READY
+func (c *Client) UpdateShardStatus(ctx context.Context, collectionName, shardName, status string) error {
+	payload, err := json.Marshal(map[string]string{"status": status})
+	if err != nil {
+		return fmt.Errorf("failed to marshal shard status: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT",
+		fmt.Sprintf("%s/v1/schema/%s/shards/%s", c.config.URL, url.PathEscape(collectionName), url.PathEscape(shardName)),
+		bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update shard %s of %s: status %d, body: %s", shardName, collectionName, resp.StatusCode, string(body))
+	}
+	return nil
+}
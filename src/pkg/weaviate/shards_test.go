@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShards tests reading and updating the shard status of a collection
+func TestShards(t *testing.T) {
+	statuses := map[string]string{"shard-a": ShardStatusReady, "shard-b": ShardStatusReadOnly}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/schema/Articles/shards":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"name": "shard-a", "status": statuses["shard-a"], "vectorQueueSize": 0},
+				{"name": "shard-b", "status": statuses["shard-b"], "vectorQueueSize": 12},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/schema/Articles/shards/shard-b":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			statuses["shard-b"] = body["status"]
+			json.NewEncoder(w).Encode(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+
+	shards, err := client.GetShards(context.Background(), "Articles")
+	require.NoError(t, err)
+	assert.Equal(t, []ShardStatus{
+		{Name: "shard-a", Status: ShardStatusReady},
+		{Name: "shard-b", Status: ShardStatusReadOnly, VectorQueueSize: 12},
+	}, shards)
+
+	require.NoError(t, client.UpdateShardStatus(context.Background(), "Articles", "shard-b", ShardStatusReady))
+	assert.Equal(t, ShardStatusReady, statuses["shard-b"])
+
+	_, err = client.GetShards(context.Background(), "Missing")
+	assert.ErrorContains(t, err, "status 404")
+	assert.ErrorContains(t, client.UpdateShardStatus(context.Background(), "Articles", "shard-z", ShardStatusReady), "status 404")
+}
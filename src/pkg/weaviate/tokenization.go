@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tokenization options for text properties, which control how values are
+// split into tokens for BM25 and filtering
+const (
+	TokenizationWord       = "word"
+	TokenizationLowercase  = "lowercase"
+	TokenizationWhitespace = "whitespace"
+	TokenizationField      = "field"
+)
+
+// validTokenizations lists the accepted tokenization values in display order
+var validTokenizations = []string{
+	TokenizationWord,
+	TokenizationLowercase,
+	TokenizationWhitespace,
+	TokenizationField,
+}
+
+// ValidateTokenization checks a tokenization value and returns it lowercased
+func ValidateTokenization(tokenization string) (string, error) {
+	normalized := strings.ToLower(tokenization)
+	for _, valid := range validTokenizations {
+		if normalized == valid {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("invalid tokenization '%s': must be one of %s", tokenization, strings.Join(validTokenizations, ", "))
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateTokenization tests tokenization validation
+func TestValidateTokenization(t *testing.T) {
+	for _, value := range []string{"word", "lowercase", "whitespace", "field", "FIELD"} {
+		normalized, err := ValidateTokenization(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, strings.ToLower(value), normalized)
+	}
+
+	_, err := ValidateTokenization("trigram")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of word, lowercase, whitespace, field")
+}
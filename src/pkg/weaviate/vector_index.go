@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Distance metrics supported by Weaviate's HNSW vector index
+const (
+	DistanceCosine    = "cosine"
+	DistanceDot       = "dot"
+	DistanceL2Squared = "l2-squared"
+	DistanceHamming   = "hamming"
+	DistanceManhattan = "manhattan"
+)
+
+// defaultDistanceMetric is Weaviate's default distance metric
+const defaultDistanceMetric = DistanceCosine
+
+// validDistanceMetrics is the set of accepted distance metric values
+var validDistanceMetrics = map[string]bool{
+	DistanceCosine:    true,
+	DistanceDot:       true,
+	DistanceL2Squared: true,
+	DistanceHamming:   true,
+	DistanceManhattan: true,
+}
+
+// VectorIndexConfig holds the tunable HNSW parameters of a collection. It maps
+// to Weaviate's class-level vectorIndexConfig; zero values are omitted so
+// Weaviate's defaults apply.
+type VectorIndexConfig struct {
+	Distance       string `json:"distance,omitempty"`
+	EfConstruction int    `json:"efConstruction,omitempty"`
+	MaxConnections int    `json:"maxConnections,omitempty"`
+	Ef             int    `json:"ef,omitempty"`
+}
+
+// ParseVectorIndexConfig validates a vector index config object. Only the
+// known keys distance, efConstruction, maxConnections, and ef are accepted.
+func ParseVectorIndexConfig(raw map[string]interface{}) (*VectorIndexConfig, error) {
+	config := &VectorIndexConfig{}
+
+	for key, value := range raw {
+		switch key {
+		case "distance":
+			distance, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("vector_index_config.distance must be a string")
+			}
+			distance = strings.ToLower(distance)
+			if !validDistanceMetrics[distance] {
+				return nil, fmt.Errorf("invalid vector_index_config.distance '%s': must be one of %s", distance, strings.Join(distanceMetricNames(), ", "))
+			}
+			config.Distance = distance
+		case "efConstruction", "maxConnections", "ef":
+			n, ok := vectorIndexInt(value)
+			if !ok {
+				return nil, fmt.Errorf("vector_index_config.%s must be an integer", key)
+			}
+			// ef may be -1 to let Weaviate pick it dynamically
+			if n <= 0 && !(key == "ef" && n == -1) {
+				return nil, fmt.Errorf("vector_index_config.%s must be positive", key)
+			}
+			switch key {
+			case "efConstruction":
+				config.EfConstruction = n
+			case "maxConnections":
+				config.MaxConnections = n
+			case "ef":
+				config.Ef = n
+			}
+		default:
+			return nil, fmt.Errorf("unknown vector_index_config key '%s': supported keys are distance, efConstruction, maxConnections, ef", key)
+		}
+	}
+
+	return config, nil
+}
+
+// vectorIndexInt converts a JSON number to an int, rejecting fractions
+func vectorIndexInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int(v), true
+	}
+	return 0, false
+}
+
+// distanceMetricNames returns the supported distance metrics in sorted order
+func distanceMetricNames() []string {
+	names := make([]string, 0, len(validDistanceMetrics))
+	for name := range validDistanceMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// vectorIndexConfigFromSchema reads the known fields from a Weaviate class
+// vectorIndexConfig value
+func vectorIndexConfigFromSchema(raw interface{}) *VectorIndexConfig {
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	config := &VectorIndexConfig{}
+	if distance, ok := values["distance"].(string); ok {
+		config.Distance = distance
+	}
+	if n, ok := vectorIndexInt(values["efConstruction"]); ok {
+		config.EfConstruction = n
+	}
+	if n, ok := vectorIndexInt(values["maxConnections"]); ok {
+		config.MaxConnections = n
+	}
+	if n, ok := vectorIndexInt(values["ef"]); ok {
+		config.Ef = n
+	}
+	return config
+}
+
+// DistanceMetric returns the collection's distance metric, defaulting to cosine
+func (s *CollectionSchema) DistanceMetric() string {
+	if s == nil || s.VectorIndexConfig == nil || s.VectorIndexConfig.Distance == "" {
+		return defaultDistanceMetric
+	}
+	return s.VectorIndexConfig.Distance
+}
+
+// distanceToScore converts a raw vector distance into a 0-1 similarity score
+// for the given distance metric
+func distanceToScore(distance float64, metric string) float64 {
+	var score float64
+	switch metric {
+	case DistanceDot:
+		// Dot distance is the negative dot product; squash it into 0-1
+		score = 1.0 / (1.0 + math.Exp(distance))
+	case DistanceL2Squared, DistanceHamming, DistanceManhattan:
+		// Unbounded non-negative distances
+		score = 1.0 / (1.0 + distance)
+	default:
+		// Cosine distance is in the 0-2 range
+		score = 1.0 - (distance / 2.0)
+	}
+
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
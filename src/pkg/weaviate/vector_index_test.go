@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseVectorIndexConfig tests vector index config validation
+func TestParseVectorIndexConfig(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		config, err := ParseVectorIndexConfig(map[string]interface{}{
+			"distance":       "Dot",
+			"efConstruction": float64(256),
+			"maxConnections": float64(32),
+			"ef":             float64(-1),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &VectorIndexConfig{Distance: "dot", EfConstruction: 256, MaxConnections: 32, Ef: -1}, config)
+	})
+
+	t.Run("invalid distance", func(t *testing.T) {
+		_, err := ParseVectorIndexConfig(map[string]interface{}{"distance": "euclid"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be one of cosine, dot, hamming, l2-squared, manhattan")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := ParseVectorIndexConfig(map[string]interface{}{"cleanupIntervalSeconds": float64(300)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown vector_index_config key")
+	})
+
+	t.Run("non-integer value", func(t *testing.T) {
+		_, err := ParseVectorIndexConfig(map[string]interface{}{"maxConnections": 1.5})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be an integer")
+	})
+
+	t.Run("non-positive value", func(t *testing.T) {
+		_, err := ParseVectorIndexConfig(map[string]interface{}{"efConstruction": float64(0)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be positive")
+	})
+}
+
+// TestDistanceToScore tests distance conversion per distance metric
+func TestDistanceToScore(t *testing.T) {
+	assert.Equal(t, 1.0, distanceToScore(0, DistanceCosine))
+	assert.Equal(t, 0.5, distanceToScore(1, DistanceCosine))
+	assert.Equal(t, 0.0, distanceToScore(2, DistanceCosine))
+
+	assert.Equal(t, 1.0, distanceToScore(0, DistanceL2Squared))
+	assert.Equal(t, 0.2, distanceToScore(4, DistanceL2Squared))
+	assert.Equal(t, 0.5, distanceToScore(1, DistanceManhattan))
+
+	assert.Equal(t, 0.5, distanceToScore(0, DistanceDot))
+	assert.Greater(t, distanceToScore(-10, DistanceDot), distanceToScore(-1, DistanceDot))
+
+	// Schemas without index config default to cosine
+	var schema *CollectionSchema
+	assert.Equal(t, DistanceCosine, schema.DistanceMetric())
+	schema = &CollectionSchema{VectorIndexConfig: &VectorIndexConfig{Distance: DistanceL2Squared}}
+	assert.Equal(t, DistanceL2Squared, schema.DistanceMetric())
+}
@@ -17,8 +17,7 @@ import (
 // WeaveClient wraps the official Weaviate client with additional functionality
 type WeaveClient struct {
 	*Client
-	httpClient *http.Client
-	config     *Config
+	config *Config
 }
 
 // NewWeaveClient creates a new Weave client with enhanced functionality
@@ -29,15 +28,10 @@ func NewWeaveClient(config *Config) (*WeaveClient, error) {
 		return nil, fmt.Errorf("failed to create official client: %w", err)
 	}
 
-	// Create HTTP client for direct REST API calls
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
+	// Direct REST API calls share the official client's connection pool
 	return &WeaveClient{
-		Client:     officialClient,
-		httpClient: httpClient,
-		config:     config,
+		Client: officialClient,
+		config: config,
 	}, nil
 }
 
@@ -74,15 +68,50 @@ func (wc *WeaveClient) GetCollectionSchema(ctx context.Context, collectionName s
 	return wc.Client.GetCollectionSchema(ctx, collectionName)
 }
 
-// DeleteDocument deletes a specific document by ID using REST API
+// Defaults for retrying deletes that return 404 on eventually-consistent clusters
+const (
+	defaultDeleteNotFoundRetries = 3
+	defaultDeleteRetryDelay      = 500 * time.Millisecond
+)
+
+// DeleteDocumentOptions controls how DeleteDocumentWithOptions deletes a document
+type DeleteDocumentOptions struct {
+	// SkipExistenceCheck skips the GetDocument lookup before deleting. Use it
+	// right after a create, when the document may not be visible yet.
+	SkipExistenceCheck bool
+	// NotFoundRetries is how many times a 404 from the delete is retried
+	// (default: 3; negative disables retries)
+	NotFoundRetries int
+	// RetryDelay is the base delay between retries, growing linearly with
+	// each attempt (default: 500ms)
+	RetryDelay time.Duration
+}
+
+// DeleteDocument deletes a specific document by ID using REST API, checking that it exists first
 func (wc *WeaveClient) DeleteDocument(ctx context.Context, collectionName, documentID string) error {
+	return wc.DeleteDocumentWithOptions(ctx, collectionName, documentID, DeleteDocumentOptions{})
+}
+
+// DeleteDocumentWithOptions deletes a document using REST API. A 404 from the
+// delete is retried briefly, since on eventually-consistent clusters a
+// just-created document may not be visible yet.
+func (wc *WeaveClient) DeleteDocumentWithOptions(ctx context.Context, collectionName, documentID string, opts DeleteDocumentOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	if opts.NotFoundRetries == 0 {
+		opts.NotFoundRetries = defaultDeleteNotFoundRetries
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultDeleteRetryDelay
+	}
+
 	// First check if the document exists in this collection
-	_, err := wc.Client.GetDocument(ctx, collectionName, documentID)
-	if err != nil {
-		return fmt.Errorf("failed to delete document %s from collection %s: document not found", documentID, collectionName)
+	if !opts.SkipExistenceCheck {
+		_, err := wc.Client.GetDocument(ctx, collectionName, documentID)
+		if err != nil {
+			return fmt.Errorf("failed to delete document %s from collection %s: document not found", documentID, collectionName)
+		}
 	}
 
 	// Construct the REST API URL
@@ -93,42 +122,58 @@ func (wc *WeaveClient) DeleteDocument(ctx context.Context, collectionName, docum
 	}
 	url := fmt.Sprintf("%s/v1/objects/%s/%s", baseURL, collectionName, documentID)
 
+	for attempt := 0; ; attempt++ {
+		statusCode, body, err := wc.deleteObject(ctx, url)
+		if err != nil {
+			return fmt.Errorf("failed to delete document %s from collection %s: %w", documentID, collectionName, err)
+		}
+
+		// Check response status
+		if statusCode == http.StatusNoContent || statusCode == http.StatusOK {
+			return nil
+		}
+
+		if statusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete document %s from collection %s: HTTP %d - %s", documentID, collectionName, statusCode, string(body))
+		}
+
+		// A 404 may mean the document is not visible yet; retry briefly
+		if attempt >= opts.NotFoundRetries {
+			return fmt.Errorf("failed to delete document %s from collection %s: document not found", documentID, collectionName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to delete document %s from collection %s: %w", documentID, collectionName, ctx.Err())
+		case <-time.After(opts.RetryDelay * time.Duration(attempt+1)):
+		}
+	}
+}
+
+// deleteObject sends a single DELETE request and returns the status code and body
+func (wc *WeaveClient) deleteObject(ctx context.Context, url string) (int, []byte, error) {
 	// Create the DELETE request
 	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create delete request: %w", err)
+		return 0, nil, fmt.Errorf("failed to create delete request: %w", err)
 	}
 
-	// Add authorization header
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
-		return fmt.Errorf("failed to delete document %s from collection %s: %w", documentID, collectionName, err)
+		return 0, nil, err
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check response status
-	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK {
-		return nil
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// If we get a 404, it means the document was not found
-	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("failed to delete document %s from collection %s: document not found", documentID, collectionName)
-	}
-
-	return fmt.Errorf("failed to delete document %s from collection %s: HTTP %d - %s", documentID, collectionName, resp.StatusCode, string(body))
+	return resp.StatusCode, body, nil
 }
 
 // DeleteDocumentsBulk deletes multiple documents in a single batch operation
@@ -136,23 +181,29 @@ func (wc *WeaveClient) DeleteDocumentsBulk(ctx context.Context, collectionName s
 	return wc.Client.DeleteDocumentsBulk(ctx, collectionName, documentIDs)
 }
 
+// DeleteDocumentsBulkDetailed delegates to the official client
+func (wc *WeaveClient) DeleteDocumentsBulkDetailed(ctx context.Context, collectionName string, documentIDs []string) (*BulkDeleteResult, error) {
+	return wc.Client.DeleteDocumentsBulkDetailed(ctx, collectionName, documentIDs)
+}
+
 // DeleteDocumentsByMetadata deletes documents matching metadata filters using REST API
 func (wc *WeaveClient) DeleteDocumentsByMetadata(ctx context.Context, collectionName string, metadataFilters []string) (int, error) {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
 	// Parse metadata filters
-	filters := make(map[string]string)
-	for _, filter := range metadataFilters {
-		parts := strings.SplitN(filter, "=", 2)
-		if len(parts) != 2 {
-			return 0, fmt.Errorf("invalid metadata filter format: %s (expected key=value)", filter)
-		}
-		filters[parts[0]] = parts[1]
+	filters, err := ParseMetadataFilters(metadataFilters)
+	if err != nil {
+		return 0, err
 	}
 
-	// First, query for documents matching the metadata filters
-	documents, err := wc.queryDocumentsByMetadata(ctx, collectionName, filters)
+	return wc.DeleteDocumentsByFilter(ctx, collectionName, AllOf(filters...))
+}
+
+// DeleteDocumentsByFilter deletes documents matching a filter expression using REST API
+func (wc *WeaveClient) DeleteDocumentsByFilter(ctx context.Context, collectionName string, filter FilterExpression) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// First, query for documents matching the filter
+	documents, err := wc.queryDocumentsByMetadata(ctx, collectionName, filter)
 	if err != nil {
 		return 0, fmt.Errorf("failed to query documents by metadata: %w", err)
 	}
@@ -164,7 +215,8 @@ func (wc *WeaveClient) DeleteDocumentsByMetadata(ctx context.Context, collection
 	// Delete each document individually using REST API
 	deletedCount := 0
 	for _, doc := range documents {
-		if err := wc.DeleteDocument(ctx, collectionName, doc.ID); err != nil {
+		// Documents come from the query above, so skip the existence check
+		if err := wc.DeleteDocumentWithOptions(ctx, collectionName, doc.ID, DeleteDocumentOptions{SkipExistenceCheck: true}); err != nil {
 			// Log error but continue with other documents
 			fmt.Printf("Warning: Failed to delete document %s: %v\n", doc.ID, err)
 			continue
@@ -175,53 +227,16 @@ func (wc *WeaveClient) DeleteDocumentsByMetadata(ctx context.Context, collection
 	return deletedCount, nil
 }
 
-// queryDocumentsByMetadata queries for documents matching metadata filters using GraphQL
-func (wc *WeaveClient) queryDocumentsByMetadata(ctx context.Context, collectionName string, filters map[string]string) ([]Document, error) {
-	// Build the where clause for metadata filtering
-	var whereClauses []string
-	for key, value := range filters {
-		if key == "filename" {
-			// For filename, we need to search within the JSON string in the metadata field
-			// Use Like operator to search for the filename within the JSON string
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["metadata"]
-				operator: Like
-				valueString: "*filename\": \"%s\"*"
-			}`, value))
-		} else if key == "original_filename" {
-			// For original_filename, we need to search within the JSON string in the metadata field
-			// Use Like operator to search for the original_filename within the JSON string
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["metadata"]
-				operator: Like
-				valueString: "*original_filename\": \"%s\"*"
-			}`, value))
-		} else if key == "url" {
-			// For URL, use Like operator to allow partial matching
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["%s"]
-				operator: Like
-				valueString: "*%s*"
-			}`, key, value))
-		} else {
-			// For other fields, use direct path with exact matching
-			whereClauses = append(whereClauses, fmt.Sprintf(`{
-				path: ["%s"]
-				operator: Equal
-				valueString: "%s"
-			}`, key, value))
-		}
+// queryDocumentsByMetadata queries for documents matching a metadata filter expression using GraphQL
+func (wc *WeaveClient) queryDocumentsByMetadata(ctx context.Context, collectionName string, filter FilterExpression) ([]Document, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
 	}
 
-	// Combine multiple filters with AND
-	var whereClause string
-	if len(whereClauses) == 1 {
-		whereClause = whereClauses[0]
-	} else {
-		whereClause = fmt.Sprintf(`{
-			operator: And
-			operands: [%s]
-		}`, strings.Join(whereClauses, ", "))
+	// Build the where clause for metadata filtering
+	whereClause, err := buildFilterExpressionClause(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create GraphQL query to get documents
@@ -262,13 +277,10 @@ func (wc *WeaveClient) queryDocumentsByMetadata(ctx context.Context, collectionN
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query documents by metadata from collection %s: %w", collectionName, err)
 	}
@@ -330,21 +342,22 @@ func (wc *WeaveClient) queryDocumentsByMetadata(ctx context.Context, collectionN
 
 // GetDocumentsByMetadata gets documents matching metadata filters
 func (wc *WeaveClient) GetDocumentsByMetadata(ctx context.Context, collectionName string, metadataFilters []string) ([]Document, error) {
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
 	// Parse metadata filters
-	filters := make(map[string]string)
-	for _, filter := range metadataFilters {
-		parts := strings.SplitN(filter, "=", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid metadata filter format: %s (expected key=value)", filter)
-		}
-		filters[parts[0]] = parts[1]
+	filters, err := ParseMetadataFilters(metadataFilters)
+	if err != nil {
+		return nil, err
 	}
 
-	// Query for documents matching the metadata filters
-	documents, err := wc.queryDocumentsByMetadata(ctx, collectionName, filters)
+	return wc.GetDocumentsByFilter(ctx, collectionName, AllOf(filters...))
+}
+
+// GetDocumentsByFilter gets documents matching a filter expression
+func (wc *WeaveClient) GetDocumentsByFilter(ctx context.Context, collectionName string, filter FilterExpression) ([]Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// Query for documents matching the filter
+	documents, err := wc.queryDocumentsByMetadata(ctx, collectionName, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query documents by metadata: %w", err)
 	}
@@ -402,13 +415,10 @@ func (wc *WeaveClient) DeleteCollectionSchema(ctx context.Context, collectionNam
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete collection schema %s: %w", collectionName, err)
 	}
@@ -430,6 +440,10 @@ func (wc *WeaveClient) DeleteCollectionSchema(ctx context.Context, collectionNam
 
 // deleteCollectionViaGraphQL deletes all objects using GraphQL
 func (wc *WeaveClient) deleteCollectionViaGraphQL(ctx context.Context, collectionName string) error {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return err
+	}
+
 	// Create GraphQL mutation to delete all objects in collection
 	mutation := fmt.Sprintf(`
 		mutation {
@@ -471,13 +485,10 @@ func (wc *WeaveClient) deleteCollectionViaGraphQL(ctx context.Context, collectio
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete collection %s: %w", collectionName, err)
 	}
@@ -555,6 +566,10 @@ func (wc *WeaveClient) deleteCollectionViaREST(ctx context.Context, collectionNa
 
 // getAllObjectsInCollection gets all objects in a collection using GraphQL query
 func (wc *WeaveClient) getAllObjectsInCollection(ctx context.Context, collectionName string) ([]ObjectInfo, error) {
+	if err := ValidateCollectionName(collectionName); err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
 		query {
 			Get {
@@ -591,13 +606,10 @@ func (wc *WeaveClient) getAllObjectsInCollection(ctx context.Context, collection
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query collection %s: %w", collectionName, err)
 	}
@@ -670,12 +682,9 @@ func (wc *WeaveClient) deleteObjectViaREST(ctx context.Context, objectID string)
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -711,15 +720,21 @@ func (wc *WeaveClient) CreateDocument(ctx context.Context, collectionName string
 	}
 	url := fmt.Sprintf("%s/v1/objects", baseURL)
 
+	// Optionally compress image data; the metadata records the encoding
+	imageData, metadata, err := wc.encodeImageData(document)
+	if err != nil {
+		return err
+	}
+
 	// Create the document payload
 	payload := map[string]interface{}{
 		"class": collectionName,
 		"properties": map[string]interface{}{
 			"content":    document.Content,
 			"image":      document.Image,
-			"image_data": document.ImageData,
+			"image_data": imageData,
 			"url":        document.URL,
-			"metadata":   document.Metadata,
+			"metadata":   metadata,
 		},
 	}
 
@@ -735,16 +750,13 @@ func (wc *WeaveClient) CreateDocument(ctx context.Context, collectionName string
 	}
 
 	// Add headers
-	if wc.config.APIKey != "" {
-		req.Header.Set("Authorization", "Bearer "+wc.config.APIKey)
-	}
 	if wc.config.OpenAIAPIKey != "" {
 		req.Header.Set("X-Openai-Api-Key", wc.config.OpenAIAPIKey)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := wc.httpClient.Do(req)
+	resp, err := wc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to create document: %w", err)
 	}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 dr.max
+
+package weaviate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDeleteTestClient starts a server whose DELETE handler returns 404 for the
+// first notFoundCount requests and 204 afterwards
+func newDeleteTestClient(t *testing.T, notFoundCount int32) (*WeaveClient, *int32, *int32) {
+	t.Helper()
+
+	var deletes, gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			if atomic.AddInt32(&deletes, 1) <= notFoundCount {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			// Existence checks fail, as for a just-created document
+			if r.URL.Path == "/v1/graphql" || strings.HasPrefix(r.URL.Path, "/v1/objects") {
+				atomic.AddInt32(&gets, 1)
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewWeaveClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+	return client, &deletes, &gets
+}
+
+// TestDeleteDocumentWithOptions tests eventual-consistency handling for deletes
+func TestDeleteDocumentWithOptions(t *testing.T) {
+	opts := DeleteDocumentOptions{SkipExistenceCheck: true, RetryDelay: time.Millisecond}
+
+	t.Run("retries 404 until the document is visible", func(t *testing.T) {
+		client, deletes, gets := newDeleteTestClient(t, 2)
+
+		err := client.DeleteDocumentWithOptions(context.Background(), "Docs", "doc1", opts)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(deletes))
+		assert.Equal(t, int32(0), atomic.LoadInt32(gets), "existence check should be skipped")
+	})
+
+	t.Run("gives up after the configured retries", func(t *testing.T) {
+		client, deletes, _ := newDeleteTestClient(t, 100)
+
+		retryOpts := opts
+		retryOpts.NotFoundRetries = 2
+		err := client.DeleteDocumentWithOptions(context.Background(), "Docs", "doc1", retryOpts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "document not found")
+		assert.Equal(t, int32(3), atomic.LoadInt32(deletes))
+	})
+
+	t.Run("negative retries disable retrying", func(t *testing.T) {
+		client, deletes, _ := newDeleteTestClient(t, 1)
+
+		noRetryOpts := opts
+		noRetryOpts.NotFoundRetries = -1
+		err := client.DeleteDocumentWithOptions(context.Background(), "Docs", "doc1", noRetryOpts)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(deletes))
+	})
+
+	t.Run("existence check runs by default", func(t *testing.T) {
+		client, deletes, gets := newDeleteTestClient(t, 0)
+
+		err := client.DeleteDocument(context.Background(), "Docs", "doc1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "document not found")
+		assert.Greater(t, atomic.LoadInt32(gets), int32(0))
+		assert.Equal(t, int32(0), atomic.LoadInt32(deletes))
+	})
+}